@@ -0,0 +1,69 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// AccountStatus is the subscription of the organization.
+type AccountStatus struct {
+	// PricingModel is e.g. `credits`.
+	PricingModel  string `json:"pricingModel"`
+	CanUpdatePlan bool   `json:"canUpdatePlan"`
+	// PlanType is `Trial`, `Free`, `Paid` or `Unknown`.
+	PlanType string `json:"planType"`
+	// PlanExpirationDays is the number of days until a trial plan expires.
+	PlanExpirationDays int    `json:"planExpirationDays"`
+	ApplicationUse     string `json:"applicationUse"`
+	AccountActivated   bool   `json:"accountActivated"`
+}
+
+// UsageForecast is the forecast credit usage of the organization until the end of its
+// contract term.
+type UsageForecast struct {
+	// AverageUsage is the average daily credit usage over the requested number of days.
+	AverageUsage float64 `json:"averageUsage"`
+	// UsagePercentage is the percentage of TotalCredits used so far.
+	UsagePercentage float64 `json:"usagePercentage"`
+	// ForecastedUsage is the total credits forecast to be used by the end of the term.
+	ForecastedUsage           float64 `json:"forecastedUsage"`
+	ForecastedUsagePercentage float64 `json:"forecastedUsagePercentage"`
+	TotalCredits              float64 `json:"totalCredits"`
+}
+
+// GetAccountOwner returns the email address of the owner of the organization.
+func (s *Client) GetAccountOwner() (string, error) {
+	var owner string
+	if _, err := s.do("GET", "account/accountOwner", nil, &owner); err != nil {
+		return "", err
+	}
+	return owner, nil
+}
+
+// GetAccountStatus returns the subscription of the organization.
+func (s *Client) GetAccountStatus() (*AccountStatus, error) {
+	var status = new(AccountStatus)
+	if _, err := s.do("GET", "account/status", nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetUsageForecast returns the forecast credit usage of the organization, based on the
+// average usage over the last numberOfDays days. Zero uses the API's default of 30 days.
+// It's only available to organizations on the credits pricing model.
+func (s *Client) GetUsageForecast(numberOfDays int) (*UsageForecast, error) {
+	if numberOfDays < 0 {
+		return nil, &ValidationError{Field: "numberOfDays", Message: fmt.Sprintf("must not be negative, got %d", numberOfDays)}
+	}
+	path := "account/usageForecast"
+	if numberOfDays > 0 {
+		path += "?" + url.Values{"numberOfDays": {strconv.Itoa(numberOfDays)}}.Encode()
+	}
+	var forecast = new(UsageForecast)
+	if _, err := s.do("GET", path, nil, forecast); err != nil {
+		return nil, err
+	}
+	return forecast, nil
+}
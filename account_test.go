@@ -0,0 +1,47 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccount(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/account/accountOwner":
+			w.Write([]byte(`"owner@example.com"`))
+		case "/account/status":
+			w.Write([]byte(`{"pricingModel":"credits","canUpdatePlan":true,"planType":"Paid","planExpirationDays":0,"applicationUse":"Observability","accountActivated":true}`))
+		case "/account/usageForecast":
+			if r.URL.Query().Get("numberOfDays") != "7" {
+				t.Errorf("Expected numberOfDays ‘7’, got ‘%s’", r.URL.Query().Get("numberOfDays"))
+			}
+			w.Write([]byte(`{"averageUsage":12.5,"usagePercentage":40,"forecastedUsage":4562.5,"forecastedUsagePercentage":91.25,"totalCredits":5000}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if owner, err := c.GetAccountOwner(); err != nil || owner != "owner@example.com" {
+		t.Errorf("GetAccountOwner() returned ‘%s’ and %v", owner, err)
+	}
+	status, err := c.GetAccountStatus()
+	if err != nil || status.PricingModel != "credits" || status.PlanType != "Paid" || !status.AccountActivated {
+		t.Errorf("GetAccountStatus() returned %+v and %v", status, err)
+	}
+	forecast, err := c.GetUsageForecast(7)
+	if err != nil || forecast.TotalCredits != 5000 || forecast.ForecastedUsagePercentage != 91.25 {
+		t.Errorf("GetUsageForecast() returned %+v and %v", forecast, err)
+	}
+	if _, err := c.GetUsageForecast(-1); err == nil {
+		t.Errorf("GetUsageForecast() expected an error for a negative number of days")
+	}
+}
@@ -0,0 +1,86 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultArchiveIngestionJob = ArchiveIngestionJob{
+	ID:        "0000000000000F01",
+	Name:      "incident-1234",
+	StartTime: "2020-05-01T00:00:00Z",
+	EndTime:   "2020-05-02T00:00:00Z",
+	Status:    ArchiveJobPending,
+}
+
+func TestArchiveIngestionJobs(t *testing.T) {
+	var deleted bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/archive/2/jobs":
+			body, _ := io.ReadAll(r.Body)
+			j := new(ArchiveIngestionJob)
+			if err := json.Unmarshal(body, &j); err != nil || j.Name != defaultArchiveIngestionJob.Name {
+				t.Errorf("Unexpected ArchiveIngestionJob, got `%s`", body)
+			}
+			js, _ := json.Marshal(defaultArchiveIngestionJob)
+			w.Write(js)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/archive/2/jobs":
+			succeeded := defaultArchiveIngestionJob
+			succeeded.Status = ArchiveJobSucceeded
+			js, _ := json.Marshal(map[string]interface{}{"data": []ArchiveIngestionJob{succeeded}})
+			w.Write(js)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/archive/2/jobs/"+defaultArchiveIngestionJob.ID:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	job := defaultArchiveIngestionJob
+	job.ID, job.Status = "", ""
+	created, err := c.CreateArchiveIngestionJob(2, job)
+	if err != nil || created.ID != defaultArchiveIngestionJob.ID || created.Done() {
+		t.Errorf("CreateArchiveIngestionJob() returned %+v and %v", created, err)
+		return
+	}
+	if created.SourceID != 2 || !created.Equivalent(job) {
+		t.Errorf("CreateArchiveIngestionJob() expected a job equivalent to the request on source 2, got %+v", created)
+	}
+
+	jobs, err := c.ListArchiveIngestionJobs(2)
+	if err != nil || len(jobs) != 1 || !jobs[0].Done() || jobs[0].Endpoint() != "archive/2/jobs/"+defaultArchiveIngestionJob.ID {
+		t.Errorf("ListArchiveIngestionJobs() returned %+v and %v", jobs, err)
+	}
+
+	if err := c.DeleteArchiveIngestionJob(2, defaultArchiveIngestionJob.ID); err != nil || !deleted {
+		t.Errorf("DeleteArchiveIngestionJob() returned %v, deleted %t", err, deleted)
+	}
+	if _, err := c.ListArchiveIngestionJobs(3); err != ErrArchiveIngestionJobNotFound {
+		t.Errorf("ListArchiveIngestionJobs() expected ErrArchiveIngestionJobNotFound, got %v", err)
+	}
+}
+
+func TestArchiveIngestionJobValidate(t *testing.T) {
+	j := defaultArchiveIngestionJob
+	j.EndTime = j.StartTime
+	if verr, ok := j.Validate().(*ValidationError); !ok || verr.Field != "endTime" {
+		t.Errorf("Validate() expected an endTime error, got %v", j.Validate())
+	}
+	j = defaultArchiveIngestionJob
+	j.StartTime = "yesterday"
+	if verr, ok := j.Validate().(*ValidationError); !ok || verr.Field != "startTime" {
+		t.Errorf("Validate() expected a startTime error, got %v", j.Validate())
+	}
+}
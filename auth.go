@@ -0,0 +1,44 @@
+package sumologic
+
+// WithAuth returns a client that authenticates with authToken instead of the client's own
+// token, for using least-privilege access keys per subsystem from one process, e.g.
+//
+//	content, err := client.WithAuth(contentToken).ExportContent(ctx, folderID)
+//
+// The returned client shares the client's endpoint, HTTP client, options and metrics, and
+// is cheap enough to create per call.
+func (s *Client) WithAuth(authToken string) *Client {
+	return &Client{
+		AuthToken:          authToken,
+		EndpointURL:        s.EndpointURL,
+		httpClient:         s.httpClient,
+		middleware:         s.middleware,
+		features:           s.features,
+		maxConcurrency:     s.maxConcurrency,
+		changeRecorder:     s.changeRecorder,
+		changeRedactions:   s.changeRedactions,
+		maskSourceURLs:     s.maskSourceURLs,
+		responseHook:       s.responseHook,
+		transientRetry:     s.transientRetry,
+		conflictRetry:      s.conflictRetry,
+		requestPolicies:    s.requestPolicies,
+		etagStore:          s.etagStore,
+		preMutationHooks:   s.preMutationHooks,
+		postMutationHooks:  s.postMutationHooks,
+		jobPolling:         s.jobPolling,
+		failover:           s.failover,
+		deprecationHandler: s.deprecationHandler,
+		parent:             s.root(),
+
+		methodDeprecationHandler:  s.methodDeprecationHandler,
+		silenceMethodDeprecations: s.silenceMethodDeprecations,
+	}
+}
+
+// root returns the client that s was derived from with WithAuth, or s itself.
+func (s *Client) root() *Client {
+	if s.parent != nil {
+		return s.parent
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithAuth(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"collector":{"id":1,"name":"collector"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("defaultToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, _, err := c.WithAuth("collectorToken").GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+
+	expected := []string{"Basic collectorToken", "Basic defaultToken"}
+	if len(tokens) != 2 || tokens[0] != expected[0] || tokens[1] != expected[1] {
+		t.Errorf("WithAuth() expected Authorization headers %v, got %v", expected, tokens)
+	}
+	if calls := c.Metrics()["GET collectors/{id}"].Calls; calls != 2 {
+		t.Errorf("WithAuth() expected calls to be recorded on the parent client, got %d calls", calls)
+	}
+	if c.AuthToken != "defaultToken" {
+		t.Errorf("WithAuth() changed the client's token to ‘%s’", c.AuthToken)
+	}
+}
@@ -0,0 +1,87 @@
+package sumologic
+
+import (
+	"context"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// WithConflictRetry makes the Auto update methods, such as UpdateHostedCollectorAuto, retry
+// updates that fail with ErrPreconditionFailed according to policy, re-reading the ETag
+// before every attempt. Without it they make a single attempt.
+//
+// A retried update overwrites the concurrent change that caused the conflict, so policy
+// should only be set where the caller's definition is meant to win.
+func WithConflictRetry(policy backoff.Policy) ClientOption {
+	return func(s *Client) error {
+		s.conflictRetry = &policy
+		return nil
+	}
+}
+
+// UpdateHostedCollectorAuto updates the hosted collector with the ID of collector without the
+// caller having to Get it first: the current ETag is read and sent with the update. A
+// concurrent change between the two fails with ErrPreconditionFailed, or is retried with
+// WithConflictRetry.
+func (s *Client) UpdateHostedCollectorAuto(collector Collector) (*Collector, error) {
+	var updated *Collector
+	err := s.retryConflicts("PUT collectors/{id}", func() error {
+		_, etag, err := s.GetHostedCollector(collector.ID)
+		if err != nil {
+			return err
+		}
+		updated, err = s.UpdateHostedCollector(collector, etag)
+		return err
+	})
+	return updated, err
+}
+
+// UpdateInstalledCollectorAuto updates the installed collector with the ID of collector, like
+// UpdateHostedCollectorAuto.
+func (s *Client) UpdateInstalledCollectorAuto(collector InstalledCollector) (*InstalledCollector, error) {
+	var updated *InstalledCollector
+	err := s.retryConflicts("PUT collectors/{id}", func() error {
+		_, etag, err := s.GetInstalledCollector(collector.ID)
+		if err != nil {
+			return err
+		}
+		updated, err = s.UpdateInstalledCollector(collector, etag)
+		return err
+	})
+	return updated, err
+}
+
+// UpdateHTTPSourceAuto updates the HTTP source with the ID of source on the collector with
+// the specified ID, like UpdateHostedCollectorAuto.
+func (s *Client) UpdateHTTPSourceAuto(collectorID int, source HTTPSource) (*HTTPSource, error) {
+	var updated *HTTPSource
+	err := s.retryConflicts("PUT collectors/{id}/sources/{id}", func() error {
+		_, etag, err := s.GetHTTPSource(collectorID, source.ID)
+		if err != nil {
+			return err
+		}
+		updated, err = s.UpdateHTTPSource(collectorID, source, etag)
+		return err
+	})
+	return updated, err
+}
+
+// retryConflicts calls update, which reads a resource's ETag and updates it, once, or with
+// WithConflictRetry until it doesn't fail with ErrPreconditionFailed. Retries are counted in
+// the metrics of endpoint.
+func (s *Client) retryConflicts(endpoint string, update func() error) error {
+	if s.conflictRetry == nil {
+		return update()
+	}
+	attempts := 0
+	return s.conflictRetry.Retry(context.Background(), func() error {
+		if attempts++; attempts > 1 {
+			s.root().metrics.recordRetry(endpoint)
+		}
+		err := update()
+		if err != ErrPreconditionFailed {
+			return backoff.Permanent(err)
+		}
+		return err
+	})
+}
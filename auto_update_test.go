@@ -0,0 +1,109 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// conflictServer serves a collector whose first conflicts updates fail with 412, as if
+// another client changed it after each Get.
+type conflictServer struct {
+	t         *testing.T
+	conflicts int
+	gets      int
+	puts      int
+}
+
+func (f *conflictServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.EscapedPath() != "/collectors/1" {
+		f.t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+		return
+	}
+	switch r.Method {
+	case "GET":
+		f.gets++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, f.gets))
+		body, _ := json.Marshal(CollectorRequest{Collector: Collector{ID: 1, Name: "collector", CollectorType: "Hosted"}})
+		w.Write(body)
+	case "PUT":
+		f.puts++
+		if expected := fmt.Sprintf(`"v%d"`, f.gets); r.Header.Get("If-Match") != expected {
+			f.t.Errorf("Expected If-Match ‘%s’, got ‘%s’", expected, r.Header.Get("If-Match"))
+		}
+		if f.puts <= f.conflicts {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		var cr CollectorRequest
+		json.NewDecoder(r.Body).Decode(&cr)
+		body, _ := json.Marshal(cr)
+		w.Write(body)
+	}
+}
+
+func TestUpdateHostedCollectorAuto(t *testing.T) {
+	fake := &conflictServer{t: t}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, err := c.UpdateHostedCollectorAuto(Collector{ID: 1, Name: "collector", CollectorType: "Hosted", Description: "updated"})
+	if err != nil {
+		t.Errorf("UpdateHostedCollectorAuto() returned an error: %s", err)
+		return
+	}
+	if collector.Description != "updated" || fake.gets != 1 || fake.puts != 1 {
+		t.Errorf("UpdateHostedCollectorAuto() expected one Get and one update, got %d, %d and %+v", fake.gets, fake.puts, collector)
+	}
+}
+
+func TestUpdateHostedCollectorAutoConflict(t *testing.T) {
+	fake := &conflictServer{t: t, conflicts: 1}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector := Collector{ID: 1, Name: "collector", CollectorType: "Hosted"}
+	if _, err := c.UpdateHostedCollectorAuto(collector); err != ErrPreconditionFailed {
+		t.Errorf("UpdateHostedCollectorAuto() expected ErrPreconditionFailed without retries, got %v", err)
+	}
+}
+
+func TestUpdateHostedCollectorAutoConflictRetry(t *testing.T) {
+	fake := &conflictServer{t: t, conflicts: 2}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithConflictRetry(backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.UpdateHostedCollectorAuto(Collector{ID: 1, Name: "collector", CollectorType: "Hosted"}); err != nil {
+		t.Errorf("UpdateHostedCollectorAuto() returned an error: %s", err)
+		return
+	}
+	if fake.gets != 3 || fake.puts != 3 {
+		t.Errorf("UpdateHostedCollectorAuto() expected 3 attempts, got %d Gets and %d updates", fake.gets, fake.puts)
+	}
+	if retries := c.Metrics()["PUT collectors/{id}"].Retries; retries != 2 {
+		t.Errorf("UpdateHostedCollectorAuto() expected 2 retries in the metrics, got %d", retries)
+	}
+}
@@ -1,13 +1,12 @@
 package sumologic
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
+	"reflect"
 	"regexp"
+	"strings"
 )
 
 // AWSLogSource is a necessary wrapper for source API calls.
@@ -16,24 +15,50 @@ type AWSLogSourceRequest struct {
 }
 
 // AWSLogSource can various types of sources including Cloudtrail and S3.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value (e.g. to unpause a
+// source), list the field's JSON name in ForceSendFields.
 type AWSLogSource struct {
-	ID                         int                    `json:"id,omitempty"`
-	Name                       string                 `json:"name"`
-	CollectorID                int                    `json:"CollectorId,omitempty"`
-	Description                string                 `json:"description,omitempty"`
-	Category                   string                 `json:"category,omitempty"`
-	TimeZone                   string                 `json:"timezone,omitempty"`
-	SourceType                 string                 `json:"sourceType,omitempty"`
-	ContentType                string                 `json:"contentType,omitempty"`
-	ScanInterval               int                    `json:"scanInterval,omitempty"`
-	Paused                     bool                   `json:"paused"`
-	CutoffRelativeTime         string                 `json:"cutoffRelativeTime,omitempty"`
-	MultilineProcessingEnabled bool                   `json:"multilineProcessingEnabled"`
-	UseAutolineMatching        bool                   `json:"useAutolineMatching"`
-	ManualPrefixRegexp         string                 `json:"manualPrefixRegexp,omitempty"`
-	Url                        string                 `json:"url,omitempty"`
-	ThirdPartyRef              AWSBucketThirdPartyRef `json:"thirdPartyRef,omitempty"`
-	Filters                    []Filter               `json:"filters,omitempty"`
+	ID                         int          `json:"id,omitempty"`
+	Name                       string       `json:"name"`
+	CollectorID                int          `json:"CollectorId,omitempty"`
+	Description                string       `json:"description,omitempty"`
+	Category                   string       `json:"category,omitempty"`
+	TimeZone                   string       `json:"timezone,omitempty"`
+	SourceType                 string       `json:"sourceType,omitempty"`
+	ContentType                string       `json:"contentType,omitempty"`
+	ScanInterval               int          `json:"scanInterval,omitempty"`
+	Paused                     bool         `json:"paused,omitempty"`
+	CutoffRelativeTime         string       `json:"cutoffRelativeTime,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	// Url is generated by Sumo Logic. For AWSSetupModeSNS sources, it's the HTTPS endpoint to
+	// subscribe the SNS topic to.
+	Url           string                 `json:"url,omitempty"`
+	ThirdPartyRef AWSBucketThirdPartyRef `json:"thirdPartyRef,omitempty"`
+	Filters       []Filter               `json:"filters,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s AWSLogSource) MarshalJSON() ([]byte, error) {
+	type awsLogSource AWSLogSource
+	return marshalForceSend(awsLogSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *AWSLogSource) UnmarshalJSON(data []byte) error {
+	type awsLogSource AWSLogSource
+	return unmarshalLenient(data, (*awsLogSource)(s))
 }
 
 type AWSBucketThirdPartyRef struct {
@@ -49,177 +74,298 @@ type AWSBucketResource struct {
 
 // AWSBucketPath contains AWS S3 Bucket configuration.
 type AWSBucketPath struct {
-	Type           string `json:"type"`
-	BucketName     string `json:"bucketName"`
-	PathExpression string `json:"pathExpression"`
+	Type                      string                        `json:"type"`
+	BucketName                string                        `json:"bucketName"`
+	PathExpression            string                        `json:"pathExpression"`
+	SNSTopicOrSubscriptionARN *AWSSNSTopicOrSubscriptionARN `json:"snsTopicOrSubscriptionArn,omitempty"`
 }
 
+// AWSSNSTopicOrSubscriptionARN configures S3 event notifications delivered through SNS.
+type AWSSNSTopicOrSubscriptionARN struct {
+	// ARN is the SNS topic to receive notifications from, or once the source's Url has been
+	// subscribed to the topic, the subscription's ARN as reported by Sumo Logic.
+	ARN string `json:"arn,omitempty"`
+	// IsSuccess is set by Sumo Logic once the subscription is confirmed.
+	IsSuccess bool `json:"isSuccess,omitempty"`
+}
+
+// Content types of AWSLogSource, for AWSLogSource.ContentType and the ServiceType of its
+// resources.
+const (
+	// AWSContentTypeS3Bucket is for generic logs stored in S3.
+	AWSContentTypeS3Bucket = "AwsS3Bucket"
+	// AWSContentTypeCloudTrail is for CloudTrail logs.
+	AWSContentTypeCloudTrail = "AwsCloudTrailBucket"
+	// AWSContentTypeELB is for Elastic Load Balancing (classic and application) access logs.
+	AWSContentTypeELB = "AwsElbBucket"
+	// AWSContentTypeS3Audit is for S3 server access logs.
+	AWSContentTypeS3Audit = "AwsS3AuditBucket"
+	// AWSContentTypeCloudFront is for CloudFront access logs.
+	AWSContentTypeCloudFront = "AwsCloudFrontBucket"
+	// AWSContentTypeS3Archive is for data archived to S3 by Sumo Logic, which isn't
+	// collected automatically but replayed with CreateArchiveIngestionJob.
+	AWSContentTypeS3Archive = "AwsS3ArchiveBucket"
+)
+
+var awsContentTypes = []string{
+	AWSContentTypeS3Bucket, AWSContentTypeCloudTrail, AWSContentTypeELB, AWSContentTypeS3Audit,
+	AWSContentTypeCloudFront, AWSContentTypeS3Archive,
+}
+
+// AWSSetupMode is how Sumo Logic discovers new objects in the bucket of an AWSLogSource.
+type AWSSetupMode string
+
+const (
+	// AWSSetupModePolling scans the bucket for new objects every ScanInterval milliseconds
+	// (or the Sumo Logic default if ScanInterval is unset).
+	AWSSetupModePolling AWSSetupMode = "Polling"
+	// AWSSetupModeSNS receives S3 event notifications through the SNS topic or subscription
+	// set in AWSBucketPath.SNSTopicOrSubscriptionARN. ScanInterval must be unset.
+	AWSSetupModeSNS AWSSetupMode = "SNS"
+)
+
 // AWSBucketAuthentication contains AWS authentication configurartion.
 type AWSBucketAuthentication struct {
 	Type    string `json:"type"`
 	RoleARN string `json:"roleARN"`
 }
 
-// GetAWSLogSource gets the source with the specified ID.
-func (s *Client) GetAWSLogSource(collectorID int, id int) (*AWSLogSource, string, error) {
+// SetupMode returns AWSSetupModeSNS if any resource path configures an SNS topic or
+// subscription and AWSSetupModePolling otherwise.
+func (s AWSLogSource) SetupMode() AWSSetupMode {
+	if s.SNSSubscription() != nil {
+		return AWSSetupModeSNS
+	}
+	return AWSSetupModePolling
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, id))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// SNSSubscription returns the SNS topic or subscription of the first resource path that has
+// one, or nil for AWSSetupModePolling sources. After a create, subscribe the topic to Url and
+// Get the source until IsSuccess reports the subscription as confirmed.
+func (s AWSLogSource) SNSSubscription() *AWSSNSTopicOrSubscriptionARN {
+	for _, r := range s.ThirdPartyRef.Resources {
+		if r.Path.SNSTopicOrSubscriptionARN != nil {
+			return r.Path.SNSTopicOrSubscriptionARN
+		}
+	}
+	return nil
+}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+// Validate checks that the fields set on the source are consistent with its content type and
+// setup mode. A *ValidationError naming the offending field is returned otherwise.
+func (s AWSLogSource) Validate() error {
+	if s.ScanInterval < 0 {
+		return &ValidationError{Field: "scanInterval", Message: "must not be negative"}
+	}
+	if err := s.validateContentType(); err != nil {
+		return err
+	}
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	if s.SetupMode() != AWSSetupModeSNS {
+		return nil
+	}
+	if s.ScanInterval != 0 {
+		return &ValidationError{Field: "scanInterval", Message: "must be unset when snsTopicOrSubscriptionArn is configured; polling and SNS notifications can't be combined"}
+	}
+	for _, r := range s.ThirdPartyRef.Resources {
+		sns := r.Path.SNSTopicOrSubscriptionARN
+		if sns == nil {
+			return &ValidationError{Field: "thirdPartyRef.resources.path.snsTopicOrSubscriptionArn", Message: "must be configured on every resource when any resource uses SNS"}
+		}
+		if sns.ARN != "" && !strings.HasPrefix(sns.ARN, "arn:aws:sns:") {
+			return &ValidationError{Field: "thirdPartyRef.resources.path.snsTopicOrSubscriptionArn.arn", Message: fmt.Sprintf("`%s` is not an SNS topic or subscription ARN", sns.ARN)}
+		}
+	}
+	return nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, "", err
+// validateContentType checks that ContentType, if set, is one of the AWSContentType constants
+// and that the resources are of the same type.
+func (s AWSLogSource) validateContentType() error {
+	if s.ContentType == "" {
+		return nil
+	}
+	valid := false
+	for _, contentType := range awsContentTypes {
+		valid = valid || s.ContentType == contentType
 	}
-	defer resp.Body.Close()
+	if !valid {
+		return &ValidationError{Field: "contentType", Message: fmt.Sprintf("`%s` must be one of `%s`", s.ContentType, strings.Join(awsContentTypes, "`, `"))}
+	}
+	for _, r := range s.ThirdPartyRef.Resources {
+		if r.ServiceType != "" && r.ServiceType != s.ContentType {
+			return &ValidationError{Field: "thirdPartyRef.resources.serviceType", Message: fmt.Sprintf("`%s` must match contentType `%s`", r.ServiceType, s.ContentType)}
+		}
+	}
+	return nil
+}
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID, URL and SNS subscription status) are ignored.
+func (s AWSLogSource) Equivalent(other AWSLogSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var r = new(AWSLogSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, "", err
+func (s AWSLogSource) userManaged() AWSLogSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Url = ""
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.ThirdPartyRef.Resources) == 0 {
+		s.ThirdPartyRef.Resources = nil
+	} else {
+		resources := make([]AWSBucketResource, len(s.ThirdPartyRef.Resources))
+		for i, r := range s.ThirdPartyRef.Resources {
+			if sns := r.Path.SNSTopicOrSubscriptionARN; sns != nil {
+				r.Path.SNSTopicOrSubscriptionARN = &AWSSNSTopicOrSubscriptionARN{ARN: sns.ARN}
+			}
+			resources[i] = r
 		}
+		s.ThirdPartyRef.Resources = resources
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
 
-		return &r.Source, resp.Header.Get("ETag"), nil
-	case http.StatusUnauthorized:
-		return nil, "", ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, "", ErrSourceNotFound
-	default:
-		return nil, "", fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+// GetAWSLogSource gets the source with the specified ID.
+func (s *Client) GetAWSLogSource(collectorID int, id int) (*AWSLogSource, string, error) {
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	var r = new(AWSLogSourceRequest)
+	resp, err := s.do("GET", path, nil, r)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
 	}
+
+	return &r.Source, resp.Header.Get("ETag"), nil
 }
 
 // CreateAWSLogSource creates a new AWSLogSource.
 func (s *Client) CreateAWSLogSource(collectorID int, source AWSLogSource) (*AWSLogSource, error) {
-
-	request := AWSLogSourceRequest{
-		Source: source,
+	if err := source.Validate(); err != nil {
+		return nil, err
 	}
 
-	body, _ := json.Marshal(request)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources", collectorID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-
-	resp, err := http.DefaultClient.Do(req)
+	path, err := formatPath("collectors/%d/sources", collectorID)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var r = new(AWSLogSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, err
-		}
-
-		return &r.Source, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		var e = new(Error)
-		err = json.Unmarshal(responseBody, &e)
-		if err != nil {
-			return nil, fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", source.Name)
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeSource, nil, collectorID, source); err != nil {
+		return nil, err
+	}
+	var r = new(AWSLogSourceRequest)
+	if _, err := s.do("POST", path, AWSLogSourceRequest{Source: source}, r); err != nil {
+		e, ok := badRequest(err)
+		if !ok {
+			return nil, sourceBadRequest(err, source.Name)
 		}
-		if e.Message == "Cannot authenticate with AWS." ||
-			e.Message == "Invalid IAM role: 'errorCode=AccessDenied'." {
+		if isAWSAuthenticationError(e) {
 			return nil, ErrAwsAuthenticationError
 		}
 		if matched, _ := regexp.MatchString("The S3 bucket 'bucketName=.*' is not readable.", e.Message); matched {
 			return nil, ErrAwsAuthenticationError
 		}
-		return nil, fmt.Errorf("Bad Request. %s", e.Message)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+		if err := planLimitError(ResourceTypeSource, e); err != nil {
+			return nil, err
+		}
+		return nil, err
 	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
 }
 
 // UpdateAWSLogSource updates an existing AWS Bucket source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
 func (s *Client) UpdateAWSLogSource(collectorID int, source AWSLogSource, etag string) (*AWSLogSource, error) {
-	request := AWSLogSourceRequest{
-		Source: source,
+	etag, err := s.resolveETag(etag, "collectors/%d/sources/%d", collectorID, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
 	}
 
-	body, _ := json.Marshal(request)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, source.ID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-	req.Header.Add("If-Match", etag)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, source.ID)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeSource, source.ID, collectorID, source); err != nil {
+		return nil, err
+	}
+	var r = new(AWSLogSourceRequest)
+	if _, err := s.doIfMatch("PUT", path, etag, AWSLogSourceRequest{Source: source}, r); err != nil {
+		if e, ok := badRequest(err); ok && isAWSAuthenticationError(e) {
+			return nil, ErrAwsAuthenticationError
+		}
+		return nil, sourceBadRequest(err, source.Name)
+	}
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var r = new(AWSLogSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, err
-		}
+// isAWSAuthenticationError reports whether e is returned because Sumo Logic couldn't assume
+// the source's IAM role.
+func isAWSAuthenticationError(e *Error) bool {
+	return e.Message == "Cannot authenticate with AWS." ||
+		e.Message == "Invalid IAM role: 'errorCode=AccessDenied'."
+}
 
-		return &r.Source, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		var e = new(Error)
-		err = json.Unmarshal(responseBody, &e)
-		if e.Message == "Cannot authenticate with AWS." ||
-			e.Message == "Invalid IAM role: 'errorCode=AccessDenied'." {
-			return nil, ErrAwsAuthenticationError
-		}
-		return nil, fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", source.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+// awsAuthenticationError returns ErrAwsAuthenticationError if err is a 400 response saying
+// that Sumo Logic can't authenticate with AWS, and err otherwise.
+func awsAuthenticationError(err error) error {
+	if e, ok := badRequest(err); ok && isAWSAuthenticationError(e) {
+		return ErrAwsAuthenticationError
 	}
+	return err
 }
 
 // DeleteAWSLogSource deletes the source with the specified ID.
 func (s *Client) DeleteAWSLogSource(collectorID int, id int) error {
-	c, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, id))
-	req, err := http.NewRequest("DELETE", s.EndpointURL.ResolveReference(c).String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+// DeleteAWSLogSourceWithETag deletes the source with the specified ID only if it hasn't changed since
+// the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteAWSLogSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
 	}
-	defer resp.Body.Close()
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
+// DeleteAWSLogSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteAWSLogSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteAWSLogSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteAWSLogSource(collectorID, id)
+	if err == ErrSourceNotFound {
 		return nil
-	case http.StatusNotFound:
-		return ErrSourceNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
 	}
+	return err
 }
@@ -3,7 +3,7 @@ package sumologic
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -88,7 +88,7 @@ func TestCreateAWSLogSourceOK(t *testing.T) {
 		if ctype := r.Header.Get("Content-Type"); ctype != "application/json" {
 			t.Errorf("Expected response to be content-type ‘application/json’, got ‘%s’", ctype)
 		}
-		body, _ := ioutil.ReadAll(r.Body)
+		body, _ := io.ReadAll(r.Body)
 		sr := new(AWSLogSourceRequest)
 		err := json.Unmarshal(body, &sr)
 		if err != nil {
@@ -282,3 +282,174 @@ func TestDeleteAWSLogSourceDoesntExist(t *testing.T) {
 		return
 	}
 }
+
+func TestAWSLogSourceEquivalent(t *testing.T) {
+	live := defaultAWSLogSource
+	live.Url = "https://api.sumologic.com/api/v1/collectors/1234567890/sources/1234567890"
+
+	desired := AWSLogSource{
+		Name: "test",
+	}
+	if !desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected sources differing only in server-managed fields to be equivalent")
+	}
+
+	desired.ThirdPartyRef.Resources = []AWSBucketResource{{ServiceType: "AwsCloudTrailBucket"}}
+	if desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected sources with different resources to differ")
+	}
+}
+
+func TestDeleteAWSLogSourceIfExistsDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d/sources/%d", defaultAWSLogSource.CollectorID, defaultAWSLogSource.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteAWSLogSourceIfExists(defaultAWSLogSource.CollectorID, defaultAWSLogSource.ID)
+	if err != nil {
+		t.Errorf("DeleteAWSLogSourceIfExists() returned an error: %s", err)
+		return
+	}
+}
+
+func TestAWSLogSourceValidateSetupMode(t *testing.T) {
+	sns := func(arn string) AWSBucketResource {
+		return AWSBucketResource{Path: AWSBucketPath{SNSTopicOrSubscriptionARN: &AWSSNSTopicOrSubscriptionARN{ARN: arn}}}
+	}
+
+	polling := AWSLogSource{Name: "test", ScanInterval: 300000}
+	if polling.SetupMode() != AWSSetupModePolling {
+		t.Errorf("SetupMode() expected `%s`, got `%s`", AWSSetupModePolling, polling.SetupMode())
+	}
+	if err := polling.Validate(); err != nil {
+		t.Errorf("Validate() returned an error for a polling source: %s", err)
+	}
+
+	notified := AWSLogSource{Name: "test"}
+	notified.ThirdPartyRef.Resources = []AWSBucketResource{sns("arn:aws:sns:us-east-1:123456789012:topic")}
+	if notified.SetupMode() != AWSSetupModeSNS {
+		t.Errorf("SetupMode() expected `%s`, got `%s`", AWSSetupModeSNS, notified.SetupMode())
+	}
+	if err := notified.Validate(); err != nil {
+		t.Errorf("Validate() returned an error for an SNS source: %s", err)
+	}
+
+	cases := map[string]AWSLogSource{
+		"scanInterval": {ScanInterval: 300000, ThirdPartyRef: AWSBucketThirdPartyRef{Resources: []AWSBucketResource{sns("")}}},
+		"thirdPartyRef.resources.path.snsTopicOrSubscriptionArn.arn": {ThirdPartyRef: AWSBucketThirdPartyRef{Resources: []AWSBucketResource{sns("arn:aws:s3:::bucket")}}},
+		"thirdPartyRef.resources.path.snsTopicOrSubscriptionArn":     {ThirdPartyRef: AWSBucketThirdPartyRef{Resources: []AWSBucketResource{sns(""), {}}}},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+}
+
+func TestAWSLogSourceValidateContentType(t *testing.T) {
+	for _, contentType := range []string{AWSContentTypeS3Audit, AWSContentTypeELB} {
+		source := AWSLogSource{Name: "test", ContentType: contentType}
+		source.ThirdPartyRef.Resources = []AWSBucketResource{{ServiceType: contentType}}
+		if err := source.Validate(); err != nil {
+			t.Errorf("Validate() returned an error for `%s`: %s", contentType, err)
+		}
+	}
+
+	cases := map[string]AWSLogSource{
+		"contentType":                         {ContentType: "AwsS3AuditLogs"},
+		"thirdPartyRef.resources.serviceType": {ContentType: AWSContentTypeS3Audit, ThirdPartyRef: AWSBucketThirdPartyRef{Resources: []AWSBucketResource{{ServiceType: AWSContentTypeELB}}}},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+}
+
+func TestCreateAWSLogSourceInvalidSetupMode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request for an invalid source, got ‘%s %s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source := AWSLogSource{Name: "test", ScanInterval: 300000}
+	source.ThirdPartyRef.Resources = []AWSBucketResource{{
+		Path: AWSBucketPath{SNSTopicOrSubscriptionARN: &AWSSNSTopicOrSubscriptionARN{}},
+	}}
+	_, err = c.CreateAWSLogSource(defaultAWSLogSource.CollectorID, source)
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("CreateAWSLogSource() expected a ValidationError, got %v", err)
+	}
+}
+
+func TestAWSLogSourceForceSendFields(t *testing.T) {
+	body, _ := json.Marshal(AWSLogSource{Name: "test"})
+	var fields map[string]interface{}
+	json.Unmarshal(body, &fields)
+	for _, name := range []string{"paused", "multilineProcessingEnabled", "useAutolineMatching", "cutoffRelativeTime"} {
+		if _, ok := fields[name]; ok {
+			t.Errorf("Expected unset field `%s` to be omitted, got `%s`", name, body)
+		}
+	}
+
+	body, _ = json.Marshal(AWSLogSource{Name: "test", ForceSendFields: []string{"paused"}})
+	fields = nil
+	json.Unmarshal(body, &fields)
+	if paused, ok := fields["paused"]; !ok || paused != false {
+		t.Errorf("Expected `\"paused\": false` with ForceSendFields, got `%s`", body)
+	}
+	if _, ok := fields["ForceSendFields"]; ok {
+		t.Errorf("Expected ForceSendFields not to be serialized, got `%s`", body)
+	}
+}
+
+func TestAWSLogSourceSNSSubscription(t *testing.T) {
+	if sub := (AWSLogSource{Name: "test"}).SNSSubscription(); sub != nil {
+		t.Errorf("SNSSubscription() expected nil for a polling source, got %+v", sub)
+	}
+
+	var created AWSLogSource
+	err := json.Unmarshal([]byte(`{"id":1,"name":"cloudtrail","contentType":"AwsCloudTrailBucket","url":"https://endpoint1.collection.sumologic.com/receiver/v1/event/secret",
+		"thirdPartyRef":{"resources":[{"serviceType":"AwsCloudTrailBucket","path":{"type":"S3BucketPathExpression","bucketName":"trail","pathExpression":"*",
+		"snsTopicOrSubscriptionArn":{"arn":"arn:aws:sns:us-east-1:123456789012:trail:0f5e","isSuccess":true}}}]}}`), &created)
+	if err != nil {
+		t.Errorf("Unmarshal() returned an error: %s", err)
+		return
+	}
+	sub := created.SNSSubscription()
+	if sub == nil || sub.ARN != "arn:aws:sns:us-east-1:123456789012:trail:0f5e" || !sub.IsSuccess {
+		t.Errorf("SNSSubscription() expected the confirmed subscription, got %+v", sub)
+	}
+
+	desired := created
+	desired.ThirdPartyRef.Resources = []AWSBucketResource{created.ThirdPartyRef.Resources[0]}
+	desired.ThirdPartyRef.Resources[0].Path.SNSTopicOrSubscriptionARN = &AWSSNSTopicOrSubscriptionARN{ARN: sub.ARN}
+	if !desired.Equivalent(created) {
+		t.Errorf("Equivalent() expected the subscription status to be ignored")
+	}
+	if !created.SNSSubscription().IsSuccess {
+		t.Errorf("Equivalent() modified the source's subscription status")
+	}
+}
@@ -0,0 +1,258 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// AWSMetadataSourceRequest is a necessary wrapper for source API calls.
+type AWSMetadataSourceRequest struct {
+	Source AWSMetadataSource `json:"source"`
+}
+
+// AWSMetadataSource is a source on a hosted collector that polls the tags of EC2 instances,
+// which Sumo Logic adds as metadata to the logs and metrics of those instances.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value (e.g. to unpause a
+// source), list the field's JSON name in ForceSendFields.
+type AWSMetadataSource struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name"`
+	CollectorID  int    `json:"CollectorId,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Category     string `json:"category,omitempty"`
+	SourceType   string `json:"sourceType,omitempty"`
+	ContentType  string `json:"contentType,omitempty"`
+	ScanInterval int    `json:"scanInterval,omitempty"`
+	Paused       bool   `json:"paused,omitempty"`
+	// ThirdPartyRef configures the AWS account and regions to poll. The resources' ServiceType
+	// and path type are set by CreateAWSMetadataSource if empty.
+	ThirdPartyRef AWSMetadataThirdPartyRef `json:"thirdPartyRef,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// AWSMetadataThirdPartyRef contains the AWS configuration of an AWSMetadataSource.
+type AWSMetadataThirdPartyRef struct {
+	Resources []AWSMetadataResource `json:"resources,omitempty"`
+}
+
+// AWSMetadataResource contains the AWS configuration of an AWSMetadataSource, including
+// authentication.
+type AWSMetadataResource struct {
+	ServiceType    string                  `json:"serviceType"`
+	Path           AWSMetadataPath         `json:"path"`
+	Authentication AWSBucketAuthentication `json:"authentication"`
+}
+
+// AWSMetadataPath limits the EC2 instances whose tags are polled.
+type AWSMetadataPath struct {
+	Type string `json:"type"`
+	// LimitToRegions lists the AWS regions to poll, e.g. `us-east-1`. Empty means all.
+	LimitToRegions []string `json:"limitToRegions,omitempty"`
+	// LimitToNamespaces lists the namespaces to poll. Only `AWS/EC2` is supported.
+	LimitToNamespaces []string `json:"limitToNamespaces,omitempty"`
+	// TagFilters limits the tags collected to those matching the filters, e.g. `Env=prod` or
+	// `Name=web-*`. Empty means all tags.
+	TagFilters []string `json:"tagFilters,omitempty"`
+}
+
+const (
+	// awsMetadataContentType is the contentType and serviceType of AWS metadata sources.
+	awsMetadataContentType = "AwsMetadata"
+	// awsMetadataPathType is the path type of AWS metadata sources.
+	awsMetadataPathType = "AwsMetadataPath"
+	// awsMetadataNamespace is the only namespace supported by AWS metadata sources.
+	awsMetadataNamespace = "AWS/EC2"
+)
+
+// awsRegionPattern matches AWS region names, e.g. `us-east-1` or `us-gov-west-1`.
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s AWSMetadataSource) MarshalJSON() ([]byte, error) {
+	type awsMetadataSource AWSMetadataSource
+	return marshalForceSend(awsMetadataSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *AWSMetadataSource) UnmarshalJSON(data []byte) error {
+	type awsMetadataSource AWSMetadataSource
+	return unmarshalLenient(data, (*awsMetadataSource)(s))
+}
+
+// Validate checks the source's regions, namespaces and role and the fields validated for all
+// sources, without calling the API. A *ValidationError naming the offending field is
+// returned otherwise.
+func (s AWSMetadataSource) Validate() error {
+	if s.ScanInterval < 0 {
+		return &ValidationError{Field: "scanInterval", Message: "must not be negative"}
+	}
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	for _, r := range s.ThirdPartyRef.Resources {
+		if r.ServiceType != "" && r.ServiceType != awsMetadataContentType {
+			return &ValidationError{Field: "thirdPartyRef.resources.serviceType", Message: fmt.Sprintf("must be `%s`, got `%s`", awsMetadataContentType, r.ServiceType)}
+		}
+		for _, region := range r.Path.LimitToRegions {
+			if !awsRegionPattern.MatchString(region) {
+				return &ValidationError{Field: "thirdPartyRef.resources.path.limitToRegions", Message: fmt.Sprintf("`%s` is not an AWS region, e.g. `us-east-1`", region)}
+			}
+		}
+		for _, namespace := range r.Path.LimitToNamespaces {
+			if namespace != awsMetadataNamespace {
+				return &ValidationError{Field: "thirdPartyRef.resources.path.limitToNamespaces", Message: fmt.Sprintf("must be `%s`, got `%s`", awsMetadataNamespace, namespace)}
+			}
+		}
+		if r.Authentication.RoleARN != "" {
+			if err := ValidateAWSRoleARN(r.Authentication.RoleARN); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID and collector ID) are ignored.
+func (s AWSMetadataSource) Equivalent(other AWSMetadataSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s AWSMetadataSource) userManaged() AWSMetadataSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	if len(s.ThirdPartyRef.Resources) == 0 {
+		s.ThirdPartyRef.Resources = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// withDefaults returns the source with the sourceType, contentType and resource types of AWS
+// metadata sources set where they're empty.
+func (s AWSMetadataSource) withDefaults() AWSMetadataSource {
+	if s.SourceType == "" {
+		s.SourceType = "Polling"
+	}
+	if s.ContentType == "" {
+		s.ContentType = awsMetadataContentType
+	}
+	resources := make([]AWSMetadataResource, len(s.ThirdPartyRef.Resources))
+	for i, r := range s.ThirdPartyRef.Resources {
+		if r.ServiceType == "" {
+			r.ServiceType = awsMetadataContentType
+		}
+		if r.Path.Type == "" {
+			r.Path.Type = awsMetadataPathType
+		}
+		if r.Authentication.Type == "" && r.Authentication.RoleARN != "" {
+			r.Authentication.Type = "AWSRoleBasedAuthentication"
+		}
+		resources[i] = r
+	}
+	s.ThirdPartyRef.Resources = resources
+	return s
+}
+
+// GetID returns the source's ID.
+func (s AWSMetadataSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s AWSMetadataSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s AWSMetadataSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s AWSMetadataSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+func (source AWSMetadataSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateAWSMetadataSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// GetAWSMetadataSource gets the source with the specified ID.
+func (s *Client) GetAWSMetadataSource(collectorID int, id int) (*AWSMetadataSource, string, error) {
+	var r = new(AWSMetadataSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	return &r.Source, etag, nil
+}
+
+// CreateAWSMetadataSource creates a new AWSMetadataSource. Empty SourceType, ContentType and
+// resource types are set to those of AWS metadata sources.
+// ErrAwsAuthenticationError is returned if Sumo Logic can't assume the role.
+func (s *Client) CreateAWSMetadataSource(collectorID int, source AWSMetadataSource) (*AWSMetadataSource, error) {
+	source = source.withDefaults()
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(AWSMetadataSourceRequest)
+	if err := s.createSource(collectorID, source.Name, AWSMetadataSourceRequest{Source: source}, r); err != nil {
+		return nil, awsAuthenticationError(err)
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateAWSMetadataSource updates an existing AWS metadata source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateAWSMetadataSource(collectorID int, source AWSMetadataSource, etag string) (*AWSMetadataSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(AWSMetadataSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, AWSMetadataSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteAWSMetadataSource deletes the source with the specified ID.
+func (s *Client) DeleteAWSMetadataSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteAWSMetadataSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteAWSMetadataSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteAWSMetadataSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteAWSMetadataSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteAWSMetadataSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteAWSMetadataSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
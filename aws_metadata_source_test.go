@@ -0,0 +1,79 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateAWSMetadataSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]map[string]interface{}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal AWSMetadataSource, got `%s`", body)
+		}
+		resource := request["source"]["thirdPartyRef"].(map[string]interface{})["resources"].([]interface{})[0].(map[string]interface{})
+		path := resource["path"].(map[string]interface{})
+		if request["source"]["contentType"] != "AwsMetadata" || resource["serviceType"] != "AwsMetadata" || path["type"] != "AwsMetadataPath" {
+			t.Errorf("Expected an AwsMetadata source, got `%s`", body)
+		}
+		if regions := path["limitToRegions"].([]interface{}); len(regions) != 1 || regions[0] != "us-east-1" {
+			t.Errorf("Expected limitToRegions to be sent, got `%s`", body)
+		}
+		if tags := path["tagFilters"].([]interface{}); len(tags) != 1 || tags[0] != "Env=prod" {
+			t.Errorf("Expected tagFilters to be sent, got `%s`", body)
+		}
+		var created AWSMetadataSourceRequest
+		json.Unmarshal(body, &created)
+		created.Source.ID = 2
+		body, _ = json.Marshal(created)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source := AWSMetadataSource{Name: "ec2 tags", Category: "aws/metadata", ScanInterval: 60000}
+	source.ThirdPartyRef.Resources = []AWSMetadataResource{{
+		Path: AWSMetadataPath{
+			LimitToRegions:    []string{"us-east-1"},
+			LimitToNamespaces: []string{"AWS/EC2"},
+			TagFilters:        []string{"Env=prod"},
+		},
+		Authentication: AWSBucketAuthentication{RoleARN: "arn:aws:iam::123456789012:role/SumoLogic"},
+	}}
+	created, err := c.CreateAWSMetadataSource(1, source)
+	if err != nil {
+		t.Errorf("CreateAWSMetadataSource() returned an error: %s", err)
+		return
+	}
+	if created.ID != 2 || created.ThirdPartyRef.Resources[0].Authentication.Type != "AWSRoleBasedAuthentication" {
+		t.Errorf("CreateAWSMetadataSource() returned an unexpected source: %+v", created)
+	}
+}
+
+func TestAWSMetadataSourceValidate(t *testing.T) {
+	resource := func(path AWSMetadataPath, roleARN string) AWSMetadataThirdPartyRef {
+		return AWSMetadataThirdPartyRef{Resources: []AWSMetadataResource{{Path: path, Authentication: AWSBucketAuthentication{RoleARN: roleARN}}}}
+	}
+	cases := map[string]AWSMetadataSource{
+		"scanInterval": {Name: "test", ScanInterval: -1},
+		"thirdPartyRef.resources.path.limitToRegions":    {Name: "test", ThirdPartyRef: resource(AWSMetadataPath{LimitToRegions: []string{"us-east"}}, "")},
+		"thirdPartyRef.resources.path.limitToNamespaces": {Name: "test", ThirdPartyRef: resource(AWSMetadataPath{LimitToNamespaces: []string{"AWS/RDS"}}, "")},
+		"authentication.roleARN":                         {Name: "test", ThirdPartyRef: resource(AWSMetadataPath{}, "arn:aws:iam::1:user/x")},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.UpdateAWSMetadataSource(1, AWSMetadataSource{Name: "test"}, ""); err != ErrMissingETag {
+		t.Errorf("UpdateAWSMetadataSource() expected ErrMissingETag, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// SumoLogicAWSAccountID is the AWS account Sumo Logic assumes customer IAM roles from. The
+// role's trust policy must allow sts:AssumeRole from this account with the external ID
+// returned by AWSExternalID.
+const SumoLogicAWSAccountID = "926226587429"
+
+// roleARNPattern matches IAM role ARNs in the commercial, China and GovCloud partitions.
+var roleARNPattern = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// AWSExternalID returns the external ID Sumo Logic sends when assuming a role for the
+// organization with the specified ID on a deployment (e.g. `us2`), as shown on the
+// organization's account page.
+func AWSExternalID(deployment, orgID string) string {
+	return fmt.Sprintf("%s:%s", deployment, orgID)
+}
+
+// ValidateAWSRoleARN checks that arn is an IAM role ARN.
+func ValidateAWSRoleARN(arn string) error {
+	if !roleARNPattern.MatchString(arn) {
+		return &ValidationError{Field: "authentication.roleARN", Message: fmt.Sprintf("`%s` is not an IAM role ARN, e.g. `arn:aws:iam::123456789012:role/SumoLogic`", arn)}
+	}
+	return nil
+}
+
+// AWSAssumeRoleFunc assumes roleARN with externalID, e.g. with the AWS SDK's STS client, and
+// returns an error if the role's trust policy doesn't allow it.
+type AWSAssumeRoleFunc func(ctx context.Context, roleARN, externalID string) error
+
+// PreflightAWSLogSource checks the role-based authentication of every resource of source
+// before it's created, so IAM misconfiguration is reported clearly instead of as a failed
+// CreateAWSLogSource. Role ARNs are validated and, if assumeRole is not nil, assumed with
+// externalID (see AWSExternalID) to verify their trust policy. The SDK doesn't depend on the
+// AWS SDK, so callers provide assumeRole themselves.
+func PreflightAWSLogSource(ctx context.Context, source AWSLogSource, externalID string, assumeRole AWSAssumeRoleFunc) error {
+	for _, r := range source.ThirdPartyRef.Resources {
+		if r.Authentication.Type != "AWSRoleBasedAuthentication" {
+			continue
+		}
+		if err := ValidateAWSRoleARN(r.Authentication.RoleARN); err != nil {
+			return err
+		}
+		if assumeRole == nil {
+			continue
+		}
+		if externalID == "" {
+			return &ValidationError{Field: "externalID", Message: "is required to assume the role"}
+		}
+		if err := assumeRole(ctx, r.Authentication.RoleARN, externalID); err != nil {
+			return fmt.Errorf("Unable to assume role `%s` with external ID `%s`. Check that its trust policy allows account %s: %s", r.Authentication.RoleARN, externalID, SumoLogicAWSAccountID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAWSRoleARN(t *testing.T) {
+	valid := []string{
+		"arn:aws:iam::123456789012:role/SumoLogic",
+		"arn:aws-us-gov:iam::123456789012:role/path/SumoLogic",
+	}
+	for _, arn := range valid {
+		if err := ValidateAWSRoleARN(arn); err != nil {
+			t.Errorf("ValidateAWSRoleARN(%s) returned an error: %s", arn, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"arn:aws:iam::123456789012:user/SumoLogic",
+		"arn:aws:iam::1234:role/SumoLogic",
+		"arn:aws:sns:us-east-1:123456789012:topic",
+	}
+	for _, arn := range invalid {
+		if _, ok := ValidateAWSRoleARN(arn).(*ValidationError); !ok {
+			t.Errorf("ValidateAWSRoleARN(%s) expected a *ValidationError", arn)
+		}
+	}
+}
+
+func TestPreflightAWSLogSource(t *testing.T) {
+	source := ALBAccessLogsSource(AWSBucketTemplate{
+		Name:       "alb",
+		BucketName: "bucket",
+		RoleARN:    "arn:aws:iam::123456789012:role/SumoLogic",
+	})
+	externalID := AWSExternalID("us2", "0000000000ABCDEF")
+	if externalID != "us2:0000000000ABCDEF" {
+		t.Errorf("AWSExternalID() returned ‘%s’", externalID)
+	}
+
+	var assumed []string
+	assumeRole := func(ctx context.Context, roleARN, externalID string) error {
+		assumed = append(assumed, roleARN+" "+externalID)
+		return nil
+	}
+	if err := PreflightAWSLogSource(context.Background(), source, externalID, assumeRole); err != nil {
+		t.Errorf("PreflightAWSLogSource() returned an error: %s", err)
+	}
+	if len(assumed) != 1 || assumed[0] != "arn:aws:iam::123456789012:role/SumoLogic us2:0000000000ABCDEF" {
+		t.Errorf("PreflightAWSLogSource() expected to assume the role once, got %v", assumed)
+	}
+
+	denied := func(ctx context.Context, roleARN, externalID string) error {
+		return errors.New("AccessDenied")
+	}
+	err := PreflightAWSLogSource(context.Background(), source, externalID, denied)
+	if err == nil || !strings.Contains(err.Error(), SumoLogicAWSAccountID) {
+		t.Errorf("PreflightAWSLogSource() expected an error naming the Sumo Logic account, got %v", err)
+	}
+
+	source.ThirdPartyRef.Resources[0].Authentication.RoleARN = "SumoLogic"
+	if _, ok := PreflightAWSLogSource(context.Background(), source, externalID, nil).(*ValidationError); !ok {
+		t.Errorf("PreflightAWSLogSource() expected a *ValidationError for an invalid role ARN")
+	}
+}
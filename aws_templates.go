@@ -0,0 +1,115 @@
+package sumologic
+
+import (
+	"strings"
+)
+
+// AWSBucketTemplate holds the parameters of the S3 bucket source templates, such as
+// ALBAccessLogsSource. Those templates return sources polling the bucket with the documented
+// content type and path expression of the integration, authenticated with an IAM role.
+type AWSBucketTemplate struct {
+	Name     string
+	Category string
+	// BucketName is the S3 bucket the integration writes to.
+	BucketName string
+	// Prefix is the key prefix configured for the integration, if any.
+	Prefix string
+	// AccountID limits the source to objects written for one AWS account. Empty means all.
+	AccountID string
+	// Region limits the source to objects written for one AWS region. Empty means all.
+	Region  string
+	RoleARN string
+}
+
+// awsTemplateScanInterval is the scan interval of the S3 bucket templates, in milliseconds.
+const awsTemplateScanInterval = 300000
+
+// CloudTrailOrgTrailSource returns a source for an AWS Organizations trail, which writes the
+// CloudTrail logs of every account in the organization with the specified ID (`o-…`).
+func CloudTrailOrgTrailSource(t AWSBucketTemplate, organizationID string) AWSLogSource {
+	return t.source(AWSContentTypeCloudTrail, "AWSLogs", organizationID, t.account(), "CloudTrail", t.region(), "*")
+}
+
+// ALBAccessLogsSource returns a source for Application Load Balancer access logs.
+func ALBAccessLogsSource(t AWSBucketTemplate) AWSLogSource {
+	return t.source(AWSContentTypeELB, "AWSLogs", t.account(), "elasticloadbalancing", t.region(), "*")
+}
+
+// ELBAccessLogsSource returns a source for Classic Load Balancer access logs, which are
+// written to the same paths as Application Load Balancer access logs.
+func ELBAccessLogsSource(t AWSBucketTemplate) AWSLogSource {
+	return ALBAccessLogsSource(t)
+}
+
+// S3AuditLogsSource returns a source for S3 server access logs. These are written under the
+// prefix alone, without the account and region segments of the other integrations.
+func S3AuditLogsSource(t AWSBucketTemplate) AWSLogSource {
+	return t.source(AWSContentTypeS3Audit, "*")
+}
+
+// ArchiveSource returns a source for data archived to S3 by Sumo Logic, e.g. with an S3
+// archive forwarding destination. Archived data is only ingested by the ingestion jobs
+// created with CreateArchiveIngestionJob.
+func ArchiveSource(t AWSBucketTemplate) AWSLogSource {
+	return t.source(AWSContentTypeS3Archive, "*")
+}
+
+// VPCFlowLogsSource returns a source for VPC Flow Logs published to S3.
+func VPCFlowLogsSource(t AWSBucketTemplate) AWSLogSource {
+	return t.source(AWSContentTypeS3Bucket, "AWSLogs", t.account(), "vpcflowlogs", t.region(), "*")
+}
+
+// GuardDutyHTTPSource returns an HTTP source receiving Amazon GuardDuty findings, which are
+// forwarded from EventBridge by the Sumo Logic GuardDuty Lambda function. Each finding is a
+// single JSON document, so multiline processing is explicitly disabled.
+func GuardDutyHTTPSource(name, category string) HTTPSource {
+	return HTTPSource{
+		Name:            name,
+		Category:        category,
+		SourceType:      "HTTP",
+		ForceSendFields: []string{"multilineProcessingEnabled"},
+	}
+}
+
+func (t AWSBucketTemplate) account() string {
+	if t.AccountID == "" {
+		return "*"
+	}
+	return t.AccountID
+}
+
+func (t AWSBucketTemplate) region() string {
+	if t.Region == "" {
+		return "*"
+	}
+	return t.Region
+}
+
+// source returns a polling source for contentType whose path expression is the template's
+// prefix followed by the path segments.
+func (t AWSBucketTemplate) source(contentType string, path ...string) AWSLogSource {
+	if prefix := strings.Trim(t.Prefix, "/"); prefix != "" {
+		path = append([]string{prefix}, path...)
+	}
+	return AWSLogSource{
+		Name:         t.Name,
+		Category:     t.Category,
+		SourceType:   "Polling",
+		ContentType:  contentType,
+		ScanInterval: awsTemplateScanInterval,
+		ThirdPartyRef: AWSBucketThirdPartyRef{
+			Resources: []AWSBucketResource{{
+				ServiceType: contentType,
+				Path: AWSBucketPath{
+					Type:           "S3BucketPathExpression",
+					BucketName:     t.BucketName,
+					PathExpression: strings.Join(path, "/"),
+				},
+				Authentication: AWSBucketAuthentication{
+					Type:    "AWSRoleBasedAuthentication",
+					RoleARN: t.RoleARN,
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var defaultAWSBucketTemplate = AWSBucketTemplate{
+	Name:       "aws",
+	Category:   "aws/logs",
+	BucketName: "logs-bucket",
+	RoleARN:    "arn:aws:iam::123456789012:role/SumoLogic",
+}
+
+func TestAWSBucketTemplates(t *testing.T) {
+	prefixed := defaultAWSBucketTemplate
+	prefixed.Prefix = "/alb/"
+	prefixed.AccountID = "123456789012"
+	prefixed.Region = "us-east-1"
+
+	cases := []struct {
+		source      AWSLogSource
+		contentType string
+		path        string
+	}{
+		{CloudTrailOrgTrailSource(defaultAWSBucketTemplate, "o-a1b2c3d4e5"), "AwsCloudTrailBucket", "AWSLogs/o-a1b2c3d4e5/*/CloudTrail/*/*"},
+		{ALBAccessLogsSource(defaultAWSBucketTemplate), "AwsElbBucket", "AWSLogs/*/elasticloadbalancing/*/*"},
+		{ALBAccessLogsSource(prefixed), "AwsElbBucket", "alb/AWSLogs/123456789012/elasticloadbalancing/us-east-1/*"},
+		{ELBAccessLogsSource(defaultAWSBucketTemplate), "AwsElbBucket", "AWSLogs/*/elasticloadbalancing/*/*"},
+		{S3AuditLogsSource(defaultAWSBucketTemplate), "AwsS3AuditBucket", "*"},
+		{S3AuditLogsSource(prefixed), "AwsS3AuditBucket", "alb/*"},
+		{VPCFlowLogsSource(defaultAWSBucketTemplate), "AwsS3Bucket", "AWSLogs/*/vpcflowlogs/*/*"},
+		{ArchiveSource(prefixed), "AwsS3ArchiveBucket", "alb/*"},
+	}
+
+	for _, c := range cases {
+		if c.source.ContentType != c.contentType {
+			t.Errorf("Expected content type ‘%s’, got ‘%s’", c.contentType, c.source.ContentType)
+		}
+		resource := c.source.ThirdPartyRef.Resources[0]
+		if resource.Path.PathExpression != c.path {
+			t.Errorf("Expected path expression ‘%s’, got ‘%s’", c.path, resource.Path.PathExpression)
+		}
+		if resource.Path.BucketName != "logs-bucket" || resource.Authentication.RoleARN != defaultAWSBucketTemplate.RoleARN {
+			t.Errorf("Expected the template's bucket and role, got %+v", resource)
+		}
+		if err := c.source.Validate(); err != nil {
+			t.Errorf("Validate() returned an error for a template: %s", err)
+		}
+	}
+}
+
+func TestGuardDutyHTTPSource(t *testing.T) {
+	body, _ := json.Marshal(GuardDutyHTTPSource("guardduty", "aws/guardduty"))
+	var sent map[string]interface{}
+	json.Unmarshal(body, &sent)
+	if sent["multilineProcessingEnabled"] != false {
+		t.Errorf("Expected multilineProcessingEnabled to be sent as false, got %v", sent["multilineProcessingEnabled"])
+	}
+	if sent["category"] != "aws/guardduty" {
+		t.Errorf("Expected category ‘aws/guardduty’, got %v", sent["category"])
+	}
+}
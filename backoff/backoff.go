@@ -0,0 +1,100 @@
+// Package backoff implements the time-bounded exponential backoff policy used by the
+// sumologic client, so the same policy can be reused when orchestrating multi-call workflows
+// such as waiting for IAM role changes to propagate before creating AWS sources.
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Policy is an exponential backoff policy. The zero value retries immediately and forever,
+// so at least Initial and one of MaxAttempts or MaxElapsedTime are normally set.
+type Policy struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay between retries. Zero means no cap.
+	Max time.Duration
+	// Multiplier is applied to the delay after every retry. Zero means 2.
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction in either direction, e.g. 0.2
+	// for ±20%, so that concurrent callers don't retry in lockstep.
+	Jitter float64
+	// MaxAttempts is the maximum number of attempts, including the first. Zero means no limit.
+	MaxAttempts int
+	// MaxElapsedTime stops retrying once the next attempt would start after this much time
+	// has passed since the first. Zero means no limit.
+	MaxElapsedTime time.Duration
+}
+
+// Delay returns how long to wait after the given attempt (starting at 1) before the next one.
+func (p Policy) Delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	d := float64(p.Initial)
+	for i := 1; i < attempt; i++ {
+		d *= multiplier
+		if p.Max > 0 && d >= float64(p.Max) {
+			break
+		}
+	}
+	if p.Max > 0 && d > float64(p.Max) {
+		d = float64(p.Max)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// permanentError wraps an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Permanent wraps err so that Retry returns it immediately instead of retrying.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Retry calls fn until it succeeds, returns an error wrapped with Permanent, or the policy's
+// attempt or time limit is reached, in which case the last error from fn is returned.
+// If ctx is done while waiting between attempts, ctx.Err() is returned.
+func (p Policy) Retry(ctx context.Context, fn func() error) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if permanent, ok := err.(*permanentError); ok {
+			return permanent.err
+		}
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return err
+		}
+
+		delay := p.Delay(attempt)
+		if p.MaxElapsedTime > 0 && time.Since(start)+delay > p.MaxElapsedTime {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
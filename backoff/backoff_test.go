@@ -0,0 +1,95 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	p := Policy{Initial: time.Second, Max: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := p.Delay(i + 1); got != want {
+			t.Errorf("Delay(%d) expected %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestDelayJitter(t *testing.T) {
+	p := Policy{Initial: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if d := p.Delay(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Errorf("Delay(1) expected 0.5s-1.5s with 50%% jitter, got %s", d)
+		}
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	p := Policy{Initial: time.Millisecond, MaxAttempts: 3}
+	calls := 0
+	errFailed := errors.New("failed")
+	err := p.Retry(context.Background(), func() error {
+		calls++
+		return errFailed
+	})
+	if err != errFailed {
+		t.Errorf("Retry() expected the last error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Retry() expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	p := Policy{Initial: time.Millisecond, MaxAttempts: 5}
+	calls := 0
+	err := p.Retry(context.Background(), func() error {
+		calls++
+		if calls < 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("Retry() expected success after 2 attempts, got %v after %d", err, calls)
+	}
+}
+
+func TestRetryPermanent(t *testing.T) {
+	p := Policy{Initial: time.Millisecond}
+	errFatal := errors.New("fatal")
+	calls := 0
+	err := p.Retry(context.Background(), func() error {
+		calls++
+		return Permanent(errFatal)
+	})
+	if err != errFatal || calls != 1 {
+		t.Errorf("Retry() expected the permanent error after 1 attempt, got %v after %d", err, calls)
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	p := Policy{Initial: time.Hour, MaxElapsedTime: time.Minute}
+	calls := 0
+	err := p.Retry(context.Background(), func() error {
+		calls++
+		return errors.New("failed")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("Retry() expected to give up before waiting past MaxElapsedTime, got %v after %d", err, calls)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	p := Policy{Initial: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := p.Retry(ctx, func() error {
+		cancel()
+		return errors.New("failed")
+	})
+	if err != context.Canceled {
+		t.Errorf("Retry() expected context.Canceled, got %v", err)
+	}
+}
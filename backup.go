@@ -0,0 +1,182 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CollectorBackupSchemaVersion is the schema version of backups written by this version of
+// the SDK. It's increased whenever the format changes, with a migration from the previous
+// version added so that older backups can still be restored.
+const CollectorBackupSchemaVersion = 2
+
+// CollectorBackup is a backup of a hosted collector and its sources, as written by
+// BackupCollector and restored by RestoreCollector. Sources are kept as the API returned
+// them, without the fields managed by Sumo Logic, so sources of any type round-trip. Encode it
+// as JSON to store it, and decode stored backups with ParseCollectorBackup, which migrates
+// backups written by older versions.
+type CollectorBackup struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Collector     Collector         `json:"collector"`
+	Sources       []json.RawMessage `json:"sources"`
+}
+
+// collectorBackupMigrations upgrades backup documents between schema versions: the
+// migration at index i upgrades a version i+1 document to version i+2 in place.
+var collectorBackupMigrations = []func(doc map[string]json.RawMessage) error{
+	// Version 2 leaves out the fields managed by Sumo Logic, including the URLs and tokens
+	// that are the credentials of HTTP and cloud syslog sources.
+	func(doc map[string]json.RawMessage) error {
+		if doc["sources"] == nil {
+			return nil
+		}
+		var sources []json.RawMessage
+		if err := json.Unmarshal(doc["sources"], &sources); err != nil {
+			return err
+		}
+		for i, source := range sources {
+			stripped, err := withoutSourceServerFields(source)
+			if err != nil {
+				return err
+			}
+			sources[i] = stripped
+		}
+		migrated, err := json.Marshal(sources)
+		if err != nil {
+			return err
+		}
+		doc["sources"] = migrated
+		return nil
+	},
+}
+
+// sourceServerFields are the fields of a source definition managed by Sumo Logic. They're
+// left out of backups and never sent when restoring: the restored source gets a new ID, URL
+// and token on the new collector.
+var sourceServerFields = []string{"id", "alive", "url", "token", "CollectorId", "collectorId"}
+
+// withoutSourceServerFields returns the source definition without its sourceServerFields.
+func withoutSourceServerFields(source json.RawMessage) (json.RawMessage, error) {
+	var definition map[string]json.RawMessage
+	if err := json.Unmarshal(source, &definition); err != nil {
+		return nil, err
+	}
+	for _, field := range sourceServerFields {
+		delete(definition, field)
+	}
+	return json.Marshal(definition)
+}
+
+// BackupCollector returns a backup of the collector with the specified ID and its sources.
+func (s *Client) BackupCollector(id int) (*CollectorBackup, error) {
+	collector, _, err := s.GetHostedCollector(id)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.ListSources(id)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &CollectorBackup{
+		SchemaVersion: CollectorBackupSchemaVersion,
+		Collector:     *collector,
+		Sources:       make([]json.RawMessage, len(sources)),
+	}
+	for i, source := range sources {
+		if backup.Sources[i], err = withoutSourceServerFields(source.raw); err != nil {
+			return nil, err
+		}
+	}
+	return backup, nil
+}
+
+// ParseCollectorBackup decodes a JSON backup, migrating it to the current schema version if
+// it was written by an older version of the SDK. Backups without a schema version, or
+// written by a newer version of the SDK, return a *ValidationError.
+func ParseCollectorBackup(data []byte) (*CollectorBackup, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var version int
+	if raw, ok := doc["schemaVersion"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("`%s` is not a schema version", raw)}
+		}
+	}
+	if err := migrateCollectorBackup(doc, version, collectorBackupMigrations); err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var backup = new(CollectorBackup)
+	if err := json.Unmarshal(migrated, backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// migrateCollectorBackup upgrades doc from version to the latest version of migrations.
+func migrateCollectorBackup(doc map[string]json.RawMessage, version int, migrations []func(doc map[string]json.RawMessage) error) error {
+	latest := len(migrations) + 1
+	switch {
+	case version <= 0:
+		return &ValidationError{Field: "schemaVersion", Message: "is missing; the document is not a collector backup"}
+	case version > latest:
+		return &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("`%d` was written by a newer version of the SDK, which supports up to `%d`", version, latest)}
+	}
+	for ; version < latest; version++ {
+		if err := migrations[version-1](doc); err != nil {
+			return fmt.Errorf("Collector backup could not be migrated from schema version `%d`: %w", version, err)
+		}
+	}
+	doc["schemaVersion"] = json.RawMessage(fmt.Sprintf("%d", latest))
+	return nil
+}
+
+// RestoreCollector creates a new collector with the sources of backup, like
+// CreateCollectorWithSources, and returns the collector and the IDs of the created sources.
+// The IDs and state of the backed up resources aren't restored, and the restored sources get
+// new URLs and tokens. Sources are validated and created like those of the SDK's Create
+// functions, so invalid sources return a *ValidationError and sources beyond the plan's limit
+// a *PlanLimitError.
+func (s *Client) RestoreCollector(backup CollectorBackup, rollback bool) (*Collector, []int, error) {
+	if backup.SchemaVersion != CollectorBackupSchemaVersion {
+		return nil, nil, &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("`%d` is not the current schema version; decode backups with ParseCollectorBackup", backup.SchemaVersion)}
+	}
+	sources := make([]SourceSpec, len(backup.Sources))
+	for i, source := range backup.Sources {
+		sources[i] = backupSourceSpec(source)
+	}
+	return s.CreateCollectorWithSources(backup.Collector.userManaged(), sources, rollback)
+}
+
+// backupSourceSpec is a source definition of any type from a CollectorBackup.
+type backupSourceSpec json.RawMessage
+
+func (spec backupSourceSpec) createOn(s *Client, collector Collector) (int, error) {
+	definition, err := withoutSourceServerFields(json.RawMessage(spec))
+	if err != nil {
+		return 0, err
+	}
+	var source Source
+	if err := json.Unmarshal(definition, &source); err != nil {
+		return 0, err
+	}
+	if err := source.validate(); err != nil {
+		return 0, err
+	}
+
+	var r struct {
+		Source Source `json:"source"`
+	}
+	if err := s.createSource(collector.ID, source.Name, map[string]json.RawMessage{"source": definition}, &r); err != nil {
+		return 0, err
+	}
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collector.ID, r.Source.raw)
+	return r.Source.ID, nil
+}
@@ -0,0 +1,137 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseCollectorBackup(t *testing.T) {
+	backup, err := ParseCollectorBackup([]byte(`{"schemaVersion":1,"collector":{"name":"collector"},"sources":[{"name":"source","sourceType":"HTTP"}]}`))
+	if err != nil {
+		t.Errorf("ParseCollectorBackup() returned an error: %s", err)
+		return
+	}
+	if backup.SchemaVersion != CollectorBackupSchemaVersion || backup.Collector.Name != "collector" || len(backup.Sources) != 1 {
+		t.Errorf("ParseCollectorBackup() returned an unexpected backup: %+v", backup)
+	}
+
+	invalid := map[string]string{
+		"unversioned": `{"collector":{"name":"collector"}}`,
+		"newer":       `{"schemaVersion":3,"collector":{"name":"collector"}}`,
+		"malformed":   `{"schemaVersion":"one"}`,
+	}
+	for name, data := range invalid {
+		_, err := ParseCollectorBackup([]byte(data))
+		if e, ok := err.(*ValidationError); !ok || e.Field != "schemaVersion" {
+			t.Errorf("ParseCollectorBackup() expected a schemaVersion ValidationError for a %s backup, got %v", name, err)
+		}
+	}
+}
+
+func TestParseCollectorBackupVersion1(t *testing.T) {
+	backup, err := ParseCollectorBackup([]byte(`{"schemaVersion":1,"collector":{"name":"collector"},"sources":[{"id":2,"name":"source","sourceType":"HTTP","url":"https://endpoint1.collection.sumologic.com/receiver/v1/http/secret","alive":true}]}`))
+	if err != nil {
+		t.Errorf("ParseCollectorBackup() returned an error: %s", err)
+		return
+	}
+	if backup.SchemaVersion != CollectorBackupSchemaVersion || len(backup.Sources) != 1 || string(backup.Sources[0]) != `{"name":"source","sourceType":"HTTP"}` {
+		t.Errorf("ParseCollectorBackup() expected the source without its server fields, got %+v", backup)
+	}
+}
+
+func TestMigrateCollectorBackup(t *testing.T) {
+	migrations := []func(doc map[string]json.RawMessage) error{
+		func(doc map[string]json.RawMessage) error {
+			doc["collector"] = doc["hostedCollector"]
+			delete(doc, "hostedCollector")
+			return nil
+		},
+		func(doc map[string]json.RawMessage) error {
+			doc["sources"] = json.RawMessage(`[]`)
+			return nil
+		},
+	}
+
+	doc := map[string]json.RawMessage{"hostedCollector": json.RawMessage(`{"name":"collector"}`)}
+	if err := migrateCollectorBackup(doc, 1, migrations); err != nil {
+		t.Errorf("migrateCollectorBackup() returned an error: %s", err)
+		return
+	}
+	if string(doc["collector"]) != `{"name":"collector"}` || string(doc["sources"]) != `[]` || string(doc["schemaVersion"]) != "3" {
+		t.Errorf("migrateCollectorBackup() expected every migration to be applied, got %s", doc)
+	}
+
+	doc = map[string]json.RawMessage{}
+	if err := migrateCollectorBackup(doc, 2, migrations); err != nil || doc["collector"] != nil || doc["sources"] == nil {
+		t.Errorf("migrateCollectorBackup() expected only the later migration to be applied, got %s and %v", doc, err)
+	}
+
+	unsupported := errors.New("unsupported")
+	failing := []func(doc map[string]json.RawMessage) error{
+		func(doc map[string]json.RawMessage) error { return unsupported },
+	}
+	if err := migrateCollectorBackup(map[string]json.RawMessage{}, 1, failing); !errors.Is(err, unsupported) {
+		t.Errorf("migrateCollectorBackup() expected the error of the failing migration, got %v", err)
+	}
+}
+
+func TestRestoreCollectorRequiresCurrentVersion(t *testing.T) {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, _, err := c.RestoreCollector(CollectorBackup{}, true); err == nil {
+		t.Errorf("RestoreCollector() expected an error for an unversioned backup")
+	}
+}
+
+func TestRestoreCollector(t *testing.T) {
+	var sourceBody []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/collectors":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"collector":{"id":1,"name":"collector","collectorType":"Hosted"}}`))
+		case r.Method == "POST" && r.URL.Path == "/collectors/1/sources" && sourceBody == nil:
+			sourceBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"source":{"id":3,"name":"source","sourceType":"HTTP"}}`))
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"code":"sources.limit.reached","message":"Maximum number of sources (1) reached."}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	backup := CollectorBackup{
+		SchemaVersion: CollectorBackupSchemaVersion,
+		Collector:     Collector{ID: 5, Name: "collector", CollectorType: "Hosted"},
+		Sources:       []json.RawMessage{json.RawMessage(`{"id":2,"name":"source","sourceType":"HTTP","url":"https://endpoint1.collection.sumologic.com/receiver/v1/http/secret","token":"secret","CollectorId":5,"alive":true}`)},
+	}
+	_, ids, err := c.RestoreCollector(backup, false)
+	if err != nil {
+		t.Errorf("RestoreCollector() returned an error: %s", err)
+		return
+	}
+	if len(ids) != 1 || ids[0] != 3 || string(sourceBody) != `{"source":{"name":"source","sourceType":"HTTP"}}` {
+		t.Errorf("RestoreCollector() expected the source without its server fields, got %v and %s", ids, sourceBody)
+	}
+
+	collector := Collector{ID: 1}
+	if _, err := backupSourceSpec(backup.Sources[0]).createOn(c, collector); !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("RestoreCollector() expected a PlanLimitError for a source over the plan's limit, got %v", err)
+	}
+	_, err = backupSourceSpec(`{"name":"source\nname","sourceType":"HTTP"}`).createOn(c, collector)
+	if e, ok := err.(*ValidationError); !ok || e.Field != "name" {
+		t.Errorf("RestoreCollector() expected a name ValidationError for an invalid source, got %v", err)
+	}
+}
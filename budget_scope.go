@@ -0,0 +1,78 @@
+package sumologic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in metadata keys that ingest budget scopes can match. Any other key matches a field.
+const (
+	ScopeSourceCategory = "_sourcecategory"
+	ScopeCollector      = "_collector"
+	ScopeSource         = "_source"
+	ScopeSourceHost     = "_sourcehost"
+	ScopeSourceName     = "_sourcename"
+)
+
+// BudgetScope returns the ingest budget scope `key=value` after validating it. value may
+// contain `*` wildcards, e.g. BudgetScope(ScopeSourceCategory, "prod/payments/*").
+// Scopes match a single key, so a budget can't combine several keys.
+func BudgetScope(key, value string) (string, error) {
+	if key == "" || strings.ContainsAny(key, "= \t*") {
+		return "", &ValidationError{Field: "scope", Message: fmt.Sprintf("`%s` is not a valid metadata or field name", key)}
+	}
+	if value == "" || strings.ContainsAny(value, "\n\r") {
+		return "", &ValidationError{Field: "scope", Message: fmt.Sprintf("value of `%s` must be a non-empty single line", key)}
+	}
+	if value == "*" {
+		return "", &ValidationError{Field: "scope", Message: fmt.Sprintf("`%s=*` matches all data; scope budgets to specific data", key)}
+	}
+	return key + "=" + value, nil
+}
+
+// ParseBudgetScope splits an ingest budget scope into its key and value and validates them.
+func ParseBudgetScope(scope string) (key, value string, err error) {
+	parts := strings.SplitN(scope, "=", 2)
+	if len(parts) != 2 {
+		return "", "", &ValidationError{Field: "scope", Message: fmt.Sprintf("`%s` must have the form `key=value`", scope)}
+	}
+	key, value = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if _, err := BudgetScope(key, value); err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
+
+// BudgetScopeMatches reports whether data with the given metadata and fields, keyed by
+// lowercase name (e.g. ScopeSourceCategory), falls within scope. Like Sumo Logic, keys and
+// values are matched case-insensitively. An invalid scope matches nothing.
+func BudgetScopeMatches(scope string, metadata map[string]string) bool {
+	key, pattern, err := ParseBudgetScope(scope)
+	if err != nil {
+		return false
+	}
+	value, ok := metadata[strings.ToLower(key)]
+	return ok && matchGlob(pattern, value)
+}
+
+// matchGlob reports whether s matches pattern case-insensitively, where `*` in pattern
+// matches any sequence of characters, including `/`.
+func matchGlob(pattern, s string) bool {
+	pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,45 @@
+package sumologic
+
+import "testing"
+
+func TestBudgetScope(t *testing.T) {
+	scope, err := BudgetScope(ScopeSourceCategory, "prod/payments/*")
+	if err != nil || scope != "_sourcecategory=prod/payments/*" {
+		t.Errorf("BudgetScope() returned ‘%s’ and %v", scope, err)
+	}
+
+	invalid := [][2]string{{"", "prod"}, {"team name", "payments"}, {"team", ""}, {"team", "*"}}
+	for _, kv := range invalid {
+		if _, err := BudgetScope(kv[0], kv[1]); err == nil {
+			t.Errorf("BudgetScope(%s, %s) expected an error", kv[0], kv[1])
+		}
+	}
+
+	key, value, err := ParseBudgetScope(" team = payments ")
+	if err != nil || key != "team" || value != "payments" {
+		t.Errorf("ParseBudgetScope() returned ‘%s’, ‘%s’ and %v", key, value, err)
+	}
+	if _, _, err := ParseBudgetScope("payments"); err == nil {
+		t.Errorf("ParseBudgetScope() expected an error for a scope without a key")
+	}
+}
+
+func TestBudgetScopeMatches(t *testing.T) {
+	metadata := map[string]string{ScopeSourceCategory: "Prod/Payments/API", "team": "payments"}
+	cases := map[string]bool{
+		"_sourceCategory=prod/payments/*": true,
+		"_sourceCategory=prod/*/api":      true,
+		"_sourceCategory=*/payments*":     true,
+		"_sourceCategory=prod/billing/*":  false,
+		"_sourceCategory=prod/payments":   false,
+		"team=payments":                   true,
+		"team=billing":                    false,
+		"_collector=payments":             false,
+		"invalid":                         false,
+	}
+	for scope, expected := range cases {
+		if got := BudgetScopeMatches(scope, metadata); got != expected {
+			t.Errorf("BudgetScopeMatches(%s) expected %t, got %t", scope, expected, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Change operations recorded by a ChangeRecorder.
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// Change describes a successful mutation of a Sumo Logic resource.
+type Change struct {
+	Time time.Time `json:"time"`
+	// Operation is ChangeCreate, ChangeUpdate or ChangeDelete.
+	Operation string `json:"operation"`
+	// ResourceType is the kind of resource, e.g. ResourceTypeCollector or ResourceTypeSource.
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId"`
+	// ParentID is the ID of the containing resource, e.g. the collector of a source.
+	ParentID string `json:"parentId,omitempty"`
+	// After is the resource as returned by Sumo Logic after a create or update.
+	After json.RawMessage `json:"after,omitempty"`
+}
+
+// ChangeRecorder is notified of every successful create, update and delete made by a client,
+// e.g. to persist a change journal for compliance. RecordChange is called synchronously after
+// the API call succeeds, and may be called from multiple goroutines at once.
+type ChangeRecorder interface {
+	RecordChange(change Change)
+}
+
+// WithChangeRecorder sets the recorder notified of every successful change.
+func WithChangeRecorder(recorder ChangeRecorder) ClientOption {
+	return func(s *Client) error {
+		s.changeRecorder = recorder
+		return nil
+	}
+}
+
+// recordChange notifies the client's ChangeRecorder, if any. after is serialized to JSON; a
+// nil value is left out.
+func (s *Client) recordChange(operation, resourceType string, id, parentID interface{}, after interface{}) {
+	s.afterMutation(operation, resourceType, id, parentID, after)
+	if s.changeRecorder == nil {
+		return
+	}
+	change := Change{
+		Time:         time.Now().UTC(),
+		Operation:    operation,
+		ResourceType: resourceType,
+		ResourceID:   fmt.Sprint(id),
+	}
+	if parentID != nil {
+		change.ParentID = fmt.Sprint(parentID)
+	}
+	if after != nil {
+		change.After, _ = json.Marshal(after)
+		change.After = s.redact(change.After)
+	}
+	s.changeRecorder.RecordChange(change)
+}
@@ -0,0 +1,86 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type memoryRecorder struct {
+	mu      sync.Mutex
+	changes []Change
+}
+
+func (r *memoryRecorder) RecordChange(change Change) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, change)
+}
+
+func TestChangeRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST", "PUT":
+			body, _ := io.ReadAll(r.Body)
+			sr := new(HTTPSourceRequest)
+			json.Unmarshal(body, &sr)
+			sr.Source.ID = defaultHTTPSource.ID
+			js, _ := json.Marshal(sr)
+			if r.Method == "POST" {
+				w.WriteHeader(http.StatusCreated)
+			}
+			w.Write(js)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+		case "GET":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	recorder := &memoryRecorder{}
+	c, err := NewClient("accessToken", ts.URL, WithChangeRecorder(recorder))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	created, err := c.CreateHTTPSource(defaultHTTPSource.CollectorID, HTTPSource{Name: "test"})
+	if err != nil {
+		t.Errorf("CreateHTTPSource() returned an error: %s", err)
+		return
+	}
+	created.Name = "updated"
+	if _, err := c.UpdateHTTPSource(defaultHTTPSource.CollectorID, *created, "etag"); err != nil {
+		t.Errorf("UpdateHTTPSource() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSource(defaultHTTPSource.CollectorID, created.ID); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+		return
+	}
+	c.GetHTTPSource(defaultHTTPSource.CollectorID, created.ID)
+
+	expected := []string{ChangeCreate, ChangeUpdate, ChangeDelete}
+	if len(recorder.changes) != len(expected) {
+		t.Errorf("Expected %d recorded changes, got %d", len(expected), len(recorder.changes))
+		return
+	}
+	for i, operation := range expected {
+		change := recorder.changes[i]
+		if change.Operation != operation || change.ResourceType != "source" || change.ResourceID != "1234567890" || change.ParentID != "1234567890" {
+			t.Errorf("Unexpected recorded change %d: %+v", i, change)
+		}
+	}
+
+	var after HTTPSource
+	if err := json.Unmarshal(recorder.changes[1].After, &after); err != nil || after.Name != "updated" {
+		t.Errorf("Expected the update to record the updated source, got `%s`", recorder.changes[1].After)
+	}
+	if recorder.changes[2].After != nil {
+		t.Errorf("Expected no after payload for a delete, got `%s`", recorder.changes[2].After)
+	}
+}
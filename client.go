@@ -2,21 +2,70 @@
 package sumologic
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
 )
 
 // Client communicates with the Sumo Logic API.
+//
+// A Client is safe for concurrent use by multiple goroutines, e.g. by parallel reconcilers,
+// as long as its exported fields aren't changed while requests are in flight.
 type Client struct {
 	AuthToken   string
 	EndpointURL *url.URL
+
+	httpClient         *http.Client
+	ownsHTTPClient     bool
+	ownsTransport      bool
+	middleware         []Middleware
+	features           map[Feature]bool
+	maxConcurrency     int
+	changeRecorder     ChangeRecorder
+	changeRedactions   [][]redactionSegment
+	maskSourceURLs     bool
+	responseHook       func(*Response)
+	transientRetry     *backoff.Policy
+	conflictRetry      *backoff.Policy
+	requestPolicies    map[string]RequestPolicy
+	etagStore          ETagStore
+	preMutationHooks   []func(Mutation) error
+	postMutationHooks  []func(Mutation)
+	jobPolling         *backoff.Policy
+	failover           *endpointFailover
+	metrics            clientMetrics
+	rateLimit          rateLimitState
+	deprecationHandler func(DeprecationNotice)
+	deprecationsLogged sync.Map
+
+	methodDeprecationHandler  func(MethodDeprecation)
+	silenceMethodDeprecations bool
+	// parent is the client this one was derived from with WithAuth. Metrics and
+	// deprecation logging are kept on the parent.
+	parent *Client
 }
 
 // ErrClientAuthenticationError is returned for authentication errors with the API.
 var ErrClientAuthenticationError = errors.New("Authentication Error with Sumo Logic")
 
 // NewClient returns a new sumologic.Client for accessing the Sumo Logic API.
-func NewClient(authToken, defaultEndpointURL string) (*Client, error) {
+// authToken is the base64 encoding of `accessID:accessKey`; use NewClientWithAccessKey to
+// authenticate with the access ID and key instead.
+// defaultEndpointURL is the API's base URL, such as `https://api.sumologic.com/api/v1/`; it
+// must use https, except for loopback hosts, and a trailing slash is added if it's missing.
+func NewClient(authToken, defaultEndpointURL string, options ...ClientOption) (*Client, error) {
 	s := &Client{
 		AuthToken: authToken,
 	}
@@ -24,6 +73,273 @@ func NewClient(authToken, defaultEndpointURL string) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := validateEndpointURL(endpointURL); err != nil {
+		return nil, err
+	}
+	if endpointURL.Path != "" && !strings.HasSuffix(endpointURL.Path, "/") {
+		endpointURL.Path += "/"
+	}
 	s.EndpointURL = endpointURL
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+	if s.httpClient == nil {
+		s.ownHTTPClient()
+	}
 	return s, nil
 }
+
+// NewClientWithAccessKey returns a new sumologic.Client that authenticates with the access
+// key accessID and accessKey, as created in Sumo Logic under Preferences > Access Keys. See
+// NewClient for defaultEndpointURL.
+func NewClientWithAccessKey(accessID, accessKey, defaultEndpointURL string, options ...ClientOption) (*Client, error) {
+	if accessID == "" {
+		return nil, &ValidationError{Field: "accessId", Message: "must not be empty"}
+	}
+	if accessKey == "" {
+		return nil, &ValidationError{Field: "accessKey", Message: "must not be empty"}
+	}
+	return NewClient(AccessKeyToken(accessID, accessKey), defaultEndpointURL, options...)
+}
+
+// AccessKeyToken returns the auth token of the access key accessID and accessKey, as taken
+// by NewClient and WithAuth: the base64 encoding of `accessID:accessKey`.
+func AccessKeyToken(accessID, accessKey string) string {
+	return base64.StdEncoding.EncodeToString([]byte(accessID + ":" + accessKey))
+}
+
+// ErrNoEndpointURL is returned when a request is made by a Client without an EndpointURL.
+var ErrNoEndpointURL = errors.New("Sumo Logic client has no endpoint URL")
+
+// newRequest creates an authenticated API request for a path relative to the client's
+// endpoint URL. A non-nil body is sent as JSON.
+func (s *Client) newRequest(method string, relativeURL *url.URL, body []byte) (*http.Request, error) {
+	if s.EndpointURL == nil {
+		return nil, ErrNoEndpointURL
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, s.EndpointURL.ResolveReference(relativeURL).String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+	return req, nil
+}
+
+// validateEndpointURL checks that u is an absolute https URL. Plain http is accepted for
+// loopback hosts, e.g. for test servers and local proxies.
+func validateEndpointURL(u *url.URL) error {
+	if u.Scheme == "" || u.Host == "" {
+		return &ValidationError{Field: "endpointURL", Message: fmt.Sprintf("`%s` must be an absolute URL with a scheme and host, e.g. `https://api.sumologic.com/api/v1/`", u)}
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+		return &ValidationError{Field: "endpointURL", Message: fmt.Sprintf("`%s` must use https", u)}
+	default:
+		return &ValidationError{Field: "endpointURL", Message: fmt.Sprintf("`%s` has unsupported scheme `%s`; use https", u, u.Scheme)}
+	}
+}
+
+// send performs an API request. Every request made by the client goes through send.
+// Transient network errors are returned as a *TransientError, and retried if the client
+// was created with WithTransientRetry.
+func (s *Client) send(req *http.Request) (*http.Response, error) {
+	client := s.httpClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	endpoint := s.endpointName(req)
+	for attempt := 1; ; attempt++ {
+		s.failover.route(client, req)
+		start := time.Now()
+		resp, err := s.withMiddleware(client).Do(req)
+		s.root().metrics.record(endpoint, resp, err)
+		s.callResponseHook(req, endpoint, resp, err, start)
+		if err == nil {
+			s.root().rateLimit.record(resp)
+			s.handleDeprecation(endpoint, resp)
+			resp.Body = drainingBody{resp.Body}
+			return resp, nil
+		}
+		if s.failover.failOver(req, err) {
+			s.root().metrics.recordRetry(endpoint)
+			continue
+		}
+		err = classifyTransportError(err)
+		if !s.retryTransient(req, err, attempt) {
+			return nil, err
+		}
+		s.root().metrics.recordRetry(endpoint)
+	}
+}
+
+// do sends an API request for path, which is relative to the endpoint URL and may include a
+// query. A non-nil body is sent as JSON; []byte and json.RawMessage bodies are sent as they
+// are. A successful response is decoded into out, unless out is nil; a *[]byte out receives
+// the raw body. See doRequest for how other responses are returned.
+func (s *Client) do(method, path string, body, out interface{}) (*http.Response, error) {
+	return s.doIfMatch(method, path, "", body, out)
+}
+
+// doIfMatch is like do, but sends etag, if not empty, as If-Match so that the request fails
+// with ErrPreconditionFailed if the resource has changed.
+func (s *Client) doIfMatch(method, path, etag string, body, out interface{}) (*http.Response, error) {
+	return s.doIfMatchContext(context.Background(), method, path, etag, body, out)
+}
+
+// doIfMatchContext is like doIfMatch, but the request is canceled when ctx is done.
+func (s *Client) doIfMatchContext(ctx context.Context, method, path, etag string, body, out interface{}) (*http.Response, error) {
+	relativeURL, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		data = b
+	case json.RawMessage:
+		data = b
+	default:
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := s.newRequest(method, relativeURL, data)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Add("If-Match", etag)
+	}
+	resp, err := s.doRequest(req.WithContext(ctx), out)
+	if err == nil {
+		s.storeETag(method, path, resp, out)
+	}
+	return resp, err
+}
+
+// doRequest sends req and handles the response like do. The response is returned with its
+// body closed, so that headers such as ETag can be read. Responses other than 2xx are
+// returned as ErrClientAuthenticationError for 401, ErrPreconditionFailed for 412 and an
+// *APIError otherwise, which resource methods map to their own errors with errorForStatus
+// and badRequest.
+func (s *Client) doRequest(req *http.Request, out interface{}) (*http.Response, error) {
+	req, cancel := s.withRequestTimeout(req)
+	defer cancel()
+	resp, err := s.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			return resp, nil
+		}
+		if raw, ok := out.(*[]byte); ok {
+			*raw, err = io.ReadAll(resp.Body)
+			return resp, err
+		}
+		return resp, json.NewDecoder(resp.Body).Decode(out)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return resp, ErrClientAuthenticationError
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return resp, ErrPreconditionFailed
+	default:
+		e := &APIError{StatusCode: resp.StatusCode, Metadata: parseResponseMetadata(resp.Header, time.Now())}
+		var body Error
+		if json.NewDecoder(resp.Body).Decode(&body) == nil {
+			e.Code, e.Message, e.ID = body.Code, body.Message, body.ID
+		}
+		return resp, e
+	}
+}
+
+// APIError is returned for error responses from the API that aren't reported as a more
+// specific error, such as ErrCollectorNotFound for a 404 from GetHostedCollector. Use
+// errors.As to branch on its status or Sumo Logic error code.
+type APIError struct {
+	StatusCode int
+	// Code, Message and ID are from the error body returned by the API, if the response had
+	// one. ID identifies the request when contacting Sumo Logic support.
+	Code    string
+	Message string
+	ID      string
+	// Metadata holds the rate limit headers of the response, e.g. when to retry a 429.
+	Metadata ResponseMetadata
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusBadRequest && e.Message != "" {
+		return fmt.Sprintf("Bad Request. %s", e.Message)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("Unknown Response with Sumo Logic: `%d` (%s)", e.StatusCode, e.Code)
+	}
+	return fmt.Sprintf("Unknown Response with Sumo Logic: `%d`", e.StatusCode)
+}
+
+// Is reports whether target is the sentinel error for e's status: ErrRateLimited for 429,
+// ErrClientAuthenticationError for 401 and ErrPreconditionFailed for 412. The client returns
+// the last two sentinels itself, so they matter for APIErrors built elsewhere, e.g. by
+// middleware.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrClientAuthenticationError:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// errorForStatus returns mapped if err is an *APIError with the given status code, e.g. to
+// return ErrCollectorNotFound for 404, and err otherwise.
+func errorForStatus(err error, statusCode int, mapped error) error {
+	if e, ok := err.(*APIError); ok && e.StatusCode == statusCode {
+		return mapped
+	}
+	return err
+}
+
+// badRequest returns the error returned by the API if err is a 400 *APIError with a message.
+func badRequest(err error) (*Error, bool) {
+	e, ok := err.(*APIError)
+	if !ok || e.StatusCode != http.StatusBadRequest || e.Message == "" {
+		return nil, false
+	}
+	return &Error{Status: e.StatusCode, ID: e.ID, Code: e.Code, Message: e.Message}, true
+}
+
+// maxDrainBytes is the most drainingBody discards when a response body is closed.
+const maxDrainBytes = 64 << 10
+
+// drainingBody discards the rest of a response body, up to maxDrainBytes, when it is closed.
+// Responses are decoded straight from the body, which can leave trailing bytes unread, and a
+// connection is only reused once its previous response body was read to the end.
+type drainingBody struct {
+	io.ReadCloser
+}
+
+func (b drainingBody) Close() error {
+	io.CopyN(io.Discard, b.ReadCloser, maxDrainBytes)
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,236 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestInvalidEndpointURL checks that every method returns an error, instead of panicking,
+// when the client's endpoint URL can't be used to build a request.
+func TestInvalidEndpointURL(t *testing.T) {
+	endpoints := map[string]*url.URL{
+		"nil":          nil,
+		"invalid host": {Scheme: "https", Host: "api sumologic com", Path: "/api/v1/"},
+	}
+	ctx := context.Background()
+	methods := map[string]func(c *Client) error{
+		"GetHostedCollector":            func(c *Client) error { _, _, err := c.GetHostedCollector(1); return err },
+		"CreateHostedCollector":         func(c *Client) error { _, err := c.CreateHostedCollector(defaultCollector); return err },
+		"UpdateHostedCollector":         func(c *Client) error { _, err := c.UpdateHostedCollector(defaultCollector, "etag"); return err },
+		"DeleteHostedCollector":         func(c *Client) error { return c.DeleteHostedCollector(1) },
+		"DeleteHostedCollectorIfExists": func(c *Client) error { return c.DeleteHostedCollectorIfExists(1) },
+		"GetCollectors":                 func(c *Client) error { return c.GetCollectors([]int{1})[0].Err },
+		"GetHTTPSource":                 func(c *Client) error { _, _, err := c.GetHTTPSource(1, 2); return err },
+		"CreateHTTPSource":              func(c *Client) error { _, err := c.CreateHTTPSource(1, defaultHTTPSource); return err },
+		"UpdateHTTPSource":              func(c *Client) error { _, err := c.UpdateHTTPSource(1, defaultHTTPSource, "etag"); return err },
+		"DeleteHTTPSource":              func(c *Client) error { return c.DeleteHTTPSource(1, 2) },
+		"GetAWSLogSource":               func(c *Client) error { _, _, err := c.GetAWSLogSource(1, 2); return err },
+		"DeleteAWSLogSource":            func(c *Client) error { return c.DeleteAWSLogSource(1, 2) },
+		"GetCloudToCloudSource":         func(c *Client) error { _, _, err := c.GetCloudToCloudSource(1, 2); return err },
+		"CreateCloudToCloudSource":      func(c *Client) error { _, err := c.CreateCloudToCloudSource(1, CloudToCloudSource{}); return err },
+		"ListSources":                   func(c *Client) error { _, err := c.ListSources(1); return err },
+		"DownloadSourcesJSON":           func(c *Client) error { _, err := c.DownloadSourcesJSON(1); return err },
+		"GetSourceHealth":               func(c *Client) error { _, err := c.GetSourceHealth(1, 2); return err },
+		"CreateOrganization":            func(c *Client) error { _, err := c.CreateOrganization(Organization{}); return err },
+		"ListOrganizations":             func(c *Client) error { _, err := c.ListOrganizations(); return err },
+		"GetOrganization":               func(c *Client) error { _, err := c.GetOrganization("1"); return err },
+		"GetOrganizationCredentials":    func(c *Client) error { _, err := c.GetOrganizationCredentials("1"); return err },
+		"DeactivateOrganization":        func(c *Client) error { return c.DeactivateOrganization("1") },
+		"ListUsers":                     func(c *Client) error { _, err := c.ListUsers(ListUsersOptions{}); return err },
+		"FindUserByEmail":               func(c *Client) error { _, err := c.FindUserByEmail("jdoe@example.com"); return err },
+		"ListRoles":                     func(c *Client) error { _, err := c.ListRoles(ListRolesOptions{}); return err },
+		"CreateSearchJob":               func(c *Client) error { _, err := c.CreateSearchJob(SearchJobRequest{}); return err },
+		"GetSearchJobStatus":            func(c *Client) error { _, err := c.GetSearchJobStatus("1"); return err },
+		"GetSearchJobRecords":           func(c *Client) error { _, err := c.GetSearchJobRecords("1", 0, 10); return err },
+		"GetSearchJobMessages":          func(c *Client) error { _, err := c.GetSearchJobMessages("1", 0, 10); return err },
+		"DeleteSearchJob":               func(c *Client) error { return c.DeleteSearchJob("1") },
+		"GetCollectorVolume": func(c *Client) error {
+			_, err := c.GetCollectorVolume(ctx, time.Now().Add(-time.Hour), time.Now())
+			return err
+		},
+		"ExportContent": func(c *Client) error { _, err := c.ExportContent(ctx, "1"); return err },
+		"ImportContent": func(c *Client) error { return c.ImportContent(ctx, "1", []byte(`{}`), false) },
+		"CreateCollectorWithSources": func(c *Client) error {
+			_, _, err := c.CreateCollectorWithSources(defaultCollector, nil, true)
+			return err
+		},
+	}
+
+	for endpointName, endpoint := range endpoints {
+		for name, method := range methods {
+			c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithExperimentalFeatures(FeatureCloudToCloudSources))
+			c.EndpointURL = endpoint
+			if err := method(c); err == nil {
+				t.Errorf("%s() with a %s endpoint URL did not return an error", name, endpointName)
+			}
+		}
+	}
+}
+
+func TestNewClientEndpointURLValidation(t *testing.T) {
+	invalid := []string{
+		"",
+		"api.sumologic.com/api/v1/",
+		"//api.sumologic.com/api/v1/",
+		"https:///api/v1/",
+		"http://api.sumologic.com/api/v1/",
+		"ftp://api.sumologic.com/api/v1/",
+	}
+	for _, endpoint := range invalid {
+		_, err := NewClient("accessToken", endpoint)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != "endpointURL" {
+			t.Errorf("NewClient(%q) expected an endpointURL ValidationError, got %v", endpoint, err)
+		}
+	}
+
+	valid := map[string]string{
+		"https://api.sumologic.com/api/v1/": "https://api.sumologic.com/api/v1/",
+		"https://api.sumologic.com/api/v1":  "https://api.sumologic.com/api/v1/",
+		"http://127.0.0.1:8080":             "http://127.0.0.1:8080",
+		"http://localhost:8080/api/v1/":     "http://localhost:8080/api/v1/",
+	}
+	for endpoint, expected := range valid {
+		c, err := NewClient("accessToken", endpoint)
+		if err != nil {
+			t.Errorf("NewClient(%q) returned an error: %s", endpoint, err)
+			continue
+		}
+		if c.EndpointURL.String() != expected {
+			t.Errorf("NewClient(%q) expected endpoint ‘%s’, got ‘%s’", endpoint, expected, c.EndpointURL)
+		}
+	}
+}
+
+func TestNewClientWithAccessKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, key, ok := r.BasicAuth()
+		if !ok || id != "suAbCdEf" || key != "secret:with:colons" {
+			t.Errorf("Expected basic auth with the access key, got ‘%s’", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"collector":{"id":1,"name":"collector"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAccessKey("suAbCdEf", "secret:with:colons", ts.URL)
+	if err != nil {
+		t.Errorf("NewClientWithAccessKey() returned an error: %s", err)
+		return
+	}
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+	}
+
+	// Raw tokens are still accepted by NewClient.
+	if c, _ := NewClient(AccessKeyToken("suAbCdEf", "secret:with:colons"), ts.URL); c.AuthToken != "c3VBYkNkRWY6c2VjcmV0OndpdGg6Y29sb25z" {
+		t.Errorf("AccessKeyToken() returned an unexpected token: %s", c.AuthToken)
+	}
+
+	if _, err := NewClientWithAccessKey("", "secret", ts.URL); err == nil {
+		t.Errorf("NewClientWithAccessKey() expected an error for an empty access ID")
+	}
+	if _, err := NewClientWithAccessKey("suAbCdEf", "", ts.URL); err == nil {
+		t.Errorf("NewClientWithAccessKey() expected an error for an empty access key")
+	}
+}
+
+func TestDo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("ETag", r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/precondition":
+			w.WriteHeader(http.StatusPreconditionFailed)
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"code":"invalid","message":"Invalid name"}`))
+		case "/locked":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"status":409,"id":"6ZPXR-KPLO8-C5UBA","code":"collector.locked","message":"Collector is locked"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	resp, err := c.doIfMatch("POST", "echo", `"1"`, map[string]string{"name": "collector"}, &out)
+	if err != nil {
+		t.Errorf("doIfMatch() returned an error: %s", err)
+		return
+	}
+	if out.Name != "collector" || resp.Header.Get("ETag") != `"1"` {
+		t.Errorf("doIfMatch() expected the body and If-Match to round-trip, got ‘%s’ and ‘%s’", out.Name, resp.Header.Get("ETag"))
+	}
+
+	var raw []byte
+	if _, err := c.do("POST", "echo", []byte(`{"raw":true}`), &raw); err != nil || string(raw) != `{"raw":true}` {
+		t.Errorf("do() expected the raw body, got ‘%s’ and %v", raw, err)
+	}
+
+	if _, err := c.do("GET", "unauthorized", nil, nil); err != ErrClientAuthenticationError {
+		t.Errorf("do() expected ErrClientAuthenticationError for 401, got %v", err)
+	}
+	if _, err := c.do("GET", "precondition", nil, nil); err != ErrPreconditionFailed {
+		t.Errorf("do() expected ErrPreconditionFailed for 412, got %v", err)
+	}
+
+	_, err = c.do("GET", "bad", nil, nil)
+	if e, ok := badRequest(err); !ok || e.Code != "invalid" {
+		t.Errorf("do() expected the API error for 400, got %v", err)
+	}
+	if err == nil || err.Error() != "Bad Request. Invalid name" {
+		t.Errorf("do() expected ‘Bad Request. Invalid name’, got %v", err)
+	}
+
+	_, err = c.do("GET", "missing", nil, nil)
+	if errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound) != ErrCollectorNotFound {
+		t.Errorf("do() expected a 404 error, got %v", err)
+	}
+	if err == nil || err.Error() != "Unknown Response with Sumo Logic: `404`" {
+		t.Errorf("do() expected an unknown response error, got %v", err)
+	}
+
+	_, err = c.do("GET", "locked", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("do() expected an *APIError for 409, got %v", err)
+		return
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Code != "collector.locked" || apiErr.Message != "Collector is locked" || apiErr.ID != "6ZPXR-KPLO8-C5UBA" {
+		t.Errorf("do() returned an unexpected APIError: %+v", apiErr)
+	}
+	if err.Error() != "Unknown Response with Sumo Logic: `409` (collector.locked)" {
+		t.Errorf("APIError.Error() returned ‘%s’", err)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	if !errors.Is(&APIError{StatusCode: http.StatusUnauthorized}, ErrClientAuthenticationError) {
+		t.Errorf("errors.Is() expected a 401 APIError to be ErrClientAuthenticationError")
+	}
+	if !errors.Is(&APIError{StatusCode: http.StatusPreconditionFailed}, ErrPreconditionFailed) {
+		t.Errorf("errors.Is() expected a 412 APIError to be ErrPreconditionFailed")
+	}
+	if errors.Is(&APIError{StatusCode: http.StatusNotFound}, ErrPreconditionFailed) {
+		t.Errorf("errors.Is() expected a 404 APIError not to be ErrPreconditionFailed")
+	}
+}
@@ -0,0 +1,205 @@
+package sumologic
+
+import (
+	"context"
+	"reflect"
+)
+
+// CloudSyslogSourceRequest is a necessary wrapper for source API calls.
+type CloudSyslogSourceRequest struct {
+	Source CloudSyslogSource `json:"source"`
+}
+
+// CloudSyslogSource is a source on a hosted collector that receives syslog messages sent
+// over TLS, e.g. by rsyslog. Senders identify the source by its Token.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type CloudSyslogSource struct {
+	ID                         int          `json:"id,omitempty"`
+	Name                       string       `json:"name"`
+	CollectorID                int          `json:"CollectorId,omitempty"`
+	Description                string       `json:"description,omitempty"`
+	Category                   string       `json:"category,omitempty"`
+	HostName                   string       `json:"hostName,omitempty"`
+	TimeZone                   string       `json:"timezone,omitempty"`
+	SourceType                 string       `json:"sourceType,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	// Token is generated by Sumo Logic when the source is created, and is included in every
+	// message sent to the source. It can't be changed and isn't sent on update.
+	Token string `json:"token,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// cloudSyslogSourceType is the sourceType of cloud syslog sources.
+const cloudSyslogSourceType = "Cloudsyslog"
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s CloudSyslogSource) MarshalJSON() ([]byte, error) {
+	type cloudSyslogSource CloudSyslogSource
+	return marshalForceSend(cloudSyslogSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *CloudSyslogSource) UnmarshalJSON(data []byte) error {
+	type cloudSyslogSource CloudSyslogSource
+	return unmarshalLenient(data, (*cloudSyslogSource)(s))
+}
+
+// Validate checks the fields validated for all sources, without calling the API.
+func (s CloudSyslogSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	return ValidateBoundaryRegex(s.ManualPrefixRegexp)
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and the token) are ignored.
+func (s CloudSyslogSource) Equivalent(other CloudSyslogSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s CloudSyslogSource) userManaged() CloudSyslogSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Token = ""
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s CloudSyslogSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s CloudSyslogSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s CloudSyslogSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s CloudSyslogSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+func (source CloudSyslogSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateCloudSyslogSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// GetCloudSyslogSource gets the source with the specified ID.
+// If the client was created with WithMaskedSourceURLs, the source's token is masked.
+func (s *Client) GetCloudSyslogSource(collectorID int, id int) (*CloudSyslogSource, string, error) {
+	var r = new(CloudSyslogSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	s.maskCloudSyslogSource(&r.Source)
+	return &r.Source, etag, nil
+}
+
+// GetCloudSyslogSourceToken gets the unmasked token of the source with the specified ID,
+// regardless of WithMaskedSourceURLs, e.g. to distribute it to rsyslog configurations.
+func (s *Client) GetCloudSyslogSourceToken(collectorID int, id int) (string, error) {
+	var r = new(CloudSyslogSourceRequest)
+	if _, err := s.getSource(collectorID, id, r); err != nil {
+		return "", err
+	}
+	return r.Source.Token, nil
+}
+
+// CreateCloudSyslogSource creates a new CloudSyslogSource. An empty SourceType is set to
+// `Cloudsyslog`. The returned source holds the generated token, masked if the client was
+// created with WithMaskedSourceURLs.
+func (s *Client) CreateCloudSyslogSource(collectorID int, source CloudSyslogSource) (*CloudSyslogSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = cloudSyslogSourceType
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Token = ""
+
+	var r = new(CloudSyslogSourceRequest)
+	if err := s.createSource(collectorID, source.Name, CloudSyslogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.maskCloudSyslogSource(&r.Source)
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateCloudSyslogSource updates an existing cloud syslog source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateCloudSyslogSource(collectorID int, source CloudSyslogSource, etag string) (*CloudSyslogSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Token = ""
+
+	var r = new(CloudSyslogSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, CloudSyslogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.maskCloudSyslogSource(&r.Source)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteCloudSyslogSource deletes the source with the specified ID.
+func (s *Client) DeleteCloudSyslogSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteCloudSyslogSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteCloudSyslogSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteCloudSyslogSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteCloudSyslogSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteCloudSyslogSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteCloudSyslogSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
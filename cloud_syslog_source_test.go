@@ -0,0 +1,87 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultCloudSyslogSource = CloudSyslogSource{
+	Name:     "rsyslog",
+	Category: "hosts/syslog",
+}
+
+func TestCreateCloudSyslogSourceReturnsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request CloudSyslogSourceRequest
+		switch r.Method {
+		case "POST":
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Errorf("Unable to unmarshal CloudSyslogSource, got `%s`", body)
+			}
+			if request.Source.SourceType != "Cloudsyslog" || request.Source.Token != "" {
+				t.Errorf("Expected a Cloudsyslog source without a token, got %+v", request.Source)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			request.Source = defaultCloudSyslogSource
+			w.Header().Set("ETag", "etag")
+		}
+		request.Source.ID = 2
+		request.Source.Token = "a1b2c3@41123"
+		body, _ := json.Marshal(request)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, err := c.CreateCloudSyslogSource(1, CloudSyslogSource{Name: "rsyslog", Token: "ignored"})
+	if err != nil {
+		t.Errorf("CreateCloudSyslogSource() returned an error: %s", err)
+		return
+	}
+	if source.ID != 2 || source.Token != "a1b2c3@41123" {
+		t.Errorf("CreateCloudSyslogSource() expected the generated token, got %+v", source)
+	}
+
+	c, _ = NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+	source, _, err = c.GetCloudSyslogSource(1, 2)
+	if err != nil {
+		t.Errorf("GetCloudSyslogSource() returned an error: %s", err)
+		return
+	}
+	if source.Token != maskedSecret || !source.Equivalent(defaultCloudSyslogSource) {
+		t.Errorf("GetCloudSyslogSource() expected a masked token, got %+v", source)
+	}
+	token, err := c.GetCloudSyslogSourceToken(1, 2)
+	if err != nil {
+		t.Errorf("GetCloudSyslogSourceToken() returned an error: %s", err)
+		return
+	}
+	if token != "a1b2c3@41123" {
+		t.Errorf("GetCloudSyslogSourceToken() expected the unmasked token, got ‘%s’", token)
+	}
+}
+
+func TestCloudSyslogSourceValidate(t *testing.T) {
+	source := CloudSyslogSource{Name: "rsyslog", TimeZone: "Mars/Olympus"}
+	if err, ok := source.Validate().(*ValidationError); !ok || err.Field != "timezone" {
+		t.Errorf("Validate() expected a ValidationError for `timezone`, got %v", err)
+	}
+	if err := defaultCloudSyslogSource.Validate(); err != nil {
+		t.Errorf("Validate() returned an error: %s", err)
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.UpdateCloudSyslogSource(1, defaultCloudSyslogSource, ""); err != ErrMissingETag {
+		t.Errorf("UpdateCloudSyslogSource() expected ErrMissingETag, got %v", err)
+	}
+}
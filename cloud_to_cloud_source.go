@@ -0,0 +1,124 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// CloudToCloudSourceRequest is a necessary wrapper for source API calls.
+type CloudToCloudSourceRequest struct {
+	Source CloudToCloudSource `json:"source"`
+}
+
+// CloudToCloudSource is a Cloud-to-Cloud integration source, which collects from third-party
+// SaaS APIs (e.g. Okta). Its settings are defined by the integration's schema, so they are
+// kept as a generic Config map.
+//
+// This is an experimental feature and requires FeatureCloudToCloudSources.
+type CloudToCloudSource struct {
+	ID          int                    `json:"id,omitempty"`
+	CollectorID int                    `json:"CollectorId,omitempty"`
+	SourceType  string                 `json:"sourceType"`
+	SchemaRef   CloudToCloudSchemaRef  `json:"schemaRef"`
+	Config      map[string]interface{} `json:"config"`
+	State       *CloudToCloudState     `json:"state,omitempty"`
+}
+
+// CloudToCloudSchemaRef names the integration a Cloud-to-Cloud source collects from.
+type CloudToCloudSchemaRef struct {
+	Type string `json:"type"`
+}
+
+// CloudToCloudState is the collection state reported by Sumo Logic.
+type CloudToCloudState struct {
+	State string `json:"state"`
+}
+
+// cloudToCloudSourceType is the sourceType of all Cloud-to-Cloud sources.
+const cloudToCloudSourceType = "Universal"
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and the state) are ignored. Config values are
+// compared as decoded from JSON, so numbers must be float64s.
+func (s CloudToCloudSource) Equivalent(other CloudToCloudSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s CloudToCloudSource) userManaged() CloudToCloudSource {
+	s.ID = 0
+	s.CollectorID = 0
+	s.State = nil
+	if len(s.Config) == 0 {
+		s.Config = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s CloudToCloudSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name, the `name` setting of its Config.
+func (s CloudToCloudSource) GetName() string {
+	name, _ := s.Config["name"].(string)
+	return name
+}
+
+// ResourceType returns ResourceTypeSource.
+func (s CloudToCloudSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s CloudToCloudSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetCloudToCloudSource gets the source with the specified ID.
+func (s *Client) GetCloudToCloudSource(collectorID int, id int) (*CloudToCloudSource, string, error) {
+	if err := s.requireFeature(FeatureCloudToCloudSources); err != nil {
+		return nil, "", err
+	}
+
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	var r = new(CloudToCloudSourceRequest)
+	resp, err := s.do("GET", path, nil, r)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
+	}
+
+	return &r.Source, resp.Header.Get("ETag"), nil
+}
+
+// CreateCloudToCloudSource creates a new CloudToCloudSource.
+func (s *Client) CreateCloudToCloudSource(collectorID int, source CloudToCloudSource) (*CloudToCloudSource, error) {
+	if err := s.requireFeature(FeatureCloudToCloudSources); err != nil {
+		return nil, err
+	}
+	if source.SourceType == "" {
+		source.SourceType = cloudToCloudSourceType
+	}
+	// The collector is identified by the path, so CollectorId isn't sent.
+	source.CollectorID = 0
+
+	path, err := formatPath("collectors/%d/sources", collectorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeSource, nil, collectorID, source); err != nil {
+		return nil, err
+	}
+	var r = new(CloudToCloudSourceRequest)
+	if _, err := s.do("POST", path, CloudToCloudSourceRequest{Source: source}, r); err != nil {
+		e, ok := badRequest(err)
+		if !ok {
+			return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check the `%s` source configuration", source.SchemaRef.Type))
+		}
+		if err := planLimitError(ResourceTypeSource, e); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
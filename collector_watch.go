@@ -0,0 +1,62 @@
+package sumologic
+
+import (
+	"context"
+	"time"
+)
+
+// CollectorAlivenessEvent reports a change in a collector's liveness, or a failure to read it.
+type CollectorAlivenessEvent struct {
+	CollectorID int
+	// Alive is the collector's current liveness.
+	Alive bool
+	// Time is when the change was observed.
+	Time time.Time
+	// Err is set if the collector couldn't be read; Alive is then meaningless.
+	Err error
+}
+
+// WatchCollectorAliveness polls the collectors with the specified IDs every interval and sends
+// an event whenever a collector goes from alive to dead or from dead to alive. The first poll
+// establishes each collector's initial state and doesn't produce events. Failed reads are sent
+// as events with Err set. The channel is closed once ctx is done.
+func (s *Client) WatchCollectorAliveness(ctx context.Context, ids []int, interval time.Duration) <-chan CollectorAlivenessEvent {
+	events := make(chan CollectorAlivenessEvent)
+
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		alive := make(map[int]bool, len(ids))
+		for {
+			for _, id := range ids {
+				collector, _, err := s.GetHostedCollector(id)
+				var event *CollectorAlivenessEvent
+				if err != nil {
+					event = &CollectorAlivenessEvent{CollectorID: id, Time: time.Now(), Err: err}
+				} else if previous, seen := alive[id]; seen && previous != collector.Alive {
+					event = &CollectorAlivenessEvent{CollectorID: id, Alive: collector.Alive, Time: time.Now()}
+				}
+				if err == nil {
+					alive[id] = collector.Alive
+				}
+				if event != nil {
+					select {
+					case events <- *event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,56 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchCollectorAliveness(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		collector := defaultCollector
+		// Alive on the first poll, dead on the second and third, alive again afterwards.
+		collector.Alive = polls == 1 || polls > 3
+		mu.Unlock()
+		body, _ := json.Marshal(CollectorRequest{Collector: collector})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := c.WatchCollectorAliveness(ctx, []int{defaultCollector.ID}, time.Millisecond)
+
+	for _, expected := range []bool{false, true} {
+		event, ok := <-events
+		if !ok {
+			t.Errorf("WatchCollectorAliveness() closed the channel early")
+			return
+		}
+		if event.Err != nil {
+			t.Errorf("WatchCollectorAliveness() sent an error: %s", event.Err)
+		}
+		if event.CollectorID != defaultCollector.ID || event.Alive != expected {
+			t.Errorf("WatchCollectorAliveness() expected alive=%t, got %+v", expected, event)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
@@ -2,6 +2,7 @@ package sumologic
 
 import (
 	"errors"
+	"fmt"
 )
 
 // Error is returned by the API
@@ -12,6 +13,27 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// ErrMissingETag is returned by Update methods when the etag is empty and there's no ETag
+// stored for the resource (see WithETagStore). Get methods return the ETag response header,
+// so an empty etag usually means a proxy between the client and Sumo Logic is stripping ETag
+// headers.
+var ErrMissingETag = errors.New("ETag missing. Updates require the ETag returned by Get; check whether a proxy strips ETag response headers")
+
+// ErrPreconditionFailed is returned by updates and WithETag deletes when the resource has
+// changed since the Get that returned the ETag.
+var ErrPreconditionFailed = errors.New("Precondition Failed. The resource was modified since its ETag was read")
+
+// ValidationError is returned when a resource fails client-side validation, before any
+// request is sent. Field is the JSON name of the offending field.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid %s: %s", e.Field, e.Message)
+}
+
 // ErrSourceNotFound is returned when a source doesn't exist on a Read or Delete.
 // It's useful for ignoring errors (e.g. delete if exists).
 var ErrSourceNotFound = errors.New("Source not found")
@@ -25,3 +47,26 @@ type Filter struct {
 	Name       string `json:"name,omitempty"`
 	Regexp     string `json:"regexp,omitempty"`
 }
+
+// DateFormat is a timestamp format tried, in order, before the built-in formats when a source
+// with AutomaticDateParsing parses the timestamps of its messages. AutomaticDateParsing is
+// enabled by default; to turn it off and use the receipt time instead, list
+// `automaticDateParsing` in the source's ForceSendFields.
+type DateFormat struct {
+	// Format is a Java SimpleDateFormat pattern, e.g. `yyyy-MM-dd HH:mm:ss,SSS`.
+	Format string `json:"format"`
+	// Locator is a regular expression whose first group matches the timestamp in a message.
+	// If empty, the first timestamp in the message is used.
+	Locator string `json:"locator,omitempty"`
+}
+
+// validateDateFormats checks that every date format has a format. Locators use Java regular
+// expression syntax, which Go can't fully parse, so they're left to the API.
+func validateDateFormats(formats []DateFormat) error {
+	for i, format := range formats {
+		if format.Format == "" {
+			return &ValidationError{Field: "defaultDateFormats", Message: fmt.Sprintf("date format %d must have a format", i)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package sumologic
+
+import (
+	"sync"
+)
+
+// defaultMaxConcurrency is the number of concurrent requests made by bulk helpers such as
+// GetCollectors unless changed with WithMaxConcurrency.
+const defaultMaxConcurrency = 4
+
+// WithMaxConcurrency sets how many requests bulk helpers such as GetCollectors may have in
+// flight at once. Lower it to stay within the organization's API rate limit.
+func WithMaxConcurrency(n int) ClientOption {
+	return func(s *Client) error {
+		if n < 1 {
+			return &ValidationError{Field: "maxConcurrency", Message: "must be at least 1"}
+		}
+		s.maxConcurrency = n
+		return nil
+	}
+}
+
+// forEach calls fn for every index in [0, n), running at most the client's maximum
+// concurrency at once, and returns when all calls have completed.
+func (s *Client) forEach(n int, fn func(i int)) {
+	workers := s.maxConcurrency
+	if workers < 1 {
+		workers = defaultMaxConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
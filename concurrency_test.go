@@ -0,0 +1,47 @@
+package sumologic
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForEachBoundsConcurrency(t *testing.T) {
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithMaxConcurrency(3))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	seen := make([]bool, 20)
+	c.forEach(len(seen), func(i int) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		seen[i] = true
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	if maxRunning > 3 {
+		t.Errorf("forEach() expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("forEach() did not call fn for index %d", i)
+		}
+	}
+}
+
+func TestWithMaxConcurrencyInvalid(t *testing.T) {
+	if _, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithMaxConcurrency(0)); err == nil {
+		t.Errorf("NewClient() did not return an error for a maximum concurrency of 0")
+	}
+}
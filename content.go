@@ -0,0 +1,132 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ContentJob is an asynchronous content export or import job.
+type ContentJob struct {
+	ID string `json:"id"`
+}
+
+// Content job statuses reported by the Content API.
+const (
+	ContentJobInProgress = "InProgress"
+	ContentJobSuccess    = "Success"
+	ContentJobFailed     = "Failed"
+)
+
+// ContentJobStatus is the progress of a content export or import job.
+type ContentJobStatus struct {
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage,omitempty"`
+	Error         *Error `json:"error,omitempty"`
+	// PollHint is the delay before polling again suggested by the API, if any.
+	PollHint time.Duration `json:"-"`
+}
+
+func (j *ContentJobStatus) setPollHint(d time.Duration) {
+	j.PollHint = d
+}
+
+// ErrContentNotFound is returned when a content item, folder or content job doesn't exist.
+var ErrContentNotFound = errors.New("Content not found")
+
+// ExportContent exports the content item (e.g. a folder or saved search) with the specified
+// ID and waits for the export to complete. The result is the item's JSON definition,
+// including its children for folders.
+func (s *Client) ExportContent(ctx context.Context, contentID string) (json.RawMessage, error) {
+	base, err := formatPath("../v2/content/%s/export", contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var job = new(ContentJob)
+	if err := s.contentRequest("POST", base, nil, nil, job); err != nil {
+		return nil, err
+	}
+	statusPath, err := formatPath("../v2/content/%s/export/%s/status", contentID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.waitForContentJob(ctx, statusPath); err != nil {
+		return nil, err
+	}
+
+	resultPath, err := formatPath("../v2/content/%s/export/%s/result", contentID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	var result json.RawMessage
+	if err := s.contentRequest("GET", resultPath, nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportContent imports a content definition, as returned by ExportContent, into the folder
+// with the specified ID and waits for the import to complete. If overwrite is true, an existing
+// item with the same name is replaced.
+func (s *Client) ImportContent(ctx context.Context, folderID string, content json.RawMessage, overwrite bool) error {
+	base, err := formatPath("../v2/content/folders/%s/import", folderID)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("overwrite", strconv.FormatBool(overwrite))
+
+	var job = new(ContentJob)
+	if err := s.contentRequest("POST", base, query, content, job); err != nil {
+		return err
+	}
+	statusPath, err := formatPath("../v2/content/folders/%s/import/%s/status", folderID, job.ID)
+	if err != nil {
+		return err
+	}
+	return s.waitForContentJob(ctx, statusPath)
+}
+
+// waitForContentJob polls a content job status until it succeeds, fails, the maximum wait
+// is exceeded or ctx is done.
+func (s *Client) waitForContentJob(ctx context.Context, statusPath string) error {
+	return s.pollJob(ctx, func() (bool, time.Duration, error) {
+		var status = new(ContentJobStatus)
+		if err := s.contentRequest("GET", statusPath, nil, nil, status); err != nil {
+			return false, 0, err
+		}
+		switch status.Status {
+		case ContentJobSuccess:
+			return true, 0, nil
+		case ContentJobFailed:
+			if status.Error != nil && status.Error.Message != "" {
+				return false, 0, fmt.Errorf("Content job failed: %s", status.Error.Message)
+			}
+			return false, 0, fmt.Errorf("Content job failed: %s", status.StatusMessage)
+		}
+		return false, status.PollHint, nil
+	})
+}
+
+// contentRequest sends a Content API request and decodes the response into out.
+func (s *Client) contentRequest(method, path string, query url.Values, body []byte, out interface{}) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := s.do(method, path, body, out)
+	if err != nil {
+		if _, ok := badRequest(err); ok {
+			return err
+		}
+		err = errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check the content definition"))
+		return errorForStatus(err, http.StatusNotFound, ErrContentNotFound)
+	}
+	recordPollHint(out, resp.Header)
+	return nil
+}
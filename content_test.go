@@ -0,0 +1,120 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestExportContentOK(t *testing.T) {
+	defer func(p backoff.Policy) { defaultJobPolling = p }(defaultJobPolling)
+	defaultJobPolling = backoff.Policy{Initial: time.Millisecond}
+
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/api/v2/content/0000000000000001/export":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"job"}`))
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/content/0000000000000001/export/job/status":
+			polls++
+			status := ContentJobInProgress
+			if polls > 1 {
+				status = ContentJobSuccess
+			}
+			body, _ := json.Marshal(ContentJobStatus{Status: status})
+			w.Write(body)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/content/0000000000000001/export/job/result":
+			w.Write([]byte(savedSearchFolder))
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	content, err := c.ExportContent(context.Background(), "0000000000000001")
+	if err != nil {
+		t.Errorf("ExportContent() returned an error: %s", err)
+		return
+	}
+	searches, err := ListSavedSearches(content)
+	if err != nil || len(searches) != 1 {
+		t.Errorf("ExportContent() expected the exported folder, got `%s`", content)
+	}
+}
+
+func TestExportContentEscapedID(t *testing.T) {
+	defer func(p backoff.Policy) { defaultJobPolling = p }(defaultJobPolling)
+	defaultJobPolling = backoff.Policy{Initial: time.Millisecond}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/api/v2/content/50%25/export":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"job"}`))
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/content/50%25/export/job/status":
+			body, _ := json.Marshal(ContentJobStatus{Status: ContentJobSuccess})
+			w.Write(body)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/content/50%25/export/job/result":
+			w.Write([]byte(savedSearchFolder))
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.ExportContent(context.Background(), "50%"); err != nil {
+		t.Errorf("ExportContent() returned an error: %s", err)
+	}
+}
+
+func TestImportContentFailed(t *testing.T) {
+	defer func(p backoff.Policy) { defaultJobPolling = p }(defaultJobPolling)
+	defaultJobPolling = backoff.Policy{Initial: time.Millisecond}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/api/v2/content/folders/0000000000000002/import":
+			if r.URL.Query().Get("overwrite") != "true" {
+				t.Errorf("Expected overwrite=true, got ‘%s’", r.URL.RawQuery)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"job"}`))
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/content/folders/0000000000000002/import/job/status":
+			w.Write([]byte(`{"status":"Failed","error":{"code":"content:invalid","message":"Invalid cron expression"}}`))
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.ImportContent(context.Background(), "0000000000000002", json.RawMessage(savedSearchFolder), true)
+	if err == nil || err.Error() != "Content job failed: Invalid cron expression" {
+		t.Errorf("ImportContent() expected the job failure, got %v", err)
+	}
+}
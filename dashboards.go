@@ -0,0 +1,225 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Dashboard panel types.
+const (
+	PanelTypeSumoSearch = "SumoSearchPanel"
+	PanelTypeText       = "TextPanel"
+)
+
+// Dashboard is a dashboard of the Dashboards (v2) API.
+type Dashboard struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// FolderID is the content folder the dashboard is created in; the personal folder if empty.
+	FolderID         string            `json:"folderId,omitempty"`
+	TopologyLabelMap *TopologyLabelMap `json:"topologyLabelMap,omitempty"`
+	Domain           string            `json:"domain,omitempty"`
+	// RefreshInterval is the auto-refresh interval in seconds; 0 disables auto-refresh.
+	RefreshInterval int `json:"refreshInterval,omitempty"`
+	// TimeRange is the default time range of the dashboard, e.g. a BeginBoundedTimeRange.
+	// It's kept as JSON as the API has several time range and boundary types.
+	TimeRange json.RawMessage     `json:"timeRange,omitempty"`
+	Panels    []DashboardPanel    `json:"panels,omitempty"`
+	Layout    *DashboardLayout    `json:"layout,omitempty"`
+	Variables []DashboardVariable `json:"variables,omitempty"`
+	// Theme is `Light` or `Dark`.
+	Theme string `json:"theme,omitempty"`
+}
+
+// TopologyLabelMap maps topology labels, such as `cluster`, to their values.
+type TopologyLabelMap struct {
+	Data map[string][]string `json:"data"`
+}
+
+// DashboardPanel is a panel of a dashboard. Queries are set for PanelTypeSumoSearch panels,
+// and Text for PanelTypeText panels.
+type DashboardPanel struct {
+	ID string `json:"id,omitempty"`
+	// Key identifies the panel in the dashboard's layout.
+	Key         string `json:"key"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	PanelType   string `json:"panelType"`
+	// VisualSettings is the panel's chart settings, serialized as a JSON string.
+	VisualSettings                         string           `json:"visualSettings,omitempty"`
+	KeepVisualSettingsConsistentWithParent bool             `json:"keepVisualSettingsConsistentWithParent,omitempty"`
+	Queries                                []DashboardQuery `json:"queries,omitempty"`
+	// TimeRange overrides the dashboard's time range for the panel.
+	TimeRange        json.RawMessage   `json:"timeRange,omitempty"`
+	ColoringRules    []json.RawMessage `json:"coloringRules,omitempty"`
+	LinkedDashboards []json.RawMessage `json:"linkedDashboards,omitempty"`
+	Text             string            `json:"text,omitempty"`
+}
+
+// DashboardQuery is a query of a search panel.
+type DashboardQuery struct {
+	QueryString string `json:"queryString"`
+	// QueryType is `Logs` or `Metrics`.
+	QueryType string `json:"queryType"`
+	// QueryKey is the query's letter in the panel, e.g. `A`.
+	QueryKey         string          `json:"queryKey"`
+	MetricsQueryMode string          `json:"metricsQueryMode,omitempty"`
+	MetricsQueryData json.RawMessage `json:"metricsQueryData,omitempty"`
+	ParseMode        string          `json:"parseMode,omitempty"`
+	TimeSource       string          `json:"timeSource,omitempty"`
+}
+
+// DashboardLayout places a dashboard's panels.
+type DashboardLayout struct {
+	// LayoutType is `Grid`.
+	LayoutType       string                     `json:"layoutType"`
+	LayoutStructures []DashboardLayoutStructure `json:"layoutStructures"`
+}
+
+// DashboardLayoutStructure is the position of a panel, identified by its key.
+type DashboardLayoutStructure struct {
+	Key string `json:"key"`
+	// Structure is the panel's position and size serialized as a JSON string, e.g.
+	// `{"height":5,"width":6,"x":0,"y":0}`.
+	Structure string `json:"structure"`
+}
+
+// DashboardVariable is a variable of a dashboard, used as `{{name}}` in its queries.
+type DashboardVariable struct {
+	ID               string                   `json:"id,omitempty"`
+	Name             string                   `json:"name"`
+	DisplayName      string                   `json:"displayName,omitempty"`
+	DefaultValue     string                   `json:"defaultValue,omitempty"`
+	SourceDefinition VariableSourceDefinition `json:"sourceDefinition"`
+	AllowMultiSelect bool                     `json:"allowMultiSelect,omitempty"`
+	IncludeAllOption bool                     `json:"includeAllOption"`
+	HideFromUI       bool                     `json:"hideFromUI,omitempty"`
+	// ValueType is `Any`, `Numeric` or `String`.
+	ValueType string `json:"valueType,omitempty"`
+}
+
+// VariableSourceDefinition is where the values of a dashboard variable come from. Query and
+// Field are set for `LogQueryVariableSourceDefinition`, Filter and Key for
+// `MetadataVariableSourceDefinition` and Values for `CsvVariableSourceDefinition`.
+type VariableSourceDefinition struct {
+	VariableSourceType string `json:"variableSourceType"`
+	Query              string `json:"query,omitempty"`
+	Field              string `json:"field,omitempty"`
+	Filter             string `json:"filter,omitempty"`
+	Key                string `json:"key,omitempty"`
+	// Values is a comma-separated list of values.
+	Values string `json:"values,omitempty"`
+}
+
+// ErrDashboardNotFound is returned when a dashboard doesn't exist on a Get, Update or Delete.
+var ErrDashboardNotFound = errors.New("Dashboard not found")
+
+// Validate checks the dashboard for errors the API would reject, without sending a request.
+func (d Dashboard) Validate() error {
+	if d.Title == "" {
+		return &ValidationError{Field: "title", Message: "must not be empty"}
+	}
+	keys := make(map[string]bool, len(d.Panels))
+	for _, panel := range d.Panels {
+		if panel.Key == "" {
+			return &ValidationError{Field: "panels", Message: fmt.Sprintf("panel `%s` must have a key", panel.Title)}
+		}
+		if keys[panel.Key] {
+			return &ValidationError{Field: "panels", Message: fmt.Sprintf("duplicate panel key `%s`", panel.Key)}
+		}
+		if panel.PanelType == "" {
+			return &ValidationError{Field: "panels", Message: fmt.Sprintf("panel `%s` must have a panelType", panel.Key)}
+		}
+		keys[panel.Key] = true
+	}
+	if d.Layout != nil {
+		for _, structure := range d.Layout.LayoutStructures {
+			if !keys[structure.Key] {
+				return &ValidationError{Field: "layout", Message: fmt.Sprintf("`%s` is not the key of a panel", structure.Key)}
+			}
+		}
+	}
+	for _, variable := range d.Variables {
+		if variable.Name == "" {
+			return &ValidationError{Field: "variables", Message: "every variable must have a name"}
+		}
+	}
+	return nil
+}
+
+// CreateDashboard creates a new dashboard.
+func (s *Client) CreateDashboard(dashboard Dashboard) (*Dashboard, error) {
+	if err := dashboard.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeDashboard, nil, nil, dashboard); err != nil {
+		return nil, err
+	}
+	var d = new(Dashboard)
+	if _, err := s.do("POST", "../v2/dashboards", dashboard, d); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check the `%s` dashboard", dashboard.Title))
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeDashboard, d.ID, nil, d)
+	return d, nil
+}
+
+// GetDashboard gets the dashboard with the specified ID.
+func (s *Client) GetDashboard(id string) (*Dashboard, error) {
+	path, err := formatPath("../v2/dashboards/%s", id)
+	if err != nil {
+		return nil, err
+	}
+	var d = new(Dashboard)
+	if _, err := s.do("GET", path, nil, d); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrDashboardNotFound)
+	}
+	return d, nil
+}
+
+// UpdateDashboard replaces the dashboard with the ID of dashboard.
+func (s *Client) UpdateDashboard(dashboard Dashboard) (*Dashboard, error) {
+	if err := dashboard.Validate(); err != nil {
+		return nil, err
+	}
+	path, err := formatPath("../v2/dashboards/%s", dashboard.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeDashboard, dashboard.ID, nil, dashboard); err != nil {
+		return nil, err
+	}
+	var d = new(Dashboard)
+	if _, err := s.do("PUT", path, dashboard, d); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusNotFound, ErrDashboardNotFound)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeDashboard, d.ID, nil, d)
+	return d, nil
+}
+
+// DeleteDashboard deletes the dashboard with the specified ID.
+func (s *Client) DeleteDashboard(id string) error {
+	path, err := formatPath("../v2/dashboards/%s", id)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeDelete, ResourceTypeDashboard, id, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do("DELETE", path, nil, nil); err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrDashboardNotFound)
+	}
+
+	s.recordChange(ChangeDelete, ResourceTypeDashboard, id, nil, nil)
+	return nil
+}
@@ -0,0 +1,122 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultDashboard = Dashboard{
+	ID:        "9jS3sGpbBYNm",
+	Title:     "Overview",
+	FolderID:  "0000000000ABCDEF",
+	TimeRange: json.RawMessage(`{"type":"BeginBoundedTimeRange","from":{"type":"RelativeTimeRangeBoundary","relativeTime":"-15m"}}`),
+	Panels: []DashboardPanel{
+		{
+			Key:       "panelA",
+			Title:     "Errors",
+			PanelType: PanelTypeSumoSearch,
+			Queries:   []DashboardQuery{{QueryString: "error | count by _sourceCategory", QueryType: "Logs", QueryKey: "A"}},
+		},
+		{Key: "panelB", PanelType: PanelTypeText, Text: "## Runbook"},
+	},
+	Layout: &DashboardLayout{
+		LayoutType: "Grid",
+		LayoutStructures: []DashboardLayoutStructure{
+			{Key: "panelA", Structure: `{"height":5,"width":6,"x":0,"y":0}`},
+			{Key: "panelB", Structure: `{"height":5,"width":6,"x":6,"y":0}`},
+		},
+	},
+	Variables: []DashboardVariable{
+		{Name: "category", SourceDefinition: VariableSourceDefinition{VariableSourceType: "CsvVariableSourceDefinition", Values: "prod,staging"}, IncludeAllOption: true},
+	},
+}
+
+func TestDashboardCRUD(t *testing.T) {
+	var stored []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/api/v2/dashboards":
+			body, _ := io.ReadAll(r.Body)
+			d := new(Dashboard)
+			if err := json.Unmarshal(body, &d); err != nil {
+				t.Errorf("Unable to unmarshal Dashboard, got `%s`", body)
+			}
+			d.ID = defaultDashboard.ID
+			stored, _ = json.Marshal(d)
+			w.Write(stored)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/v2/dashboards/"+defaultDashboard.ID:
+			w.Write(stored)
+		case r.Method == "PUT" && r.URL.EscapedPath() == "/api/v2/dashboards/"+defaultDashboard.ID:
+			stored, _ = io.ReadAll(r.Body)
+			w.Write(stored)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/api/v2/dashboards/"+defaultDashboard.ID:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	dashboard := defaultDashboard
+	dashboard.ID = ""
+	created, err := c.CreateDashboard(dashboard)
+	if err != nil {
+		t.Errorf("CreateDashboard() returned an error: %s", err)
+		return
+	}
+	if created.ID != defaultDashboard.ID {
+		t.Errorf("CreateDashboard() expected ID ‘%s’, got ‘%s’", defaultDashboard.ID, created.ID)
+	}
+
+	got, err := c.GetDashboard(defaultDashboard.ID)
+	if err != nil {
+		t.Errorf("GetDashboard() returned an error: %s", err)
+		return
+	}
+	if len(got.Panels) != 2 || got.Panels[0].Queries[0].QueryKey != "A" || got.Panels[1].Text != "## Runbook" {
+		t.Errorf("GetDashboard() returned unexpected panels: %+v", got.Panels)
+	}
+	if len(got.Layout.LayoutStructures) != 2 || got.Variables[0].SourceDefinition.Values != "prod,staging" {
+		t.Errorf("GetDashboard() returned an unexpected layout or variables: %+v %+v", got.Layout, got.Variables)
+	}
+
+	got.Title = "Service overview"
+	updated, err := c.UpdateDashboard(*got)
+	if err != nil {
+		t.Errorf("UpdateDashboard() returned an error: %s", err)
+		return
+	}
+	if updated.Title != "Service overview" {
+		t.Errorf("UpdateDashboard() expected the new title, got ‘%s’", updated.Title)
+	}
+
+	if err := c.DeleteDashboard(defaultDashboard.ID); err != nil {
+		t.Errorf("DeleteDashboard() returned an error: %s", err)
+	}
+	if _, err := c.GetDashboard("missing"); err != ErrDashboardNotFound {
+		t.Errorf("GetDashboard() expected ErrDashboardNotFound, got %v", err)
+	}
+}
+
+func TestDashboardValidate(t *testing.T) {
+	invalid := defaultDashboard
+	invalid.Layout = &DashboardLayout{LayoutType: "Grid", LayoutStructures: []DashboardLayoutStructure{{Key: "panelC"}}}
+	if verr, ok := invalid.Validate().(*ValidationError); !ok || verr.Field != "layout" {
+		t.Errorf("Validate() expected a layout error for an unknown panel key, got %v", invalid.Validate())
+	}
+
+	invalid = defaultDashboard
+	invalid.Title = ""
+	if verr, ok := invalid.Validate().(*ValidationError); !ok || verr.Field != "title" {
+		t.Errorf("Validate() expected a title error, got %v", invalid.Validate())
+	}
+}
@@ -0,0 +1,81 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// VolumeUsage is the ingest volume of a single collector or source category.
+type VolumeUsage struct {
+	Name     string
+	Bytes    int64
+	Messages int64
+}
+
+// volumeQuery aggregates the data volume index for the given _sourceCategory
+// (`collector_volume` or `sourcecategory_volume`) per collector or source category.
+const volumeQuery = `_index=sumologic_volume _sourceCategory=%s
+| parse regex "\"(?<name>[^\"]+)\"\:\{\"sizeInBytes\"\:(?<bytes>\d+),\"count\"\:(?<messages>\d+)\}" multi
+| sum(bytes) as bytes, sum(messages) as messages by name
+| sort by bytes`
+
+// searchJobTimeFormat is the ISO 8601 format accepted by the Search Job API.
+const searchJobTimeFormat = "2006-01-02T15:04:05"
+
+// volumeRecordsPageSize is the number of records requested per page of volume results.
+const volumeRecordsPageSize = 10000
+
+// GetCollectorVolume returns the ingest volume per collector between from and to, largest
+// first. It runs a search job against the data volume index, which must be enabled for the
+// organization, and waits for it to complete.
+func (s *Client) GetCollectorVolume(ctx context.Context, from, to time.Time) ([]VolumeUsage, error) {
+	return s.runVolumeQuery(ctx, fmt.Sprintf(volumeQuery, "collector_volume"), from, to)
+}
+
+// GetSourceCategoryVolume returns the ingest volume per source category between from and to,
+// largest first. Like GetCollectorVolume, it requires the data volume index.
+func (s *Client) GetSourceCategoryVolume(ctx context.Context, from, to time.Time) ([]VolumeUsage, error) {
+	return s.runVolumeQuery(ctx, fmt.Sprintf(volumeQuery, "sourcecategory_volume"), from, to)
+}
+
+func (s *Client) runVolumeQuery(ctx context.Context, query string, from, to time.Time) ([]VolumeUsage, error) {
+	job, err := s.createSearchJob(ctx, SearchJobRequest{
+		Query:    query,
+		From:     from.UTC().Format(searchJobTimeFormat),
+		To:       to.UTC().Format(searchJobTimeFormat),
+		TimeZone: "UTC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.DeleteSearchJob(job.ID)
+
+	status, err := s.waitForSearchJob(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]VolumeUsage, 0, status.RecordCount)
+	for offset := 0; offset < status.RecordCount; offset += volumeRecordsPageSize {
+		records, err := s.getSearchJobRecords(ctx, job.ID, offset, volumeRecordsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records.Records {
+			u := VolumeUsage{Name: record.Map["name"]}
+			if u.Bytes, err = strconv.ParseInt(record.Map["bytes"], 10, 64); err != nil {
+				return nil, fmt.Errorf("Unexpected bytes `%s` for `%s` in data volume results", record.Map["bytes"], u.Name)
+			}
+			if u.Messages, err = strconv.ParseInt(record.Map["messages"], 10, 64); err != nil {
+				return nil, fmt.Errorf("Unexpected messages `%s` for `%s` in data volume results", record.Map["messages"], u.Name)
+			}
+			usage = append(usage, u)
+		}
+		if len(records.Records) == 0 {
+			break
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,45 @@
+package sumologic
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestGetCollectorVolume(t *testing.T) {
+	defer func(p backoff.Policy) { defaultJobPolling = p }(defaultJobPolling)
+	defaultJobPolling = backoff.Policy{Initial: time.Millisecond}
+
+	fake := &searchJobServer{t: t, records: []SearchJobRow{
+		{Map: map[string]string{"name": "prod", "bytes": "2048", "messages": "20"}},
+		{Map: map[string]string{"name": "dev", "bytes": "1024", "messages": "10"}},
+	}}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	to := time.Now()
+	usage, err := c.GetCollectorVolume(context.Background(), to.Add(-24*time.Hour), to)
+	if err != nil {
+		t.Errorf("GetCollectorVolume() returned an error: %s", err)
+		return
+	}
+	if len(usage) != 2 || usage[0] != (VolumeUsage{Name: "prod", Bytes: 2048, Messages: 20}) {
+		t.Errorf("GetCollectorVolume() returned unexpected usage: %+v", usage)
+	}
+	if !strings.Contains(fake.query, "_sourceCategory=collector_volume") {
+		t.Errorf("GetCollectorVolume() expected a collector_volume query, got `%s`", fake.query)
+	}
+	if !fake.deleted {
+		t.Errorf("GetCollectorVolume() did not delete the search job")
+	}
+}
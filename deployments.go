@@ -0,0 +1,139 @@
+package sumologic
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sumo Logic deployments. An organization's deployment is shown in its account settings and
+// in the URL of the web application, e.g. `service.us2.sumologic.com` for us2.
+const (
+	DeploymentUS1 = "us1"
+	DeploymentUS2 = "us2"
+	DeploymentEU  = "eu"
+	DeploymentAU  = "au"
+	DeploymentDE  = "de"
+	DeploymentJP  = "jp"
+	DeploymentCA  = "ca"
+	DeploymentIN  = "in"
+	DeploymentKR  = "kr"
+	DeploymentCH  = "ch"
+	// DeploymentFed is the FedRAMP deployment for US government customers.
+	DeploymentFed = "fed"
+)
+
+// Deployment holds the URLs of a Sumo Logic deployment.
+type Deployment struct {
+	Name string
+	// APIURL is the API base URL, as accepted by NewClient.
+	APIURL string
+	// CollectorURL is the endpoint installed collectors register with.
+	CollectorURL string
+}
+
+// deployments lists every Sumo Logic deployment by name.
+var deployments = map[string]Deployment{
+	DeploymentUS1: {Name: DeploymentUS1, APIURL: "https://api.sumologic.com/api/v1/", CollectorURL: "https://collectors.sumologic.com"},
+	DeploymentUS2: {Name: DeploymentUS2, APIURL: "https://api.us2.sumologic.com/api/v1/", CollectorURL: "https://collectors.us2.sumologic.com"},
+	DeploymentEU:  {Name: DeploymentEU, APIURL: "https://api.eu.sumologic.com/api/v1/", CollectorURL: "https://collectors.eu.sumologic.com"},
+	DeploymentAU:  {Name: DeploymentAU, APIURL: "https://api.au.sumologic.com/api/v1/", CollectorURL: "https://collectors.au.sumologic.com"},
+	DeploymentDE:  {Name: DeploymentDE, APIURL: "https://api.de.sumologic.com/api/v1/", CollectorURL: "https://collectors.de.sumologic.com"},
+	DeploymentJP:  {Name: DeploymentJP, APIURL: "https://api.jp.sumologic.com/api/v1/", CollectorURL: "https://collectors.jp.sumologic.com"},
+	DeploymentCA:  {Name: DeploymentCA, APIURL: "https://api.ca.sumologic.com/api/v1/", CollectorURL: "https://collectors.ca.sumologic.com"},
+	DeploymentIN:  {Name: DeploymentIN, APIURL: "https://api.in.sumologic.com/api/v1/", CollectorURL: "https://collectors.in.sumologic.com"},
+	DeploymentKR:  {Name: DeploymentKR, APIURL: "https://api.kr.sumologic.com/api/v1/", CollectorURL: "https://collectors.kr.sumologic.com"},
+	DeploymentCH:  {Name: DeploymentCH, APIURL: "https://api.ch.sumologic.com/api/v1/", CollectorURL: "https://collectors.ch.sumologic.com"},
+	DeploymentFed: {Name: DeploymentFed, APIURL: "https://api.fed.sumologic.com/api/v1/", CollectorURL: "https://collectors.fed.sumologic.com"},
+}
+
+// LookupDeployment returns the URLs of the deployment with the specified name, e.g. `us2`.
+// Names are case-insensitive.
+func LookupDeployment(name string) (Deployment, bool) {
+	d, ok := deployments[strings.ToLower(name)]
+	return d, ok
+}
+
+// Deployments returns all Sumo Logic deployments, sorted by name.
+func Deployments() []Deployment {
+	all := make([]Deployment, 0, len(deployments))
+	for _, d := range deployments {
+		all = append(all, d)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// deploymentNames returns the names of all deployments, sorted and separated by commas.
+func deploymentNames() string {
+	names := make([]string, 0, len(deployments))
+	for _, d := range Deployments() {
+		names = append(names, d.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// NewClientForDeployment returns a new sumologic.Client for the API of a Sumo Logic
+// deployment (e.g. `us2` or `fed`), so callers don't need to know its URL.
+//
+// The FedRAMP deployment (`fed`) doesn't accept long-lived tokens, only access keys, so
+// authToken must be an access key token as returned by AccessKeyToken. Its client requires
+// TLS 1.2 or later.
+func NewClientForDeployment(deployment, authToken string, options ...ClientOption) (*Client, error) {
+	d, ok := LookupDeployment(deployment)
+	if !ok {
+		return nil, &ValidationError{Field: "deployment", Message: fmt.Sprintf("unknown Sumo Logic deployment `%s`, expected one of %s", deployment, deploymentNames())}
+	}
+	if d.Name == DeploymentFed {
+		if !isAccessKeyToken(authToken) {
+			return nil, &ValidationError{Field: "authToken", Message: "the fed deployment only accepts access keys, expected the Base64 encoding of `<accessId>:<accessKey>` as returned by AccessKeyToken"}
+		}
+		// Applied last, so that WithTLSConfig can't lower the minimum version. The options are
+		// copied so that the caller's slice is never written to.
+		options = append(append([]ClientOption(nil), options...), withMinTLSVersion(tls.VersionTLS12))
+	}
+	return NewClient(authToken, d.APIURL, options...)
+}
+
+// isAccessKeyToken reports whether token is the strict Base64 encoding of
+// `<accessId>:<accessKey>`, with an access ID and key that are non-empty and free of
+// whitespace and colons, rather than a long-lived token.
+func isAccessKeyToken(token string) bool {
+	decoded, err := base64.StdEncoding.Strict().DecodeString(token)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || strings.IndexFunc(part, func(r rune) bool { return r <= ' ' || r == 0x7f }) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// withMinTLSVersion requires at least the given TLS version for API connections. It changes
+// copies of the transport and TLS configuration, never those of a client passed to
+// WithHTTPClient or http.DefaultTransport.
+func withMinTLSVersion(version uint16) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		} else {
+			t.TLSClientConfig = t.TLSClientConfig.Clone()
+		}
+		if t.TLSClientConfig.MinVersion < version {
+			t.TLSClientConfig.MinVersion = version
+		}
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package sumologic
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewClientForDeployment(t *testing.T) {
+	c, err := NewClientForDeployment("US2", "accessToken")
+	if err != nil {
+		t.Errorf("NewClientForDeployment() returned an error: %s", err)
+		return
+	}
+	if c.EndpointURL.String() != "https://api.us2.sumologic.com/api/v1/" {
+		t.Errorf("NewClientForDeployment() expected the us2 endpoint, got `%s`", c.EndpointURL)
+	}
+
+	_, err = NewClientForDeployment("mars", "accessToken")
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "deployment" || !strings.Contains(verr.Message, "us1, us2") {
+		t.Errorf("NewClientForDeployment() returned ‘%v’ for an unknown deployment, expected a deployment ValidationError", err)
+	}
+}
+
+func TestNewClientForDeploymentFed(t *testing.T) {
+	token := base64.StdEncoding.EncodeToString([]byte("suAbCdEf:secretKey"))
+	c, err := NewClientForDeployment(DeploymentFed, token)
+	if err != nil {
+		t.Errorf("NewClientForDeployment() returned an error: %s", err)
+		return
+	}
+	if c.EndpointURL.String() != "https://api.fed.sumologic.com/api/v1/" {
+		t.Errorf("NewClientForDeployment() expected the fed endpoint, got `%s`", c.EndpointURL)
+	}
+	transport := c.httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("NewClientForDeployment() expected a minimum of TLS 1.2 for fed")
+	}
+
+	config := &tls.Config{}
+	shared := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+	c, err = NewClientForDeployment(DeploymentFed, token, WithHTTPClient(shared))
+	if err != nil {
+		t.Errorf("NewClientForDeployment() returned an error: %s", err)
+		return
+	}
+	if shared.Transport.(*http.Transport).TLSClientConfig != config || config.MinVersion != 0 {
+		t.Errorf("NewClientForDeployment() expected the caller's TLS configuration to be unchanged")
+	}
+	if c.httpClient.Transport.(*http.Transport).TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("NewClientForDeployment() expected a minimum of TLS 1.2 with a custom HTTP client")
+	}
+	if _, err := NewClientForDeployment(DeploymentFed, token, WithHTTPClient(http.DefaultClient)); err != nil {
+		t.Errorf("NewClientForDeployment() returned an error: %s", err)
+	}
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil && config.MinVersion != 0 {
+		t.Errorf("NewClientForDeployment() expected http.DefaultTransport to be unchanged")
+	}
+
+	options := make([]ClientOption, 1, 2)
+	options[0] = WithHTTPClient(shared)
+	if _, err := NewClientForDeployment(DeploymentFed, token, options...); err != nil {
+		t.Errorf("NewClientForDeployment() returned an error: %s", err)
+	}
+	if extra := options[:2][1]; extra != nil {
+		t.Errorf("NewClientForDeployment() expected the caller's options to be unchanged")
+	}
+}
+
+func TestNewClientForDeploymentFedAuthToken(t *testing.T) {
+	if _, err := NewClientForDeployment(DeploymentFed, AccessKeyToken("suAbCdEf", "secretKey")); err != nil {
+		t.Errorf("NewClientForDeployment() returned an error for an access key: %s", err)
+	}
+
+	longLived := []string{
+		"accessToken",
+		"U1VNT1RPS0VOX2xvbmdMaXZlZFRva2Vu",
+		base64.StdEncoding.EncodeToString([]byte("no-separator")),
+		base64.StdEncoding.EncodeToString([]byte(":key")),
+		base64.StdEncoding.EncodeToString([]byte("suAbCdEf:")),
+		base64.StdEncoding.EncodeToString([]byte("suAbCdEf:secret:key")),
+		base64.StdEncoding.EncodeToString([]byte("suAbCdEf:secret key")),
+	}
+	for _, token := range longLived {
+		_, err := NewClientForDeployment(DeploymentFed, token)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != "authToken" {
+			t.Errorf("NewClientForDeployment() returned ‘%v’ for `%s`, expected an authToken ValidationError", err, token)
+		}
+		if _, err := NewClientForDeployment(DeploymentUS2, token); err != nil {
+			t.Errorf("NewClientForDeployment() returned an error for `%s` on us2: %s", token, err)
+		}
+	}
+}
+
+func TestDeployments(t *testing.T) {
+	all := Deployments()
+	if len(all) != len(deployments) {
+		t.Errorf("Deployments() expected %d deployments, got %d", len(deployments), len(all))
+	}
+	for i, d := range all {
+		if i > 0 && all[i-1].Name >= d.Name {
+			t.Errorf("Deployments() expected deployments sorted by name, got ‘%s’ before ‘%s’", all[i-1].Name, d.Name)
+		}
+		api, err := url.Parse(d.APIURL)
+		if err != nil || api.Scheme != "https" || !strings.HasSuffix(api.Host, ".sumologic.com") || api.Path != "/api/v1/" {
+			t.Errorf("Deployment ‘%s’ has an invalid API URL ‘%s’", d.Name, d.APIURL)
+		}
+		collector, err := url.Parse(d.CollectorURL)
+		if err != nil || collector.Scheme != "https" || !strings.HasPrefix(collector.Host, "collectors.") {
+			t.Errorf("Deployment ‘%s’ has an invalid collector URL ‘%s’", d.Name, d.CollectorURL)
+		}
+	}
+
+	d, ok := LookupDeployment("EU")
+	if !ok || d.APIURL != "https://api.eu.sumologic.com/api/v1/" || d.CollectorURL != "https://collectors.eu.sumologic.com" {
+		t.Errorf("LookupDeployment() returned unexpected URLs for eu: %+v", d)
+	}
+	if _, ok := LookupDeployment("mars"); ok {
+		t.Errorf("LookupDeployment() expected an unknown deployment not to be found")
+	}
+}
@@ -0,0 +1,121 @@
+package sumologic
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecationNotice describes an API endpoint that Sumo Logic has marked as deprecated
+// using the Deprecation and Sunset response headers.
+type DeprecationNotice struct {
+	// Endpoint is the method and path of the deprecated endpoint, e.g. `GET collectors/{id}`.
+	Endpoint string
+	// Deprecated is when the endpoint was deprecated; zero if the header gave no date.
+	Deprecated time.Time
+	// Sunset is when the endpoint will be removed; zero if no Sunset header was sent.
+	Sunset time.Time
+	// Link is the raw Link header, which may point to migration documentation.
+	Link string
+}
+
+// WithDeprecationHandler sets a callback invoked for every response that marks its endpoint
+// as deprecated. Notices are also written to the standard logger once per endpoint.
+func WithDeprecationHandler(handler func(DeprecationNotice)) ClientOption {
+	return func(s *Client) error {
+		s.deprecationHandler = handler
+		return nil
+	}
+}
+
+// parseDeprecationNotice returns a notice if resp carries a Deprecation or Sunset header.
+func parseDeprecationNotice(endpoint string, resp *http.Response) (DeprecationNotice, bool) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return DeprecationNotice{}, false
+	}
+	if strings.EqualFold(deprecation, "false") {
+		return DeprecationNotice{}, false
+	}
+
+	notice := DeprecationNotice{
+		Endpoint: endpoint,
+		Link:     resp.Header.Get("Link"),
+	}
+	if strings.HasPrefix(deprecation, "@") {
+		if seconds, err := strconv.ParseInt(deprecation[1:], 10, 64); err == nil {
+			notice.Deprecated = time.Unix(seconds, 0).UTC()
+		}
+	} else if t, err := http.ParseTime(deprecation); err == nil {
+		notice.Deprecated = t
+	}
+	if t, err := http.ParseTime(sunset); err == nil {
+		notice.Sunset = t
+	}
+	return notice, true
+}
+
+// handleDeprecation reports a deprecation notice for resp, if any.
+func (s *Client) handleDeprecation(endpoint string, resp *http.Response) {
+	notice, ok := parseDeprecationNotice(endpoint, resp)
+	if !ok {
+		return
+	}
+	if _, logged := s.root().deprecationsLogged.LoadOrStore(endpoint, true); !logged {
+		if notice.Sunset.IsZero() {
+			log.Printf("[DEBUG] Sumo Logic API endpoint %s is deprecated", endpoint)
+		} else {
+			log.Printf("[DEBUG] Sumo Logic API endpoint %s is deprecated and will be removed on %s", endpoint, notice.Sunset.Format(time.RFC1123))
+		}
+	}
+	if s.deprecationHandler != nil {
+		s.deprecationHandler(notice)
+	}
+}
+
+// MethodDeprecation describes a call to an SDK method that has been renamed or superseded
+// and will be removed in a future major version.
+type MethodDeprecation struct {
+	// Code identifies the deprecation, e.g. `SDK-DEP-001`, so callers can be tracked and
+	// migrated systematically.
+	Code string `json:"code"`
+	// Method is the deprecated method, e.g. `Client.GetHostedCollector`.
+	Method string `json:"method"`
+	// Replacement is the method to use instead.
+	Replacement string `json:"replacement"`
+}
+
+// WithMethodDeprecationHandler sets a callback invoked for every call to a deprecated SDK
+// method. Calls are also written to the standard logger once per method, unless
+// WithoutMethodDeprecationWarnings is used.
+func WithMethodDeprecationHandler(handler func(MethodDeprecation)) ClientOption {
+	return func(s *Client) error {
+		s.methodDeprecationHandler = handler
+		return nil
+	}
+}
+
+// WithoutMethodDeprecationWarnings stops the client from logging calls to deprecated SDK
+// methods. A handler set with WithMethodDeprecationHandler is still called.
+func WithoutMethodDeprecationWarnings() ClientOption {
+	return func(s *Client) error {
+		s.silenceMethodDeprecations = true
+		return nil
+	}
+}
+
+// deprecatedMethod reports a call to a deprecated SDK method. Deprecated methods call it
+// first, before delegating to their replacement.
+func (s *Client) deprecatedMethod(d MethodDeprecation) {
+	if !s.silenceMethodDeprecations {
+		if _, logged := s.root().deprecationsLogged.LoadOrStore("method "+d.Method, true); !logged {
+			log.Printf("[WARN] %s: %s is deprecated, use %s instead", d.Code, d.Method, d.Replacement)
+		}
+	}
+	if s.methodDeprecationHandler != nil {
+		s.methodDeprecationHandler(d)
+	}
+}
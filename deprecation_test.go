@@ -0,0 +1,97 @@
+package sumologic
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeprecationHandler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1688169599")
+		w.Header().Set("Sunset", "Wed, 11 Nov 2026 23:59:59 GMT")
+		w.Header().Set("Link", `<https://help.sumologic.com/>; rel="deprecation"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var notices []DeprecationNotice
+	c, err := NewClient("accessToken", ts.URL, WithDeprecationHandler(func(n DeprecationNotice) {
+		notices = append(notices, n)
+	}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+		return
+	}
+	if len(notices) != 1 {
+		t.Errorf("Expected 1 deprecation notice, got %d", len(notices))
+		return
+	}
+	n := notices[0]
+	if n.Endpoint != "DELETE collectors/{id}" {
+		t.Errorf("Expected endpoint `DELETE collectors/{id}`, got `%s`", n.Endpoint)
+	}
+	if !n.Deprecated.Equal(time.Unix(1688169599, 0)) {
+		t.Errorf("Expected deprecation date 2023-06-30, got %s", n.Deprecated)
+	}
+	if !n.Sunset.Equal(time.Date(2026, 11, 11, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Expected sunset date 2026-11-11, got %s", n.Sunset)
+	}
+	if n.Link == "" {
+		t.Errorf("Expected the Link header to be included")
+	}
+}
+
+func TestParseDeprecationNoticeAbsent(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := parseDeprecationNotice("GET collectors", resp); ok {
+		t.Errorf("parseDeprecationNotice() returned a notice without deprecation headers")
+	}
+	resp.Header.Set("Deprecation", "true")
+	if n, ok := parseDeprecationNotice("GET collectors", resp); !ok || !n.Deprecated.IsZero() {
+		t.Errorf("parseDeprecationNotice() expected an undated notice for `Deprecation: true`, got %+v", n)
+	}
+}
+
+func TestMethodDeprecation(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	var calls []MethodDeprecation
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithMethodDeprecationHandler(func(d MethodDeprecation) {
+		calls = append(calls, d)
+	}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	d := MethodDeprecation{Code: "SDK-DEP-001", Method: "Client.OldMethod", Replacement: "Client.NewMethod"}
+	c.deprecatedMethod(d)
+	c.WithAuth("otherToken").deprecatedMethod(d)
+
+	if len(calls) != 2 || calls[0] != d {
+		t.Errorf("deprecatedMethod() expected the handler to be called twice with %+v, got %+v", d, calls)
+	}
+	if n := strings.Count(logged.String(), "SDK-DEP-001: Client.OldMethod is deprecated, use Client.NewMethod instead"); n != 1 {
+		t.Errorf("deprecatedMethod() expected one warning to be logged, got %d in ‘%s’", n, logged.String())
+	}
+
+	logged.Reset()
+	c, _ = NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithoutMethodDeprecationWarnings())
+	c.deprecatedMethod(d)
+	if logged.Len() != 0 {
+		t.Errorf("deprecatedMethod() expected no warning with WithoutMethodDeprecationWarnings, got ‘%s’", logged.String())
+	}
+}
@@ -0,0 +1,35 @@
+package sumologic
+
+import "time"
+
+// TimeFromEpochMillis converts a timestamp in milliseconds since the epoch, as used by the
+// collector and source APIs, to a time.Time in UTC. 0 means unset and converts to the zero
+// time.Time, so IsZero can be used to check for it.
+func TimeFromEpochMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// EpochMillis converts t to milliseconds since the epoch, e.g. to set
+// InstalledCollector.CutoffTimestamp. The zero time.Time converts to 0.
+func EpochMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// LastSeenAliveTime returns when the collector was last seen alive, or the zero time.Time if
+// it never was.
+func (c Collector) LastSeenAliveTime() time.Time { return TimeFromEpochMillis(c.LastSeenAlive) }
+
+// LastSeenAliveTime returns when the collector was last seen alive, or the zero time.Time if
+// it never was.
+func (c InstalledCollector) LastSeenAliveTime() time.Time {
+	return TimeFromEpochMillis(c.LastSeenAlive)
+}
+
+// CutoffTime returns CutoffTimestamp as a time.Time, or the zero time.Time if it isn't set.
+func (c InstalledCollector) CutoffTime() time.Time { return TimeFromEpochMillis(c.CutoffTimestamp) }
@@ -0,0 +1,41 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEpochMillis(t *testing.T) {
+	if !TimeFromEpochMillis(0).IsZero() {
+		t.Errorf("TimeFromEpochMillis(0) expected the zero time, got %s", TimeFromEpochMillis(0))
+	}
+	if EpochMillis(time.Time{}) != 0 {
+		t.Errorf("EpochMillis() of the zero time expected 0, got %d", EpochMillis(time.Time{}))
+	}
+
+	expected := time.Date(2019, 8, 20, 12, 0, 0, 123000000, time.UTC)
+	if got := TimeFromEpochMillis(1566302400123); !got.Equal(expected) || got.Location() != time.UTC {
+		t.Errorf("TimeFromEpochMillis() expected %s, got %s", expected, got)
+	}
+	if got := EpochMillis(expected.In(time.FixedZone("EST", -5*60*60))); got != 1566302400123 {
+		t.Errorf("EpochMillis() expected 1566302400123, got %d", got)
+	}
+}
+
+func TestCollectorLastSeenAliveTime(t *testing.T) {
+	var c Collector
+	if err := json.Unmarshal([]byte(`{"name":"collector","lastSeenAlive":1566302400000}`), &c); err != nil {
+		t.Errorf("Unable to unmarshal Collector: %s", err)
+		return
+	}
+	if got := c.LastSeenAliveTime(); !got.Equal(time.Date(2019, 8, 20, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("LastSeenAliveTime() returned an unexpected time: %s", got)
+	}
+	if !(Collector{}).LastSeenAliveTime().IsZero() {
+		t.Errorf("LastSeenAliveTime() of a collector never seen alive expected the zero time")
+	}
+	if !(InstalledCollector{}).CutoffTime().IsZero() {
+		t.Errorf("CutoffTime() without a cutoff expected the zero time")
+	}
+}
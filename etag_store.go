@@ -0,0 +1,154 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ETagStore keeps the ETags of resources between a Get and a later Update, for when they're
+// made by separate processes or code paths that can't pass the ETag along. Keys are the
+// resources' canonical API paths, e.g. `collectors/1`, also for resources read another way,
+// such as by name. Implementations backed by shared storage, such
+// as Redis or a file, must be safe for concurrent use.
+type ETagStore interface {
+	// LoadETag returns the ETag stored for key, or an empty string if there is none.
+	LoadETag(key string) (string, error)
+	// StoreETag stores etag for key, replacing any ETag stored before. An empty etag, stored
+	// when the resource is deleted, removes the stored ETag.
+	StoreETag(key, etag string) error
+}
+
+// WithETagStore makes the client store the ETag of every resource it reads or updates in
+// store, and use the stored ETag when an Update method is passed an empty etag. A nil store
+// uses a new MemoryETagStore.
+//
+// Errors storing an ETag are ignored, since the request itself succeeded; a later Update with
+// an empty etag then returns ErrMissingETag as it would without a store.
+func WithETagStore(store ETagStore) ClientOption {
+	return func(s *Client) error {
+		if store == nil {
+			store = NewMemoryETagStore()
+		}
+		s.etagStore = store
+		return nil
+	}
+}
+
+// MemoryETagStore is an ETagStore that keeps ETags in memory, for the lifetime of the
+// process.
+type MemoryETagStore struct {
+	etags sync.Map
+}
+
+// NewMemoryETagStore returns an empty MemoryETagStore.
+func NewMemoryETagStore() *MemoryETagStore {
+	return &MemoryETagStore{}
+}
+
+// LoadETag returns the ETag stored for key, or an empty string if there is none.
+func (m *MemoryETagStore) LoadETag(key string) (string, error) {
+	etag, _ := m.etags.Load(key)
+	s, _ := etag.(string)
+	return s, nil
+}
+
+// StoreETag stores etag for key, or removes the ETag stored for key if etag is empty.
+func (m *MemoryETagStore) StoreETag(key, etag string) error {
+	if etag == "" {
+		m.etags.Delete(key)
+		return nil
+	}
+	m.etags.Store(key, etag)
+	return nil
+}
+
+// storeETag stores the ETag of resp, the response to a method request for path decoded into
+// out, if the client has an ETagStore. The ETags of GETs and PUTs are stored under the
+// canonical path of the returned resource, and a DELETE removes the ETag of the deleted one.
+// Responses that aren't a single resource, such as lists or a POST's, aren't stored.
+func (s *Client) storeETag(method, path string, resp *http.Response, out interface{}) {
+	if s.etagStore == nil || resp == nil {
+		return
+	}
+	switch method {
+	case "GET", "PUT":
+		if key, etag := etagKey(path, out), resp.Header.Get("ETag"); key != "" && etag != "" {
+			s.etagStore.StoreETag(key, etag)
+		}
+	case "DELETE":
+		s.etagStore.StoreETag(strings.SplitN(path, "?", 2)[0], "")
+	}
+}
+
+// etagKey returns the canonical path of the resource decoded into out from the response to a
+// request for path: path itself if it ends with the resource's ID, or the collection's path
+// and the ID for a read by name, e.g. `collectors/1` for `collectors/name/payments`. It's
+// empty if out isn't a single resource.
+func etagKey(path string, out interface{}) string {
+	id := decodedResourceID(reflect.ValueOf(out))
+	if id == "" {
+		return ""
+	}
+	path = strings.SplitN(path, "?", 2)[0]
+	segments := strings.Split(path, "/")
+	last := len(segments) - 1
+	switch {
+	case segments[last] == url.PathEscape(id):
+		return path
+	case last >= 2 && segments[last-1] == "name":
+		return strings.Join(segments[:last-1], "/") + "/" + url.PathEscape(id)
+	}
+	return ""
+}
+
+// decodedResourceID returns the ID of the resource in v, a resource struct or a request
+// wrapper such as CollectorRequest, or an empty string if it has none.
+func decodedResourceID(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if id := v.FieldByName("ID"); id.IsValid() {
+		switch id.Kind() {
+		case reflect.Int, reflect.Int64:
+			if id.Int() > 0 {
+				return strconv.FormatInt(id.Int(), 10)
+			}
+		case reflect.String:
+			return id.String()
+		}
+		return ""
+	}
+	if v.NumField() == 1 && v.Field(0).Kind() == reflect.Struct {
+		return decodedResourceID(v.Field(0))
+	}
+	return ""
+}
+
+// resolveETag returns etag, or if it's empty, the ETag stored for the resource at the path
+// formatted from format and ids as with formatPath. ErrMissingETag is returned if neither is
+// set.
+func (s *Client) resolveETag(etag string, format string, ids ...interface{}) (string, error) {
+	if etag == "" && s.etagStore != nil {
+		path, err := formatPath(format, ids...)
+		if err != nil {
+			return "", err
+		}
+		if etag, err = s.etagStore.LoadETag(path); err != nil {
+			return "", err
+		}
+	}
+	if etag == "" {
+		return "", ErrMissingETag
+	}
+	return etag, nil
+}
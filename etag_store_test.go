@@ -0,0 +1,117 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestETagStore(t *testing.T) {
+	version := 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := `"v` + strconv.Itoa(version) + `"`
+		if r.Method == "PUT" {
+			if r.Header.Get("If-Match") != current {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			version++
+			current = `"v` + strconv.Itoa(version) + `"`
+		}
+		w.Header().Set("ETag", current)
+		body, _ := json.Marshal(CollectorRequest{Collector: Collector{ID: 1, Name: "collector"}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	// The store is shared by the reading and the writing client, as it would be through e.g.
+	// Redis by separate processes.
+	store := NewMemoryETagStore()
+	reader, _ := NewClient("accessToken", ts.URL, WithETagStore(store))
+	writer, _ := NewClient("accessToken", ts.URL, WithETagStore(store))
+
+	if _, _, err := reader.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if etag, _ := store.LoadETag("collectors/1"); etag != `"v1"` {
+		t.Errorf("Expected the ETag of the Get to be stored, got ‘%s’", etag)
+	}
+	if _, err := writer.UpdateHostedCollector(Collector{ID: 1, Name: "renamed"}, ""); err != nil {
+		t.Errorf("UpdateHostedCollector() with a stored ETag returned an error: %s", err)
+		return
+	}
+	// The ETag returned by the Update is stored too, so a second Update succeeds.
+	if _, err := writer.UpdateHostedCollector(Collector{ID: 1, Name: "renamed again"}, ""); err != nil {
+		t.Errorf("UpdateHostedCollector() after an Update returned an error: %s", err)
+	}
+	// An explicit etag takes precedence over the stored one.
+	if _, err := writer.UpdateHostedCollector(Collector{ID: 1, Name: "renamed"}, `"v1"`); err != ErrPreconditionFailed {
+		t.Errorf("UpdateHostedCollector() with a stale etag expected ErrPreconditionFailed, got %v", err)
+	}
+	if _, err := writer.UpdateHostedCollector(Collector{ID: 2, Name: "other"}, ""); err != ErrMissingETag {
+		t.Errorf("UpdateHostedCollector() without a stored ETag expected ErrMissingETag, got %v", err)
+	}
+
+	// A create's response is for the new resource, not the collection it was posted to.
+	if _, err := writer.CreateHostedCollector(Collector{Name: "new", CollectorType: "Hosted"}); err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	if etag, _ := store.LoadETag("collectors"); etag != "" {
+		t.Errorf("Expected no ETag to be stored for the collection, got ‘%s’", etag)
+	}
+
+	c, _ := NewClient("accessToken", ts.URL, WithETagStore(nil))
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if _, err := c.UpdateHostedCollector(Collector{ID: 1, Name: "renamed"}, ""); err != nil {
+		t.Errorf("UpdateHostedCollector() with the default store returned an error: %s", err)
+	}
+}
+
+func TestETagStoreCanonicalKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if r.Method == "PUT" && r.Header.Get("If-Match") != `"v1"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		body, _ := json.Marshal(CollectorRequest{Collector: Collector{ID: 1, Name: "payments"}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	store := NewMemoryETagStore()
+	c, _ := NewClient("accessToken", ts.URL, WithETagStore(store))
+
+	if _, _, err := c.GetCollectorByName("payments"); err != nil {
+		t.Errorf("GetCollectorByName() returned an error: %s", err)
+		return
+	}
+	if etag, _ := store.LoadETag("collectors/1"); etag != `"v1"` {
+		t.Errorf("Expected the ETag of a read by name to be stored under ‘collectors/1’, got ‘%s’", etag)
+	}
+	if etag, _ := store.LoadETag("collectors/name/payments"); etag != "" {
+		t.Errorf("Expected no ETag to be stored under the name path, got ‘%s’", etag)
+	}
+	if _, err := c.UpdateHostedCollector(Collector{ID: 1, Name: "payments"}, ""); err != nil {
+		t.Errorf("UpdateHostedCollector() after a read by name returned an error: %s", err)
+	}
+
+	if err := c.DeleteHostedCollector(1); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+		return
+	}
+	if etag, _ := store.LoadETag("collectors/1"); etag != "" {
+		t.Errorf("Expected the ETag of a deleted collector to be removed, got ‘%s’", etag)
+	}
+}
@@ -0,0 +1,134 @@
+// Command bootstrap provisions the standard ingestion setup of a new organization in one or
+// more Sumo Logic deployments in parallel: a hosted collector with an HTTP source for
+// application logs and a CloudTrail source for the organization's AWS trail.
+//
+// Each deployment is provisioned with CreateCollectorWithSources and rollback, so a failure
+// in one deployment leaves no half-provisioned collector behind and doesn't affect the
+// others. Ingest budgets and monitors will be added to the recipe once the SDK can manage
+// them.
+//
+// Usage:
+//
+//	SUMOLOGIC_ACCESSID=... SUMOLOGIC_ACCESSKEY=... bootstrap \
+//		-deployments us1,eu -name acme -bucket acme-cloudtrail \
+//		-org o-abc123 -role arn:aws:iam::123456789012:role/sumologic
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+// config is the organization to provision.
+type config struct {
+	Name           string
+	TimeZone       string
+	BucketName     string
+	OrganizationID string
+	RoleARN        string
+}
+
+// result is the outcome of provisioning one deployment.
+type result struct {
+	Deployment string
+	Collector  *sumologic.Collector
+	SourceIDs  []int
+	Err        error
+}
+
+func main() {
+	deployments := flag.String("deployments", sumologic.DeploymentUS1, "comma-separated deployments to provision, e.g. us1,eu")
+	cfg := config{}
+	flag.StringVar(&cfg.Name, "name", "", "name of the organization, used to name the collector")
+	flag.StringVar(&cfg.TimeZone, "timezone", "UTC", "time zone of the collector")
+	flag.StringVar(&cfg.BucketName, "bucket", "", "S3 bucket of the organization trail")
+	flag.StringVar(&cfg.OrganizationID, "org", "", "AWS Organizations ID (o-…) of the trail")
+	flag.StringVar(&cfg.RoleARN, "role", "", "IAM role Sumo Logic assumes to read the bucket")
+	flag.Parse()
+
+	token := base64.StdEncoding.EncodeToString([]byte(os.Getenv("SUMOLOGIC_ACCESSID") + ":" + os.Getenv("SUMOLOGIC_ACCESSKEY")))
+	clients := map[string]*sumologic.Client{}
+	for _, deployment := range strings.Split(*deployments, ",") {
+		c, err := sumologic.NewClientForDeployment(deployment, token)
+		if err != nil {
+			log.Fatal(err)
+		}
+		clients[deployment] = c
+	}
+
+	failed := false
+	for _, r := range provision(clients, cfg) {
+		if r.Err != nil {
+			failed = true
+			log.Printf("%s: %s", r.Deployment, r.Err)
+			continue
+		}
+		log.Printf("%s: created collector %d with sources %v", r.Deployment, r.Collector.ID, r.SourceIDs)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// provision provisions cfg with each client concurrently, returning the results sorted by
+// deployment.
+func provision(clients map[string]*sumologic.Client, cfg config) []result {
+	var wg sync.WaitGroup
+	results := make(chan result, len(clients))
+	for deployment, c := range clients {
+		wg.Add(1)
+		go func(deployment string, c *sumologic.Client) {
+			defer wg.Done()
+			collector, sourceIDs, err := bootstrap(c, cfg)
+			results <- result{Deployment: deployment, Collector: collector, SourceIDs: sourceIDs, Err: err}
+		}(deployment, c)
+	}
+	wg.Wait()
+	close(results)
+
+	sorted := make([]result, 0, len(clients))
+	for r := range results {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Deployment < sorted[j].Deployment })
+	return sorted
+}
+
+// bootstrap creates the collector and its sources with c.
+func bootstrap(c *sumologic.Client, cfg config) (*sumologic.Collector, []int, error) {
+	if cfg.Name == "" {
+		return nil, nil, fmt.Errorf("A name is required")
+	}
+	if err := sumologic.ValidateAWSRoleARN(cfg.RoleARN); err != nil {
+		return nil, nil, err
+	}
+	collector := sumologic.Collector{
+		Name:          cfg.Name,
+		Description:   fmt.Sprintf("Ingestion for %s", cfg.Name),
+		Category:      cfg.Name,
+		TimeZone:      cfg.TimeZone,
+		CollectorType: sumologic.CollectorTypeHosted,
+	}
+	sources := []sumologic.SourceSpec{
+		sumologic.InheritCollectorDefaults(sumologic.HTTPSource{
+			Name:       "applications",
+			Category:   cfg.Name + "/applications",
+			SourceType: "HTTP",
+		}),
+		sumologic.InheritCollectorDefaults(sumologic.CloudTrailOrgTrailSource(sumologic.AWSBucketTemplate{
+			Name:       "cloudtrail",
+			Category:   cfg.Name + "/aws/cloudtrail",
+			BucketName: cfg.BucketName,
+			RoleARN:    cfg.RoleARN,
+		}, cfg.OrganizationID)),
+	}
+	return c.CreateCollectorWithSources(collector, sources, true)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+	"github.com/nextgenhealthcare/sumologic-sdk-go/sumologictest"
+)
+
+var defaultConfig = config{
+	Name:           "acme",
+	TimeZone:       "UTC",
+	BucketName:     "acme-cloudtrail",
+	OrganizationID: "o-abc123",
+	RoleARN:        "arn:aws:iam::123456789012:role/sumologic",
+}
+
+func TestProvision(t *testing.T) {
+	clients := map[string]*sumologic.Client{}
+	for _, deployment := range []string{"us1", "eu", "au"} {
+		ts := sumologictest.NewServer()
+		defer ts.Close()
+		c, err := ts.NewClient()
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			return
+		}
+		clients[deployment] = c
+	}
+
+	results := provision(clients, defaultConfig)
+	if len(results) != 3 || results[0].Deployment != "au" || results[2].Deployment != "us1" {
+		t.Errorf("provision() expected sorted results for 3 deployments, got %+v", results)
+		return
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("provision() returned an error for %s: %s", r.Deployment, r.Err)
+			continue
+		}
+		sources, err := clients[r.Deployment].ListSources(r.Collector.ID)
+		if err != nil {
+			t.Errorf("ListSources() returned an error: %s", err)
+			continue
+		}
+		if len(sources) != 2 || len(r.SourceIDs) != 2 {
+			t.Errorf("provision() expected 2 sources in %s, got %d", r.Deployment, len(sources))
+			continue
+		}
+		for _, source := range sources {
+			if source.TimeZone != "UTC" {
+				t.Errorf("provision() expected source ‘%s’ to inherit the collector time zone, got ‘%s’", source.Name, source.TimeZone)
+			}
+		}
+	}
+}
+
+func TestProvisionInvalidRoleARN(t *testing.T) {
+	ts := sumologictest.NewServer()
+	defer ts.Close()
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	cfg := defaultConfig
+	cfg.RoleARN = "not-an-arn"
+	results := provision(map[string]*sumologic.Client{"us1": c}, cfg)
+	if results[0].Err == nil {
+		t.Errorf("provision() expected an error for an invalid role ARN")
+		return
+	}
+	collectors, err := c.ListCollectors(sumologic.ListCollectorsOptions{})
+	if err != nil || len(collectors) != 0 {
+		t.Errorf("provision() expected no collector to be created, got %v and %v", collectors, err)
+	}
+}
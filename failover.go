@@ -0,0 +1,160 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultFailbackInterval is how often a client that has failed over checks whether its
+// primary endpoint is reachable again.
+const defaultFailbackInterval = time.Minute
+
+// failbackProbeTimeout bounds the request that checks whether the primary endpoint is back.
+const failbackProbeTimeout = 5 * time.Second
+
+// endpointFailover routes requests to the first reachable endpoint of an ordered list.
+type endpointFailover struct {
+	// endpoints are the primary endpoint followed by the fallbacks, in order.
+	endpoints []*url.URL
+	interval  time.Duration
+
+	mu        sync.Mutex
+	active    int
+	lastProbe time.Time
+}
+
+// WithFallbackEndpoints adds endpoint URLs to fail over to, in order, when the primary
+// endpoint can't be connected to, e.g. alternative hosts published during a Sumo Logic
+// incident. A request that can't connect is resent to the next endpoint, and later requests
+// use that endpoint too. While failed over, the primary endpoint is checked at most every
+// failbackInterval (a minute if zero) and used again once it responds.
+//
+// Fallback endpoints replace the scheme and host of requests, so their path must match the
+// primary endpoint's.
+func WithFallbackEndpoints(failbackInterval time.Duration, fallbackURLs ...string) ClientOption {
+	return func(s *Client) error {
+		if s.EndpointURL == nil {
+			return ErrNoEndpointURL
+		}
+		if failbackInterval <= 0 {
+			failbackInterval = defaultFailbackInterval
+		}
+		f := &endpointFailover{endpoints: []*url.URL{s.EndpointURL}, interval: failbackInterval}
+		for _, fallbackURL := range fallbackURLs {
+			u, err := url.Parse(fallbackURL)
+			if err != nil {
+				return err
+			}
+			if err := validateEndpointURL(u); err != nil {
+				return err
+			}
+			if strings.TrimSuffix(u.Path, "/") != strings.TrimSuffix(s.EndpointURL.Path, "/") {
+				return &ValidationError{Field: "fallbackURLs", Message: fmt.Sprintf("`%s` must have the same path as the endpoint URL `%s`", u, s.EndpointURL)}
+			}
+			f.endpoints = append(f.endpoints, u)
+		}
+		s.failover = f
+		return nil
+	}
+}
+
+// ActiveEndpointURL returns the endpoint URL requests are currently sent to. It's the
+// EndpointURL unless the client has failed over to a fallback endpoint.
+func (s *Client) ActiveEndpointURL() *url.URL {
+	if s.failover == nil {
+		return s.EndpointURL
+	}
+	s.failover.mu.Lock()
+	defer s.failover.mu.Unlock()
+	return s.failover.endpoints[s.failover.active]
+}
+
+// route points req at the active endpoint, failing back to the primary endpoint first if
+// it's due to be checked and responds.
+func (f *endpointFailover) route(client *http.Client, req *http.Request) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	probe := f.active > 0 && time.Since(f.lastProbe) >= f.interval
+	if probe {
+		f.lastProbe = time.Now()
+	}
+	f.mu.Unlock()
+
+	if probe && f.reachable(client, f.endpoints[0]) {
+		f.mu.Lock()
+		f.active = 0
+		f.mu.Unlock()
+	}
+
+	f.mu.Lock()
+	endpoint := f.endpoints[f.active]
+	f.mu.Unlock()
+	req.URL.Scheme = endpoint.Scheme
+	req.URL.Host = endpoint.Host
+	req.Host = ""
+}
+
+// reachable reports whether endpoint responds to HTTP requests. Any response, including an
+// error status, means the endpoint is up.
+func (f *endpointFailover) reachable(client *http.Client, endpoint *url.URL) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), failbackProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequest("HEAD", endpoint.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// failOver reports whether req, which failed with err, should be resent to the next
+// endpoint. Only failures to connect are failed over, since the request can't have reached
+// the server.
+func (f *endpointFailover) failOver(req *http.Request, err error) bool {
+	if f == nil || !isConnectError(err) {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current := -1
+	for i, endpoint := range f.endpoints {
+		if endpoint.Host == req.URL.Host && endpoint.Scheme == req.URL.Scheme {
+			current = i
+			break
+		}
+	}
+	if current < 0 || current+1 >= len(f.endpoints) || !rewindBody(req) {
+		return false
+	}
+	if f.active <= current {
+		f.active = current + 1
+		f.lastProbe = time.Now()
+	}
+	return true
+}
+
+// isConnectError reports whether err is a failure to resolve or connect to the server.
+func isConnectError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,79 @@
+package sumologic
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFallbackEndpoints(t *testing.T) {
+	// Reserve a port for the primary endpoint and close it, so connecting to it fails.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("net.Listen() returned an error: %s", err)
+		return
+	}
+	primaryAddr := listener.Addr().String()
+	listener.Close()
+
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"collector":{"id":1,"name":"fallback"}}`))
+	}))
+	defer fallback.Close()
+
+	c, err := NewClient("accessToken", "http://"+primaryAddr+"/api/v1/",
+		WithFallbackEndpoints(time.Millisecond, fallback.URL+"/api/v1/"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, _, err := c.GetHostedCollector(1)
+	if err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if collector.Name != "fallback" {
+		t.Errorf("GetHostedCollector() expected the fallback endpoint to respond, got ‘%s’", collector.Name)
+	}
+	if c.ActiveEndpointURL().Host != fallback.Listener.Addr().String() {
+		t.Errorf("ActiveEndpointURL() expected the fallback endpoint, got ‘%s’", c.ActiveEndpointURL())
+	}
+
+	// Bring the primary endpoint back up; the next request fails back to it.
+	listener, err = net.Listen("tcp", primaryAddr)
+	if err != nil {
+		t.Skipf("Unable to listen on %s again: %s", primaryAddr, err)
+	}
+	primary := &httptest.Server{Listener: listener, Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"collector":{"id":1,"name":"primary"}}`))
+	})}}
+	primary.Start()
+	defer primary.Close()
+	time.Sleep(2 * time.Millisecond)
+
+	collector, _, err = c.GetHostedCollector(1)
+	if err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if collector.Name != "primary" || c.ActiveEndpointURL() != c.EndpointURL {
+		t.Errorf("GetHostedCollector() expected to fail back to the primary endpoint, got ‘%s’", collector.Name)
+	}
+}
+
+func TestFallbackEndpointsInvalid(t *testing.T) {
+	_, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithFallbackEndpoints(0, "https://api.backup.sumologic.com/api/v2/"))
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "fallbackURLs" {
+		t.Errorf("NewClient() expected a fallbackURLs ValidationError, got %v", err)
+	}
+
+	_, err = NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithFallbackEndpoints(0, "http://api.backup.sumologic.com/api/v1/"))
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "endpointURL" {
+		t.Errorf("NewClient() expected an endpointURL ValidationError, got %v", err)
+	}
+}
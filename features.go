@@ -0,0 +1,46 @@
+package sumologic
+
+import (
+	"fmt"
+)
+
+// Feature names an experimental part of the SDK. Experimental features wrap beta Sumo Logic
+// endpoints whose request and response formats may still change, so they must be enabled
+// explicitly with WithExperimentalFeatures.
+type Feature string
+
+const (
+	// FeatureCloudToCloudSources enables Cloud-to-Cloud integration sources.
+	FeatureCloudToCloudSources Feature = "cloud-to-cloud-sources"
+)
+
+// FeatureNotEnabledError is returned when calling a method for an experimental feature that
+// hasn't been enabled on the client.
+type FeatureNotEnabledError struct {
+	Feature Feature
+}
+
+func (e *FeatureNotEnabledError) Error() string {
+	return fmt.Sprintf("Experimental feature `%s` is not enabled. Enable it with sumologic.WithExperimentalFeatures(%q)", e.Feature, string(e.Feature))
+}
+
+// WithExperimentalFeatures opts into experimental features.
+func WithExperimentalFeatures(features ...Feature) ClientOption {
+	return func(s *Client) error {
+		if s.features == nil {
+			s.features = make(map[Feature]bool)
+		}
+		for _, f := range features {
+			s.features[f] = true
+		}
+		return nil
+	}
+}
+
+// requireFeature returns a *FeatureNotEnabledError unless f is enabled.
+func (s *Client) requireFeature(f Feature) error {
+	if !s.features[f] {
+		return &FeatureNotEnabledError{Feature: f}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExperimentalFeatureNotEnabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request without the feature enabled, got ‘%s %s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateCloudToCloudSource(1, CloudToCloudSource{})
+	ferr, ok := err.(*FeatureNotEnabledError)
+	if !ok || ferr.Feature != FeatureCloudToCloudSources {
+		t.Errorf("CreateCloudToCloudSource() expected a FeatureNotEnabledError, got %v", err)
+	}
+}
+
+func TestCreateCloudToCloudSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sr := new(CloudToCloudSourceRequest)
+		if err := json.Unmarshal(body, &sr); err != nil {
+			t.Errorf("Unable to unmarshal CloudToCloudSourceRequest, got `%s`", body)
+		}
+		if sr.Source.SourceType != "Universal" || sr.Source.SchemaRef.Type != "Okta" {
+			t.Errorf("Expected a Universal Okta source, got %+v", sr.Source)
+		}
+		sr.Source.ID = 1234567890
+		js, _ := json.Marshal(sr)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(js)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithExperimentalFeatures(FeatureCloudToCloudSources))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, err := c.CreateCloudToCloudSource(1, CloudToCloudSource{
+		SchemaRef: CloudToCloudSchemaRef{Type: "Okta"},
+		Config:    map[string]interface{}{"name": "okta", "domain": "example.okta.com"},
+	})
+	if err != nil {
+		t.Errorf("CreateCloudToCloudSource() returned an error: %s", err)
+		return
+	}
+	if source.ID != 1234567890 {
+		t.Errorf("CreateCloudToCloudSource() expected ID 1234567890, got `%d`", source.ID)
+	}
+	desired := CloudToCloudSource{
+		SourceType: "Universal",
+		SchemaRef:  CloudToCloudSchemaRef{Type: "Okta"},
+		Config:     map[string]interface{}{"name": "okta", "domain": "example.okta.com"},
+	}
+	if !source.Equivalent(desired) || source.GetName() != "okta" {
+		t.Errorf("CreateCloudToCloudSource() expected a source equivalent to the request, got %+v", source)
+	}
+}
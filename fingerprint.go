@@ -0,0 +1,34 @@
+package sumologic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// fingerprint returns the hex-encoded SHA-256 hash of the JSON encoding of v.
+func fingerprint(v interface{}) string {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Fingerprint returns a stable hash of the collector's user-manageable configuration, the
+// fields compared by Equivalent. Reconcilers can store it and skip a Get when the local
+// configuration's fingerprint hasn't changed. Fingerprints may change when fields are added
+// to the struct in a new version of this package.
+func (c Collector) Fingerprint() string {
+	return fingerprint(c.userManaged())
+}
+
+// Fingerprint returns a stable hash of the source's user-manageable configuration, the
+// fields compared by Equivalent. See Collector.Fingerprint.
+func (s HTTPSource) Fingerprint() string {
+	return fingerprint(s.userManaged())
+}
+
+// Fingerprint returns a stable hash of the source's user-manageable configuration, the
+// fields compared by Equivalent. See Collector.Fingerprint.
+func (s AWSLogSource) Fingerprint() string {
+	return fingerprint(s.userManaged())
+}
@@ -0,0 +1,32 @@
+package sumologic
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	local := HTTPSource{Name: "http", Category: "prod/app"}
+	remote := HTTPSource{ID: 1, CollectorID: 2, Name: "http", Category: "prod/app", Url: "https://example.com/receiver", Filters: []Filter{}}
+
+	if local.Fingerprint() != remote.Fingerprint() {
+		t.Errorf("Fingerprint() expected fields managed by Sumo Logic to be ignored")
+	}
+	if len(local.Fingerprint()) != 64 {
+		t.Errorf("Fingerprint() expected a hex-encoded SHA-256 hash, got ‘%s’", local.Fingerprint())
+	}
+
+	changed := local
+	changed.Category = "prod/other"
+	if local.Fingerprint() == changed.Fingerprint() {
+		t.Errorf("Fingerprint() expected a different hash after changing the category")
+	}
+
+	collector := Collector{Name: "collector"}
+	alive := Collector{ID: 1, Name: "collector", Alive: true, LastSeenAlive: 1}
+	if collector.Fingerprint() != alive.Fingerprint() {
+		t.Errorf("Fingerprint() expected the collector's liveness to be ignored")
+	}
+
+	aws := AWSLogSource{Name: "aws", ScanInterval: 300000}
+	if aws.Fingerprint() == (AWSLogSource{Name: "aws"}).Fingerprint() {
+		t.Errorf("Fingerprint() expected a different hash after changing the scan interval")
+	}
+}
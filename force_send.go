@@ -0,0 +1,91 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// sourceCollectorIDField is the collector ID the API returns with every source. It isn't
+// part of source requests, where the collector is identified by the path, so it's never sent.
+const sourceCollectorIDField = "CollectorId"
+
+// marshalForceSend marshals v (a collector or source struct) and adds the fields named in
+// forceSendFields, by JSON name, even if they were omitted for holding their zero value. This
+// lets optional fields use omitempty, so that an omitted field takes its server default,
+// while still allowing a caller to explicitly send a zero value such as `"paused": false`. A
+// nil map is sent as `{}`, so forcing `fields` removes all fields.
+//
+// Struct fields tagged omitempty are omitted if they marshal to `{}`, which encoding/json
+// never does, and keys are sorted so the same definition always marshals to the same bytes.
+func marshalForceSend(v interface{}, forceSendFields []string) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(v)
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		name := jsonFieldName(structField)
+		if name == "" {
+			continue
+		}
+		forced := containsString(forceSendFields, name)
+		fieldBody, present := fields[name]
+		if present && !forced && string(fieldBody) == "{}" && hasOmitEmpty(structField) {
+			delete(fields, name)
+			continue
+		}
+		if present || !forced {
+			continue
+		}
+		field := value.Field(i)
+		if field.Kind() == reflect.Map && field.IsNil() {
+			field = reflect.MakeMap(field.Type())
+		}
+		fieldBody, err := json.Marshal(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = fieldBody
+	}
+	delete(fields, sourceCollectorIDField)
+
+	return json.Marshal(fields)
+}
+
+// hasOmitEmpty reports whether a struct field's JSON tag has the omitempty option.
+func hasOmitEmpty(field reflect.StructField) bool {
+	return containsString(strings.Split(field.Tag.Get("json"), ",")[1:], "omitempty")
+}
+
+// jsonFieldName returns the JSON name of a struct field, or "" if it isn't serialized.
+func jsonFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalPayloads(t *testing.T) {
+	cases := []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{
+			name:     "hosted collector",
+			v:        CollectorRequest{Collector: Collector{Name: "payments", CollectorType: "Hosted", Fields: map[string]string{"team": "payments"}}},
+			expected: `{"collector":{"collectorType":"Hosted","fields":{"team":"payments"},"name":"payments"}}`,
+		},
+		{
+			name:     "HTTP source read from the API",
+			v:        HTTPSourceRequest{Source: HTTPSource{ID: 2, CollectorID: 1, Name: "api", SourceType: "HTTP", MessagePerRequest: true}},
+			expected: `{"source":{"id":2,"messagePerRequest":true,"name":"api","sourceType":"HTTP"}}`,
+		},
+		{
+			name:     "unpaused CloudTrail source",
+			v:        AWSLogSource{Name: "trail", SourceType: "Polling", ContentType: AWSContentTypeCloudTrail, ForceSendFields: []string{"paused"}},
+			expected: `{"contentType":"AwsCloudTrailBucket","name":"trail","paused":false,"sourceType":"Polling"}`,
+		},
+		{
+			name: "CloudTrail source",
+			v: AWSLogSource{Name: "trail", ContentType: AWSContentTypeCloudTrail, ScanInterval: 300000, ThirdPartyRef: AWSBucketThirdPartyRef{Resources: []AWSBucketResource{{
+				ServiceType:    AWSContentTypeCloudTrail,
+				Path:           AWSBucketPath{Type: "S3BucketPathExpression", BucketName: "trail", PathExpression: "*"},
+				Authentication: AWSBucketAuthentication{Type: "AWSRoleBasedAuthentication", RoleARN: "arn:aws:iam::123456789012:role/SumoLogic"},
+			}}}},
+			expected: `{"contentType":"AwsCloudTrailBucket","name":"trail","scanInterval":300000,"thirdPartyRef":{"resources":[{"serviceType":"AwsCloudTrailBucket",` +
+				`"path":{"type":"S3BucketPathExpression","bucketName":"trail","pathExpression":"*"},` +
+				`"authentication":{"type":"AWSRoleBasedAuthentication","roleARN":"arn:aws:iam::123456789012:role/SumoLogic"}}]}}`,
+		},
+		{
+			name:     "source without a third-party reference",
+			v:        AWSLogSource{Name: "trail", ContentType: AWSContentTypeCloudTrail},
+			expected: `{"contentType":"AwsCloudTrailBucket","name":"trail"}`,
+		},
+		{
+			name:     "forced empty third-party reference",
+			v:        AWSLogSource{Name: "trail", ForceSendFields: []string{"thirdPartyRef"}},
+			expected: `{"name":"trail","thirdPartyRef":{}}`,
+		},
+	}
+	for _, c := range cases {
+		body, err := json.Marshal(c.v)
+		if err != nil {
+			t.Errorf("Marshal() of the %s returned an error: %s", c.name, err)
+			continue
+		}
+		if string(body) != c.expected {
+			t.Errorf("Marshal() of the %s expected\n%s\ngot\n%s", c.name, c.expected, body)
+		}
+	}
+}
+
+func TestUnmarshalCollectorIDCasing(t *testing.T) {
+	for _, data := range []string{`{"id":2,"CollectorId":1}`, `{"id":2,"collectorId":1}`, `{"id":"2","CollectorId":"1"}`} {
+		var source AWSLogSource
+		if err := json.Unmarshal([]byte(data), &source); err != nil || source.CollectorID != 1 {
+			t.Errorf("Unmarshal(%s) expected collector ID 1, got %d and %v", data, source.CollectorID, err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// GCPSourceRequest is a necessary wrapper for source API calls.
+type GCPSourceRequest struct {
+	Source GCPSource `json:"source"`
+}
+
+// GCPSource is a Google Cloud Platform source on a hosted collector. It receives log entries
+// pushed by a Pub/Sub subscription to its Url, and processes each as a single message.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type GCPSource struct {
+	ID                         int          `json:"id,omitempty"`
+	Name                       string       `json:"name"`
+	CollectorID                int          `json:"CollectorId,omitempty"`
+	Description                string       `json:"description,omitempty"`
+	Category                   string       `json:"category,omitempty"`
+	HostName                   string       `json:"hostName,omitempty"`
+	TimeZone                   string       `json:"timezone,omitempty"`
+	SourceType                 string       `json:"sourceType,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	// Url is the endpoint generated by Sumo Logic for the Pub/Sub push subscription. It can't
+	// be changed and isn't sent on update.
+	Url string `json:"url,omitempty"`
+	// ThirdPartyRef identifies the source as a GCP source. It's set by CreateGCPSource if
+	// empty.
+	ThirdPartyRef GCPThirdPartyRef `json:"thirdPartyRef,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// GCPThirdPartyRef contains the GCP configuration of a GCPSource.
+type GCPThirdPartyRef struct {
+	Resources []GCPResource `json:"resources,omitempty"`
+}
+
+// GCPResource identifies the GCP service a GCPSource receives from. Pub/Sub authenticates
+// with the secret in the source's Url, so there's no path or authentication to configure.
+type GCPResource struct {
+	ServiceType    string            `json:"serviceType"`
+	Path           GCPResourceConfig `json:"path"`
+	Authentication GCPResourceConfig `json:"authentication"`
+}
+
+// GCPResourceConfig is the type of the path or authentication of a GCPResource.
+type GCPResourceConfig struct {
+	Type string `json:"type"`
+}
+
+const (
+	// gcpSourceType is the sourceType of GCP sources.
+	gcpSourceType = "HTTP"
+	// gcpServiceType is the serviceType of GCP source resources.
+	gcpServiceType = "GoogleCloudLogs"
+)
+
+// gcpResource is the resource of every GCP source.
+var gcpResource = GCPResource{
+	ServiceType:    gcpServiceType,
+	Path:           GCPResourceConfig{Type: "NoPathExpression"},
+	Authentication: GCPResourceConfig{Type: "NoAuthentication"},
+}
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s GCPSource) MarshalJSON() ([]byte, error) {
+	type gcpSource GCPSource
+	return marshalForceSend(gcpSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *GCPSource) UnmarshalJSON(data []byte) error {
+	type gcpSource GCPSource
+	return unmarshalLenient(data, (*gcpSource)(s))
+}
+
+// Validate checks the source's resources and the fields validated for all sources, without
+// calling the API.
+func (s GCPSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	for _, r := range s.ThirdPartyRef.Resources {
+		if r.ServiceType != gcpServiceType {
+			return &ValidationError{Field: "thirdPartyRef.resources.serviceType", Message: fmt.Sprintf("must be `%s`, got `%s`", gcpServiceType, r.ServiceType)}
+		}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID, the URL and the resources) are ignored.
+func (s GCPSource) Equivalent(other GCPSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s GCPSource) userManaged() GCPSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Url = ""
+	s.ThirdPartyRef = GCPThirdPartyRef{}
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s GCPSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s GCPSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s GCPSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s GCPSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+func (source GCPSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateGCPSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// GetGCPSource gets the source with the specified ID.
+// If the client was created with WithMaskedSourceURLs, the source's URL is masked.
+func (s *Client) GetGCPSource(collectorID int, id int) (*GCPSource, string, error) {
+	var r = new(GCPSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	s.maskGCPSource(&r.Source)
+	return &r.Source, etag, nil
+}
+
+// GetGCPSourceURL gets the unmasked URL of the source with the specified ID, regardless of
+// WithMaskedSourceURLs, e.g. to configure the Pub/Sub push subscription.
+func (s *Client) GetGCPSourceURL(collectorID int, id int) (string, error) {
+	var r = new(GCPSourceRequest)
+	if _, err := s.getSource(collectorID, id, r); err != nil {
+		return "", err
+	}
+	return r.Source.Url, nil
+}
+
+// CreateGCPSource creates a new GCPSource. An empty SourceType is set to `HTTP` and empty
+// ThirdPartyRef to the GCP resource. The returned source holds the generated URL, masked if
+// the client was created with WithMaskedSourceURLs.
+func (s *Client) CreateGCPSource(collectorID int, source GCPSource) (*GCPSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = gcpSourceType
+	}
+	if len(source.ThirdPartyRef.Resources) == 0 {
+		source.ThirdPartyRef.Resources = []GCPResource{gcpResource}
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Url = ""
+
+	var r = new(GCPSourceRequest)
+	if err := s.createSource(collectorID, source.Name, GCPSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.maskGCPSource(&r.Source)
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateGCPSource updates an existing GCP source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateGCPSource(collectorID int, source GCPSource, etag string) (*GCPSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Url = ""
+
+	var r = new(GCPSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, GCPSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.maskGCPSource(&r.Source)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteGCPSource deletes the source with the specified ID.
+func (s *Client) DeleteGCPSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteGCPSourceWithETag deletes the source with the specified ID only if it hasn't changed since
+// the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteGCPSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteGCPSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteGCPSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteGCPSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteGCPSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const gcpSourceURL = "https://endpoint1.collection.sumologic.com/receiver/v1/gcp/ZaVnC4dhaV2ZFQ"
+
+func TestCreateGCPSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request GCPSourceRequest
+		switch r.Method {
+		case "POST":
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Errorf("Unable to unmarshal GCPSource, got `%s`", body)
+			}
+			resources := request.Source.ThirdPartyRef.Resources
+			if request.Source.SourceType != "HTTP" || len(resources) != 1 || resources[0].ServiceType != "GoogleCloudLogs" {
+				t.Errorf("Expected an HTTP source with a GoogleCloudLogs resource, got %+v", request.Source)
+			}
+			if request.Source.Url != "" {
+				t.Errorf("Expected the URL not to be sent, got ‘%s’", request.Source.Url)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			request.Source = GCPSource{Name: "gcp", Category: "gcp/logs"}
+		}
+		request.Source.ID = 2
+		request.Source.Url = gcpSourceURL
+		body, _ := json.Marshal(request)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, err := c.CreateGCPSource(1, GCPSource{Name: "gcp", Category: "gcp/logs", Url: "ignored"})
+	if err != nil {
+		t.Errorf("CreateGCPSource() returned an error: %s", err)
+		return
+	}
+	if source.ID != 2 || source.Url != MaskIngestionURL(gcpSourceURL) {
+		t.Errorf("CreateGCPSource() expected the masked URL, got %+v", source)
+	}
+	if !source.Equivalent(GCPSource{Name: "gcp", Category: "gcp/logs", SourceType: "HTTP"}) {
+		t.Errorf("CreateGCPSource() returned an unexpected source: %+v", source)
+	}
+
+	url, err := c.GetGCPSourceURL(1, 2)
+	if err != nil {
+		t.Errorf("GetGCPSourceURL() returned an error: %s", err)
+		return
+	}
+	if url != gcpSourceURL {
+		t.Errorf("GetGCPSourceURL() expected the unmasked URL, got ‘%s’", url)
+	}
+}
+
+func TestGCPSourceValidate(t *testing.T) {
+	source := GCPSource{Name: "gcp"}
+	source.ThirdPartyRef.Resources = []GCPResource{{ServiceType: "AwsS3Bucket"}}
+	if err, ok := source.Validate().(*ValidationError); !ok || err.Field != "thirdPartyRef.resources.serviceType" {
+		t.Errorf("Validate() expected a ValidationError for `thirdPartyRef.resources.serviceType`, got %v", err)
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.UpdateGCPSource(1, GCPSource{Name: "gcp"}, ""); err != ErrMissingETag {
+		t.Errorf("UpdateGCPSource() expected ErrMissingETag, got %v", err)
+	}
+}
+
+func TestGetGCPSourceDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, _, err := c.GetGCPSource(1, 2); err != ErrSourceNotFound {
+		t.Errorf("GetGCPSource() expected ErrSourceNotFound, got %v", err)
+	}
+	if err := c.DeleteGCPSourceIfExists(1, 2); err != nil {
+		t.Errorf("DeleteGCPSourceIfExists() returned an error: %s", err)
+	}
+}
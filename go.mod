@@ -1,3 +1,3 @@
 module github.com/nextgenhealthcare/sumologic-sdk-go
 
-go 1.12
+go 1.16
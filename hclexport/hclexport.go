@@ -0,0 +1,205 @@
+// Package hclexport converts collectors and sources into Terraform configuration for the
+// sumologic provider, easing the migration of resources created in the Sumo Logic UI into
+// infrastructure as code. Each resource is preceded by the `terraform import` command that
+// brings the existing resource under Terraform management.
+//
+// It is a separate package so that the core SDK doesn't grow code generation concerns.
+package hclexport
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sumologic "github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+// Exporter renders resources as HCL, giving each one a unique Terraform resource name.
+// The zero value is ready to use.
+type Exporter struct {
+	names map[string]bool
+}
+
+// ExportCollector fetches the hosted collector with the specified ID and all of its sources
+// and returns them as HCL. Sources of types this package doesn't support are listed as
+// comments so they aren't silently dropped.
+func ExportCollector(client *sumologic.Client, id int) (string, error) {
+	collector, _, err := client.GetHostedCollector(id)
+	if err != nil {
+		return "", err
+	}
+	sources, err := client.ListSources(id)
+	if err != nil {
+		return "", err
+	}
+
+	e := &Exporter{}
+	var b strings.Builder
+	collectorName := e.Collector(&b, *collector)
+	for _, source := range sources {
+		b.WriteString("\n")
+		if err := e.Source(&b, collectorName, source); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// Collector writes a sumologic_collector resource and returns its Terraform resource name.
+func (e *Exporter) Collector(b *strings.Builder, c sumologic.Collector) string {
+	name := e.resourceName(c.Name)
+	fmt.Fprintf(b, "# terraform import sumologic_collector.%s %d\n", name, c.ID)
+	fmt.Fprintf(b, "resource \"sumologic_collector\" %q {\n", name)
+	writeString(b, "name", c.Name)
+	writeString(b, "description", c.Description)
+	writeString(b, "category", c.Category)
+	writeString(b, "timezone", c.TimeZone)
+	b.WriteString("}\n")
+	return name
+}
+
+// Source writes the resource for a source listed by ListSources. collectorName is the
+// Terraform resource name of the source's collector, as returned by Collector.
+func (e *Exporter) Source(b *strings.Builder, collectorName string, source sumologic.Source) error {
+	switch source.SourceType {
+	case "HTTP":
+		var s sumologic.HTTPSource
+		if err := source.Decode(&s); err != nil {
+			return err
+		}
+		e.HTTPSource(b, collectorName, s)
+	case "Polling":
+		var s sumologic.AWSLogSource
+		if err := source.Decode(&s); err != nil {
+			return err
+		}
+		e.AWSLogSource(b, collectorName, s)
+	default:
+		fmt.Fprintf(b, "# Source %q (%d) of type %s is not supported by hclexport.\n", source.Name, source.ID, source.SourceType)
+	}
+	return nil
+}
+
+// HTTPSource writes a sumologic_http_source resource.
+func (e *Exporter) HTTPSource(b *strings.Builder, collectorName string, s sumologic.HTTPSource) {
+	name := e.resourceName(s.Name)
+	fmt.Fprintf(b, "# terraform import sumologic_http_source.%s %d/%d\n", name, s.CollectorID, s.ID)
+	fmt.Fprintf(b, "resource \"sumologic_http_source\" %q {\n", name)
+	writeString(b, "name", s.Name)
+	writeString(b, "description", s.Description)
+	writeString(b, "category", s.Category)
+	writeString(b, "timezone", s.TimeZone)
+	fmt.Fprintf(b, "  collector_id = sumologic_collector.%s.id\n", collectorName)
+	writeBool(b, "message_per_request", s.MessagePerRequest)
+	writeBool(b, "multiline_processing_enabled", s.MultilineProcessingEnabled)
+	writeBool(b, "use_autoline_matching", s.UseAutolineMatching)
+	writeString(b, "manual_prefix_regexp", s.ManualPrefixRegexp)
+	writeFilters(b, s.Filters)
+	b.WriteString("}\n")
+}
+
+// awsSourceResources maps AWS content types to the provider's resource types.
+var awsSourceResources = map[string]string{
+	"AwsCloudTrailBucket":  "sumologic_cloudtrail_source",
+	"AwsS3Bucket":          "sumologic_s3_source",
+	"AwsS3AuditBucket":     "sumologic_s3_audit_source",
+	"AwsElbBucket":         "sumologic_elb_source",
+	"AwsCloudFrontBucket":  "sumologic_cloudfront_source",
+	"AwsS3ArchiveBucket":   "sumologic_s3_archive_source",
+	"AwsCloudWatch":        "sumologic_cloudwatch_source",
+	"AwsMetadata":          "sumologic_metadata_source",
+	"AwsVpcFlowLogsBucket": "sumologic_s3_source",
+}
+
+// AWSLogSource writes the provider resource matching the source's content type.
+func (e *Exporter) AWSLogSource(b *strings.Builder, collectorName string, s sumologic.AWSLogSource) {
+	resourceType, ok := awsSourceResources[s.ContentType]
+	if !ok {
+		resourceType = "sumologic_s3_source"
+	}
+	name := e.resourceName(s.Name)
+	fmt.Fprintf(b, "# terraform import %s.%s %d/%d\n", resourceType, name, s.CollectorID, s.ID)
+	fmt.Fprintf(b, "resource %q %q {\n", resourceType, name)
+	writeString(b, "name", s.Name)
+	writeString(b, "description", s.Description)
+	writeString(b, "category", s.Category)
+	writeString(b, "timezone", s.TimeZone)
+	writeString(b, "content_type", s.ContentType)
+	fmt.Fprintf(b, "  collector_id = sumologic_collector.%s.id\n", collectorName)
+	if s.ScanInterval != 0 {
+		fmt.Fprintf(b, "  scan_interval = %d\n", s.ScanInterval)
+	}
+	writeBool(b, "paused", s.Paused)
+	writeString(b, "cutoff_relative_time", s.CutoffRelativeTime)
+	writeBool(b, "multiline_processing_enabled", s.MultilineProcessingEnabled)
+	writeBool(b, "use_autoline_matching", s.UseAutolineMatching)
+	writeString(b, "manual_prefix_regexp", s.ManualPrefixRegexp)
+	for _, r := range s.ThirdPartyRef.Resources {
+		b.WriteString("\n  authentication {\n")
+		writeIndented(b, "    ", "type", r.Authentication.Type)
+		writeIndented(b, "    ", "role_arn", r.Authentication.RoleARN)
+		b.WriteString("  }\n\n  path {\n")
+		writeIndented(b, "    ", "type", r.Path.Type)
+		writeIndented(b, "    ", "bucket_name", r.Path.BucketName)
+		writeIndented(b, "    ", "path_expression", r.Path.PathExpression)
+		b.WriteString("  }\n")
+	}
+	writeFilters(b, s.Filters)
+	b.WriteString("}\n")
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// resourceName derives a unique, valid Terraform resource name from a Sumo Logic name.
+func (e *Exporter) resourceName(sumoName string) string {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(sumoName), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+	if e.names == nil {
+		e.names = make(map[string]bool)
+	}
+	unique := name
+	for i := 2; e.names[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	e.names[unique] = true
+	return unique
+}
+
+func writeString(b *strings.Builder, key, value string) {
+	writeIndented(b, "  ", key, value)
+}
+
+func writeIndented(b *strings.Builder, indent, key, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s%s = %s\n", indent, key, quote(value))
+}
+
+func writeBool(b *strings.Builder, key string, value bool) {
+	if value {
+		fmt.Fprintf(b, "  %s = true\n", key)
+	}
+}
+
+func writeFilters(b *strings.Builder, filters []sumologic.Filter) {
+	for _, f := range filters {
+		b.WriteString("\n  filters {\n")
+		writeIndented(b, "    ", "filter_type", f.FilterType)
+		writeIndented(b, "    ", "name", f.Name)
+		writeIndented(b, "    ", "regexp", f.Regexp)
+		b.WriteString("  }\n")
+	}
+}
+
+// quote returns s as an HCL string literal, escaping template sequences so values such as
+// regular expressions containing `${` are taken literally.
+func quote(s string) string {
+	q := strconv.Quote(s)
+	q = strings.Replace(q, "${", "$${", -1)
+	q = strings.Replace(q, "%{", "%%{", -1)
+	return q
+}
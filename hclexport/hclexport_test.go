@@ -0,0 +1,71 @@
+package hclexport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sumologic "github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+func TestExportCollector(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/collectors/100":
+			w.Write([]byte(`{"collector":{"id":100,"name":"Prod Collector","category":"prod","collectorType":"Hosted"}}`))
+		case "/collectors/100/sources":
+			w.Write([]byte(`{"sources":[
+				{"id":1,"name":"app-http","sourceType":"HTTP","CollectorId":100,"messagePerRequest":true,"manualPrefixRegexp":"^${date}"},
+				{"id":2,"name":"trail","sourceType":"Polling","CollectorId":100,"contentType":"AwsCloudTrailBucket","scanInterval":300000,
+				 "thirdPartyRef":{"resources":[{"serviceType":"AwsCloudTrailBucket","path":{"type":"S3BucketPathExpression","bucketName":"logs","pathExpression":"*"},
+				 "authentication":{"type":"AWSRoleBasedAuthentication","roleARN":"arn:aws:iam::123456789012:role/sumo"}}]}},
+				{"id":3,"name":"syslog","sourceType":"Syslog","CollectorId":100}
+			]}`))
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := sumologic.NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	hcl, err := ExportCollector(c, 100)
+	if err != nil {
+		t.Errorf("ExportCollector() returned an error: %s", err)
+		return
+	}
+
+	for _, expected := range []string{
+		`# terraform import sumologic_collector.prod_collector 100`,
+		`resource "sumologic_collector" "prod_collector" {`,
+		`resource "sumologic_http_source" "app_http" {`,
+		`  collector_id = sumologic_collector.prod_collector.id`,
+		`  message_per_request = true`,
+		`  manual_prefix_regexp = "^$${date}"`,
+		`# terraform import sumologic_cloudtrail_source.trail 100/2`,
+		`    role_arn = "arn:aws:iam::123456789012:role/sumo"`,
+		`  scan_interval = 300000`,
+		`# Source "syslog" (3) of type Syslog is not supported by hclexport.`,
+	} {
+		if !strings.Contains(hcl, expected) {
+			t.Errorf("ExportCollector() expected output to contain `%s`, got:\n%s", expected, hcl)
+		}
+	}
+}
+
+func TestResourceNameUnique(t *testing.T) {
+	e := &Exporter{}
+	names := []string{e.resourceName("My Source"), e.resourceName("my-source"), e.resourceName("123")}
+	expected := []string{"my_source", "my_source_2", "r_123"}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("resourceName() expected `%s`, got `%s`", expected[i], names[i])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// HealthEvent is an ongoing problem reported by Sumo Logic for a collector, source or
+// other resource, such as a failed S3 bucket scan.
+type HealthEvent struct {
+	EventID          string                 `json:"eventId"`
+	EventName        string                 `json:"eventName"`
+	Details          HealthEventDetails     `json:"details"`
+	ResourceIdentity HealthResourceIdentity `json:"resourceIdentity"`
+	EventTime        string                 `json:"eventTime"`
+	Subsystem        string                 `json:"subsystem"`
+	SeverityLevel    string                 `json:"severityLevel"`
+}
+
+// HealthEventDetails describes the problem behind a health event.
+type HealthEventDetails struct {
+	TrackerID   string `json:"trackerId"`
+	Error       string `json:"error"`
+	Description string `json:"description"`
+}
+
+// HealthResourceIdentity identifies the resource a health event applies to.
+// IDs are hexadecimal strings; use ParseHexID to convert them to collector and source IDs.
+type HealthResourceIdentity struct {
+	ID            string `json:"id"`
+	Name          string `json:"name,omitempty"`
+	Type          string `json:"type"`
+	CollectorID   string `json:"collectorId,omitempty"`
+	CollectorName string `json:"collectorName,omitempty"`
+}
+
+// Source health statuses, from best to worst.
+const (
+	SourceHealthy       = "Healthy"
+	SourceHealthWarning = "Warning"
+	SourceHealthError   = "Error"
+)
+
+const (
+	healthSeverityError  = "Error"
+	healthResourceSource = "Source"
+)
+
+// SourceHealth summarizes the health events of a single source.
+type SourceHealth struct {
+	CollectorID int
+	SourceID    int
+	// Status is SourceHealthy if there are no events, SourceHealthError if any event has
+	// Error severity and SourceHealthWarning otherwise.
+	Status string
+	Events []HealthEvent
+}
+
+// GetSourceHealth returns the health of the source with the specified ID, combining all of
+// the health events currently open for it.
+func (s *Client) GetSourceHealth(collectorID int, sourceID int) (*SourceHealth, error) {
+	identity := HealthResourceIdentity{
+		ID:          HexID(sourceID),
+		Type:        healthResourceSource,
+		CollectorID: HexID(collectorID),
+	}
+	events, err := s.listHealthEventsForResources([]HealthResourceIdentity{identity})
+	if err != nil {
+		return nil, err
+	}
+
+	health := &SourceHealth{
+		CollectorID: collectorID,
+		SourceID:    sourceID,
+		Status:      SourceHealthy,
+		Events:      events,
+	}
+	for _, event := range events {
+		if event.SeverityLevel == healthSeverityError {
+			health.Status = SourceHealthError
+			break
+		}
+		health.Status = SourceHealthWarning
+	}
+	return health, nil
+}
+
+// listHealthEventsForResources returns every open health event for the given resources.
+func (s *Client) listHealthEventsForResources(resources []HealthResourceIdentity) ([]HealthEvent, error) {
+	body, _ := json.Marshal(map[string]interface{}{"data": resources})
+
+	events := []HealthEvent{}
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(defaultPageLimit))
+		if token != "" {
+			query.Set("token", token)
+		}
+		var page struct {
+			Data []HealthEvent `json:"data"`
+			Next string        `json:"next"`
+		}
+		if _, err := s.do("POST", "healthEvents/resources?"+query.Encode(), body, &page); err != nil {
+			return nil, err
+		}
+		events = append(events, page.Data...)
+		if page.Next == "" {
+			return events, nil
+		}
+		token = page.Next
+	}
+}
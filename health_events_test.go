@@ -0,0 +1,65 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSourceHealth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/healthEvents/resources" {
+			t.Errorf("Expected request to ‘/healthEvents/resources’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Data []HealthResourceIdentity `json:"data"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil || len(request.Data) != 1 {
+			t.Errorf("Unable to unmarshal resource identities, got `%s`", body)
+			return
+		}
+		if request.Data[0].ID != "00000000499602D2" || request.Data[0].Type != "Source" {
+			t.Errorf("Expected source identity `00000000499602D2`, got %+v", request.Data[0])
+		}
+
+		var page map[string]interface{}
+		if r.URL.Query().Get("token") == "" {
+			page = map[string]interface{}{
+				"data": []HealthEvent{{EventName: "AwsS3ScanWarning", SeverityLevel: "Warning"}},
+				"next": "page2",
+			}
+		} else {
+			page = map[string]interface{}{
+				"data": []HealthEvent{{EventName: "AwsAuthenticationError", SeverityLevel: "Error", Details: HealthEventDetails{Error: "Access Denied"}}},
+			}
+		}
+		js, _ := json.Marshal(page)
+		w.WriteHeader(http.StatusOK)
+		w.Write(js)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	health, err := c.GetSourceHealth(defaultAWSLogSource.CollectorID, defaultAWSLogSource.ID)
+	if err != nil {
+		t.Errorf("GetSourceHealth() returned an error: %s", err)
+		return
+	}
+	if health.Status != SourceHealthError {
+		t.Errorf("GetSourceHealth() expected status `%s`, got `%s`", SourceHealthError, health.Status)
+	}
+	if len(health.Events) != 2 || health.Events[1].Details.Error != "Access Denied" {
+		t.Errorf("GetSourceHealth() expected events from both pages, got %+v", health.Events)
+	}
+}
@@ -1,13 +1,17 @@
 package sumologic
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
 )
 
 // CollectorRequest is a necessary wrapper for collector API calls.
@@ -18,6 +22,11 @@ type CollectorRequest struct {
 // Collector can either be an installed or hosted collector.
 // Installed collectors are installed as agents on servers.
 // Hosted collectors receive data via HTTP or more specicialized (e.g. reading from AWS S3).
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// collector, so an omitted field takes its server default; start from the collector
+// returned by a Get to keep its current settings. To explicitly send a zero value (e.g. to
+// remove all of a collector's fields), list the field's JSON name in ForceSendFields.
 type Collector struct {
 	ID               int              `json:"id,omitempty"`
 	Name             string           `json:"name"`
@@ -29,6 +38,9 @@ type Collector struct {
 	CollectorVersion string           `json:"collectorVersion,omitempty"`
 	LastSeenAlive    int64            `json:"lastSeenAlive,omitempty"`
 	Alive            bool             `json:"alive,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
 }
 
 // CollectorLinks contains references to related resources such as sources.
@@ -37,152 +49,412 @@ type CollectorLinks struct {
 	Href string `json:"href"`
 }
 
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (c Collector) MarshalJSON() ([]byte, error) {
+	type collector Collector
+	return marshalForceSend(collector(c), c.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (c *Collector) UnmarshalJSON(data []byte) error {
+	type collector Collector
+	return unmarshalLenient(data, (*collector)(c))
+}
+
+// Equivalent reports whether c and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, links, version and liveness) are ignored.
+func (c Collector) Equivalent(other Collector) bool {
+	return reflect.DeepEqual(c.userManaged(), other.userManaged())
+}
+
+func (c Collector) userManaged() Collector {
+	c.ForceSendFields = nil
+	c.ID = 0
+	c.Links = nil
+	c.CollectorVersion = ""
+	c.LastSeenAlive = 0
+	c.Alive = false
+	if len(c.Fields) == 0 {
+		c.Fields = nil
+	}
+	return c
+}
+
 // ErrCollectorNotFound is returned when a collector doesn't exist on a Read or Delete.
 // It's useful for ignoring errors (e.g. delete if exists).
 var ErrCollectorNotFound = errors.New("Collector not found")
 
 // GetHostedCollector gets the collector with the specified ID.
 func (s *Client) GetHostedCollector(id int) (*Collector, string, error) {
+	path, err := formatPath("collectors/%d", id)
+	if err != nil {
+		return nil, "", err
+	}
+	var cr = new(CollectorRequest)
+	resp, err := s.do("GET", path, nil, cr)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+	}
+	return &cr.Collector, resp.Header.Get("ETag"), nil
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d", id))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// GetCollectorByName gets the collector with the specified name, of any type, and its ETag.
+// Names are unique within an organization, so they're a stable key across organizations
+// where IDs differ.
+func (s *Client) GetCollectorByName(name string) (*Collector, string, error) {
+	path, err := formatPath("collectors/name/%s", name)
 	if err != nil {
 		return nil, "", err
 	}
-	defer resp.Body.Close()
+	var cr = new(CollectorRequest)
+	resp, err := s.do("GET", path, nil, cr)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+	}
+	return &cr.Collector, resp.Header.Get("ETag"), nil
+}
+
+// Collector filters for ListCollectorsOptions.Filter.
+const (
+	CollectorFilterHosted    = "hosted"
+	CollectorFilterInstalled = "installed"
+	CollectorFilterAlive     = "alive"
+	CollectorFilterDead      = "dead"
+)
+
+// ListCollectorsOptions are server-side filters for ListCollectors. Empty fields are not sent.
+// The collectors API has no name filter for lists; use GetCollectorByName instead.
+type ListCollectorsOptions struct {
+	// Filter is one of the CollectorFilter constants.
+	Filter string
+}
 
-	ResponseBody, _ := ioutil.ReadAll(resp.Body)
+// collectorPageLimit is the page size requested by ListCollectors, the API's maximum.
+const collectorPageLimit = 1000
+
+// ListCollectors returns all collectors matching the options, following offset pagination
+// transparently.
+func (s *Client) ListCollectors(options ListCollectorsOptions) ([]Collector, error) {
+	collectors := []Collector{}
+	err := s.listCollectorPages(options.Filter, func(data json.RawMessage) (int, error) {
+		var page []Collector
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		collectors = append(collectors, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collectors, nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(ResponseBody, &cr)
+// listCollectorPages requests every page of collectors matching filter and calls fn with
+// each page's collectors. fn returns the number of collectors on the page.
+func (s *Client) listCollectorPages(filter string, fn func(data json.RawMessage) (int, error)) error {
+	for offset := 0; ; offset += collectorPageLimit {
+		data, err := s.getCollectorPage(filter, offset)
 		if err != nil {
-			return nil, "", err
+			return err
+		}
+		if len(data) == 0 {
+			return nil
 		}
 
-		return &cr.Collector, resp.Header.Get("ETag"), nil
-	case http.StatusUnauthorized:
-		return nil, "", ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, "", ErrCollectorNotFound
-	default:
-		return nil, "", fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+		n, err := fn(data)
+		if err != nil {
+			return err
+		}
+		if n < collectorPageLimit {
+			return nil
+		}
 	}
 }
 
-// CreateHostedCollector creates a new Hosted Collector.
-func (s *Client) CreateHostedCollector(collector Collector) (*Collector, error) {
+// getCollectorPage requests the page of collectors matching filter at offset.
+func (s *Client) getCollectorPage(filter string, offset int) (json.RawMessage, error) {
+	var page struct {
+		Collectors json.RawMessage `json:"collectors"`
+	}
+	query := collectorFilterQuery(filter)
+	query.Set("limit", strconv.Itoa(collectorPageLimit))
+	query.Set("offset", strconv.Itoa(offset))
+	if _, err := s.do("GET", "collectors?"+query.Encode(), nil, &page); err != nil {
+		return nil, err
+	}
+	return page.Collectors, nil
+}
 
-	collectorRequest := CollectorRequest{
-		Collector: collector,
+func collectorFilterQuery(filter string) url.Values {
+	query := url.Values{}
+	if filter != "" {
+		query.Set("filter", filter)
 	}
+	return query
+}
 
-	body, _ := json.Marshal(collectorRequest)
+// ListCollectorsPage returns the page of collectors matching the options at cursor, and the
+// cursor of the next page, which is empty after the last page.
+func (s *Client) ListCollectorsPage(options ListCollectorsOptions, cursor Cursor) ([]Collector, Cursor, error) {
+	state, err := decodeCursor(cursor, "collectors", collectorFilterQuery(options.Filter))
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := s.getCollectorPage(options.Filter, state.Offset)
+	if err != nil {
+		return nil, "", err
+	}
+	page := []Collector{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, "", err
+		}
+	}
+	if len(page) < collectorPageLimit {
+		return page, "", nil
+	}
+	state.Offset += collectorPageLimit
+	return page, state.encode(), nil
+}
 
-	relativeURL, _ := url.Parse("collectors")
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// CreateHostedCollector creates a new Hosted Collector.
+func (s *Client) CreateHostedCollector(collector Collector) (*Collector, error) {
+	c, _, err := s.CreateHostedCollectorWithResponse(collector)
+	return c, err
+}
 
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+// CreateHostedCollectorWithResponse creates a new Hosted Collector like CreateHostedCollector,
+// and also returns the response, whose ETag can be passed to a later update without a Get.
+func (s *Client) CreateHostedCollectorWithResponse(collector Collector) (*Collector, *Response, error) {
+	if err := validateNameAndCategory(collector.Name, collector.Category); err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateTimeZone(collector.TimeZone); err != nil {
+		return nil, nil, err
+	}
+	if err := validateFields(collector.Fields); err != nil {
+		return nil, nil, err
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeCollector, nil, nil, collector); err != nil {
+		return nil, nil, err
+	}
+	var cr = new(CollectorRequest)
+	resp, err := s.do("POST", "collectors", CollectorRequest{Collector: collector}, cr)
 	if err != nil {
-		return nil, err
+		if e, ok := badRequest(err); ok {
+			if err := planLimitError(ResourceTypeCollector, e); err != nil {
+				return nil, nil, err
+			}
+		}
+		return nil, nil, collectorBadRequest(err, collector)
 	}
-	defer resp.Body.Close()
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(responseBody, &cr)
-		if err != nil {
-			return nil, err
-		}
+	s.recordChange(ChangeCreate, ResourceTypeCollector, cr.Collector.ID, nil, cr.Collector)
+	return &cr.Collector, s.newResponse(resp), nil
+}
 
-		return &cr.Collector, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		return nil, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+// collectorBadRequest explains a 400 response to creating or updating collector. The API's
+// error is returned as is if it has a message.
+func collectorBadRequest(err error, collector Collector) error {
+	if _, ok := badRequest(err); ok {
+		return err
 	}
+	return errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name))
 }
 
 // UpdateHostedCollector updates an existing hosted collector.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
 func (s *Client) UpdateHostedCollector(collector Collector, etag string) (*Collector, error) {
-	collectorRequest := CollectorRequest{
-		Collector: collector,
+	c, _, err := s.UpdateHostedCollectorWithResponse(collector, etag)
+	return c, err
+}
+
+// UpdateHostedCollectorWithResponse updates an existing hosted collector like
+// UpdateHostedCollector, and also returns the response, whose ETag is the collector's new ETag.
+func (s *Client) UpdateHostedCollectorWithResponse(collector Collector, etag string) (*Collector, *Response, error) {
+	etag, err := s.resolveETag(etag, "collectors/%d", collector.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateNameAndCategory(collector.Name, collector.Category); err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateTimeZone(collector.TimeZone); err != nil {
+		return nil, nil, err
+	}
+	if err := validateFields(collector.Fields); err != nil {
+		return nil, nil, err
 	}
 
-	body, _ := json.Marshal(collectorRequest)
+	path, err := formatPath("collectors/%d", collector.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeCollector, collector.ID, nil, collector); err != nil {
+		return nil, nil, err
+	}
+	var cr = new(CollectorRequest)
+	resp, err := s.doIfMatch("PUT", path, etag, CollectorRequest{Collector: collector}, cr)
+	if err != nil {
+		return nil, nil, collectorBadRequest(err, collector)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeCollector, cr.Collector.ID, nil, cr.Collector)
+	return &cr.Collector, s.newResponse(resp), nil
+}
+
+// collectorDeleteBackoff is used to retry collector deletes that fail with a server error.
+// Deleting a collector cascades to all of its sources, and while that is in progress the
+// API can intermittently return 5xx responses.
+var collectorDeleteBackoff = backoff.Policy{
+	Initial:     time.Second,
+	Max:         10 * time.Second,
+	Jitter:      0.2,
+	MaxAttempts: 5,
+}
+
+// collectorDeletePollInterval is how often WaitForCollectorDeleted checks the collector.
+var collectorDeletePollInterval = 2 * time.Second
+
+// DeleteHostedCollector deletes the collector with the specified ID.
+// Server errors, which can occur while the deletion cascades to the collector's sources,
+// and rate limiting are retried a bounded number of times.
+func (s *Client) DeleteHostedCollector(id int) error {
+	return s.deleteCollector(context.Background(), id, "")
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d", collector.ID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// DeleteHostedCollectorContext is like DeleteHostedCollector, but stops retrying and cancels
+// the request in flight when ctx is done, returning ctx.Err().
+func (s *Client) DeleteHostedCollectorContext(ctx context.Context, id int) error {
+	return s.deleteCollector(ctx, id, "")
+}
 
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-	req.Header.Add("If-Match", etag)
+// DeleteHostedCollectorWithETag deletes the collector with the specified ID only if it hasn't
+// changed since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteHostedCollectorWithETag(id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteCollector(context.Background(), id, etag)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// DeleteHostedCollectorWithResponse deletes the collector with the specified ID like
+// DeleteHostedCollector, or like DeleteHostedCollectorWithETag if etag is not empty, and also
+// returns the response.
+func (s *Client) DeleteHostedCollectorWithResponse(id int, etag string) (*Response, error) {
+	resp, err := s.deleteCollectorWithResponse(context.Background(), id, etag)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return s.newResponse(resp), nil
+}
 
-	ResponseBody, _ := ioutil.ReadAll(resp.Body)
+// deleteCollector deletes a collector of any type. If etag is not empty, it's sent as If-Match.
+func (s *Client) deleteCollector(ctx context.Context, id int, etag string) error {
+	_, err := s.deleteCollectorWithResponse(ctx, id, etag)
+	return err
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(ResponseBody, &cr)
-		if err != nil {
-			return nil, err
+// deleteCollectorWithResponse is deleteCollector returning the response of the final attempt.
+func (s *Client) deleteCollectorWithResponse(ctx context.Context, id int, etag string) (*http.Response, error) {
+	path, err := formatPath("collectors/%d", id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeDelete, ResourceTypeCollector, id, nil, nil); err != nil {
+		return nil, err
+	}
+	// mayHaveDeleted is set once an attempt fails in a way that doesn't rule out that the
+	// collector was deleted: a server error, but not a 429. Timeouts are retried within an
+	// attempt, if at all, according to the client's transient retry policy.
+	mayHaveDeleted := false
+	for attempt := 1; ; attempt++ {
+		resp, err := s.doIfMatchContext(ctx, "DELETE", path, etag, nil, nil)
+		if e, ok := err.(*APIError); ok && e.StatusCode == http.StatusNotFound && mayHaveDeleted {
+			// An earlier attempt deleted the collector before failing.
+			err = nil
+		}
+		if err == nil {
+			s.recordChange(ChangeDelete, ResourceTypeCollector, id, nil, nil)
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
 		}
 
-		return &cr.Collector, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		return nil, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+		serverError := isServerError(err)
+		mayHaveDeleted = mayHaveDeleted || serverError
+		if !(serverError || errors.Is(err, ErrRateLimited)) || attempt >= collectorDeleteBackoff.MaxAttempts {
+			return nil, errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+		}
+		s.root().metrics.recordRetry("DELETE collectors/{id}")
+		timer := time.NewTimer(collectorDeleteBackoff.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
 	}
 }
 
-// DeleteHostedCollector deletes the collector with the specified ID.
-func (s *Client) DeleteHostedCollector(id int) error {
-	c, _ := url.Parse(fmt.Sprintf("collectors/%d", id))
-	req, err := http.NewRequest("DELETE", s.EndpointURL.ResolveReference(c).String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+// isServerError reports whether err is an *APIError for a 5xx response.
+func isServerError(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && e.StatusCode >= 500
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+// WaitForCollectorDeleted blocks until the collector with the specified ID no longer exists,
+// the context is done, or reading the collector fails with an error other than ErrCollectorNotFound.
+func (s *Client) WaitForCollectorDeleted(ctx context.Context, id int) error {
+	ticker := time.NewTicker(collectorDeletePollInterval)
+	defer ticker.Stop()
+	for {
+		_, _, err := s.GetHostedCollector(id)
+		if err == ErrCollectorNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
+// DeleteHostedCollectorIfExists deletes the collector with the specified ID.
+// Unlike DeleteHostedCollector, a collector that doesn't exist is not an error.
+func (s *Client) DeleteHostedCollectorIfExists(id int) error {
+	err := s.DeleteHostedCollector(id)
+	if err == ErrCollectorNotFound {
 		return nil
-	case http.StatusNotFound:
-		return ErrCollectorNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
 	}
+	return err
+}
+
+// CollectorResult is the outcome of getting a single collector with GetCollectors.
+type CollectorResult struct {
+	ID        int
+	Collector *Collector
+	ETag      string
+	Err       error
+}
+
+// GetCollectors gets the collectors with the specified IDs concurrently, bounded by the
+// client's maximum concurrency (see WithMaxConcurrency). Results are returned in the same
+// order as ids, each with its own error, so one missing collector doesn't fail the rest.
+func (s *Client) GetCollectors(ids []int) []CollectorResult {
+	results := make([]CollectorResult, len(ids))
+	s.forEach(len(ids), func(i int) {
+		collector, etag, err := s.GetHostedCollector(ids[i])
+		results[i] = CollectorResult{ID: ids[i], Collector: collector, ETag: etag, Err: err}
+	})
+	return results
 }
@@ -1,12 +1,16 @@
 package sumologic
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
 )
 
 var defaultCollector = Collector{
@@ -101,6 +105,39 @@ func TestGetHostedCollectorDoesntExist(t *testing.T) {
 	}
 }
 
+func TestGetCollectorByName(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedURL := "/collectors/name/prod%2Fpayments"
+		if r.URL.EscapedPath() != expectedURL {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		body, _ := json.Marshal(CollectorRequest{Collector: Collector{ID: 1, Name: "prod/payments"}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, etag, err := c.GetCollectorByName("prod/payments")
+	if err != nil {
+		t.Errorf("GetCollectorByName() returned an error: %s", err)
+		return
+	}
+	if collector.ID != 1 || etag != `"etag"` {
+		t.Errorf("GetCollectorByName() expected collector 1 with ETag ‘\"etag\"’, got %d with ‘%s’", collector.ID, etag)
+	}
+
+	if _, _, err := c.GetCollectorByName("missing"); err != ErrCollectorNotFound {
+		t.Errorf("GetCollectorByName() expected ErrCollectorNotFound, got %v", err)
+	}
+}
+
 func TestCreateHostedCollectorOK(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusCreated)
@@ -113,7 +150,7 @@ func TestCreateHostedCollectorOK(t *testing.T) {
 		if ctype := r.Header.Get("Content-Type"); ctype != "application/json" {
 			t.Errorf("Expected response to be content-type ‘application/json’, got ‘%s’", ctype)
 		}
-		body, _ := ioutil.ReadAll(r.Body)
+		body, _ := io.ReadAll(r.Body)
 		cr := new(CollectorRequest)
 		err := json.Unmarshal(body, &cr)
 		if err != nil {
@@ -226,6 +263,29 @@ func TestUpdateHostedCollectorOK(t *testing.T) {
 	}
 }
 
+func TestCreateHostedCollectorBadRequestMessage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":400,"code":"collectors.validation.fields.invalid","message":"Invalid field name"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateHostedCollector(Collector{Name: "test", CollectorType: CollectorTypeHosted})
+	if e, ok := err.(*APIError); !ok || e.Code != "collectors.validation.fields.invalid" || err.Error() != "Bad Request. Invalid field name" {
+		t.Errorf("CreateHostedCollector() expected the API's error, got %v", err)
+	}
+	_, err = c.UpdateHostedCollector(defaultCollector, "etag")
+	if _, ok := err.(*APIError); !ok {
+		t.Errorf("UpdateHostedCollector() expected the API's error, got %v", err)
+	}
+}
+
 func TestUpdateHostedCollectorAlreadyExists(t *testing.T) {
 	updatedCollector := defaultCollector
 	updatedCollector.Name = "Updated"
@@ -308,3 +368,403 @@ func TestDeleteHostedCollectorDoesntExist(t *testing.T) {
 		return
 	}
 }
+
+func TestCollectorEquivalent(t *testing.T) {
+	live := defaultCollector
+	live.Alive = true
+	live.LastSeenAlive = 1566302400000
+	live.CollectorVersion = "19.253-4"
+	live.Links = []CollectorLinks{{Rel: "sources", Href: "/v1/collectors/1234567890/sources"}}
+
+	desired := Collector{
+		Name:          "test",
+		CollectorType: "Hosted",
+	}
+	if !desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected collectors differing only in server-managed fields to be equivalent")
+	}
+
+	desired.Description = "changed"
+	if desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected collectors with different descriptions to differ")
+	}
+}
+
+func TestDeleteHostedCollectorIfExistsDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d", defaultCollector.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteHostedCollectorIfExists(defaultCollector.ID)
+	if err != nil {
+		t.Errorf("DeleteHostedCollectorIfExists() returned an error: %s", err)
+		return
+	}
+}
+
+func TestDeleteHostedCollectorRetriesServerErrors(t *testing.T) {
+	defer func(b backoff.Policy) { collectorDeleteBackoff = b }(collectorDeleteBackoff)
+	collectorDeleteBackoff = backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteHostedCollector(defaultCollector.ID)
+	if err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+		return
+	}
+	if calls != 3 {
+		t.Errorf("DeleteHostedCollector() expected 3 attempts, got %d", calls)
+	}
+	if m := c.Metrics()["DELETE collectors/{id}"]; m.Retries != 2 {
+		t.Errorf("Metrics() expected 2 retries, got %d", m.Retries)
+	}
+}
+
+func TestDeleteHostedCollectorRetryNotFound(t *testing.T) {
+	defer func(b backoff.Policy) { collectorDeleteBackoff = b }(collectorDeleteBackoff)
+	collectorDeleteBackoff = backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	recorder := &memoryRecorder{}
+	c, err := NewClient("accessToken", ts.URL, WithChangeRecorder(recorder))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() expected a 404 after a failed attempt to succeed, got %v", err)
+		return
+	}
+	if len(recorder.changes) != 1 || recorder.changes[0].Operation != ChangeDelete {
+		t.Errorf("DeleteHostedCollector() expected the delete to be recorded, got %+v", recorder.changes)
+	}
+}
+
+func TestDeleteHostedCollectorRateLimitedNotFound(t *testing.T) {
+	defer func(b backoff.Policy) { collectorDeleteBackoff = b }(collectorDeleteBackoff)
+	collectorDeleteBackoff = backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != ErrCollectorNotFound {
+		t.Errorf("DeleteHostedCollector() expected ErrCollectorNotFound for a 404 after a 429, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("DeleteHostedCollector() expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestDeleteHostedCollectorContextCancelsRetries(t *testing.T) {
+	defer func(b backoff.Policy) { collectorDeleteBackoff = b }(collectorDeleteBackoff)
+	collectorDeleteBackoff = backoff.Policy{Initial: time.Hour, MaxAttempts: 3}
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.DeleteHostedCollectorContext(ctx, defaultCollector.ID); err != context.DeadlineExceeded {
+		t.Errorf("DeleteHostedCollectorContext() expected the context's error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("DeleteHostedCollectorContext() expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestWaitForCollectorDeleted(t *testing.T) {
+	defer func(d time.Duration) { collectorDeletePollInterval = d }(collectorDeletePollInterval)
+	collectorDeletePollInterval = time.Millisecond
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+			w.WriteHeader(http.StatusOK)
+			w.Write(body)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = c.WaitForCollectorDeleted(ctx, defaultCollector.ID)
+	if err != nil {
+		t.Errorf("WaitForCollectorDeleted() returned an error: %s", err)
+		return
+	}
+	if calls != 3 {
+		t.Errorf("WaitForCollectorDeleted() expected 3 polls, got %d", calls)
+	}
+}
+
+func TestUpdateHostedCollectorETagStrippedByProxy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no update request without an ETag, got ‘%s’", r.Method)
+		}
+		body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+		w.Header().Set("ETag", "etag")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	// Simulate an intermediary that strips ETag headers from responses.
+	stripETag := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err == nil {
+				resp.Header.Del("ETag")
+			}
+			return resp, err
+		})
+	}
+	c, err := NewClient("accessToken", ts.URL, WithMiddleware(stripETag))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, etag, err := c.GetHostedCollector(defaultCollector.ID)
+	if err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if etag != "" {
+		t.Errorf("GetHostedCollector() expected the ETag to be stripped, got `%s`", etag)
+	}
+
+	_, err = c.UpdateHostedCollector(*collector, etag)
+	if err != ErrMissingETag {
+		t.Errorf("UpdateHostedCollector() returned the wrong error: %v", err)
+	}
+}
+
+func TestGetCollectors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/collectors/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		collector := defaultCollector
+		fmt.Sscanf(r.URL.EscapedPath(), "/collectors/%d", &collector.ID)
+		body, _ := json.Marshal(CollectorRequest{Collector: collector})
+		w.Header().Set("ETag", fmt.Sprintf("etag-%d", collector.ID))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithMaxConcurrency(2))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	results := c.GetCollectors([]int{1, 2, 3})
+	if len(results) != 3 {
+		t.Errorf("GetCollectors() expected 3 results, got %d", len(results))
+		return
+	}
+	for i, id := range []int{1, 2, 3} {
+		r := results[i]
+		if r.ID != id {
+			t.Errorf("GetCollectors() expected result %d for ID %d, got %d", i, id, r.ID)
+		}
+		if id == 2 {
+			if r.Err != ErrCollectorNotFound {
+				t.Errorf("GetCollectors() expected ErrCollectorNotFound for ID 2, got %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Collector.ID != id || r.ETag != fmt.Sprintf("etag-%d", id) {
+			t.Errorf("GetCollectors() returned an unexpected result for ID %d: %+v", id, r)
+		}
+	}
+}
+
+func TestListCollectors(t *testing.T) {
+	pages := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/collectors" {
+			t.Errorf("Expected request to ‘/collectors’, got ‘%s’", r.URL.EscapedPath())
+		}
+		query := r.URL.Query()
+		if query.Get("filter") != CollectorFilterHosted {
+			t.Errorf("Expected query parameter ‘filter=hosted’, got ‘%s’", r.URL.RawQuery)
+		}
+		if query.Get("offset") != fmt.Sprint(pages*collectorPageLimit) {
+			t.Errorf("Expected offset ‘%d’, got ‘%s’", pages*collectorPageLimit, query.Get("offset"))
+		}
+		count := collectorPageLimit
+		if pages > 0 {
+			count = 1
+		}
+		pages++
+		var page struct {
+			Collectors []Collector `json:"collectors"`
+		}
+		for i := 0; i < count; i++ {
+			page.Collectors = append(page.Collectors, Collector{ID: i + 1, Name: "collector"})
+		}
+		body, _ := json.Marshal(page)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	collectors, err := c.ListCollectors(ListCollectorsOptions{Filter: CollectorFilterHosted})
+	if err != nil {
+		t.Errorf("ListCollectors() returned an error: %s", err)
+		return
+	}
+	if len(collectors) != collectorPageLimit+1 || pages != 2 {
+		t.Errorf("ListCollectors() expected %d collectors over 2 pages, got %d over %d", collectorPageLimit+1, len(collectors), pages)
+	}
+}
+
+func TestListCollectorsPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := collectorPageLimit
+		if r.URL.Query().Get("offset") != "0" {
+			count = 1
+		}
+		var page struct {
+			Collectors []Collector `json:"collectors"`
+		}
+		for i := 0; i < count; i++ {
+			page.Collectors = append(page.Collectors, Collector{ID: i + 1, Name: "collector"})
+		}
+		body, _ := json.Marshal(page)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	options := ListCollectorsOptions{Filter: CollectorFilterHosted}
+	collectors, cursor, err := c.ListCollectorsPage(options, "")
+	if err != nil {
+		t.Errorf("ListCollectorsPage() returned an error: %s", err)
+		return
+	}
+	if len(collectors) != collectorPageLimit || cursor == "" {
+		t.Errorf("ListCollectorsPage() expected a full page and a cursor, got %d, ‘%s’", len(collectors), cursor)
+		return
+	}
+
+	collectors, cursor, err = c.ListCollectorsPage(options, cursor)
+	if err != nil {
+		t.Errorf("ListCollectorsPage() returned an error: %s", err)
+		return
+	}
+	if len(collectors) != 1 || cursor != "" {
+		t.Errorf("ListCollectorsPage() expected the last page and no cursor, got %d, ‘%s’", len(collectors), cursor)
+	}
+}
+
+func TestDeleteHostedCollectorWithETag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != "current" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+
+	if err := c.DeleteHostedCollectorWithETag(defaultCollector.ID, "current"); err != nil {
+		t.Errorf("DeleteHostedCollectorWithETag() returned an error: %s", err)
+	}
+	if err := c.DeleteHostedCollectorWithETag(defaultCollector.ID, "stale"); err != ErrPreconditionFailed {
+		t.Errorf("DeleteHostedCollectorWithETag() expected ErrPreconditionFailed, got %v", err)
+	}
+}
+
+func TestCollectorForceSendFields(t *testing.T) {
+	body, _ := json.Marshal(Collector{Name: "test", Fields: map[string]string{}})
+	if string(body) != `{"name":"test"}` {
+		t.Errorf("Expected empty fields to be omitted, got `%s`", body)
+	}
+
+	body, _ = json.Marshal(Collector{Name: "test", ForceSendFields: []string{"fields"}})
+	if string(body) != `{"fields":{},"name":"test"}` {
+		t.Errorf("Expected `\"fields\": {}` with ForceSendFields, got `%s`", body)
+	}
+}
@@ -1,13 +1,9 @@
 package sumologic
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"log"
+	"context"
 	"net/http"
-	"net/url"
+	"reflect"
 )
 
 // HTTPSource is a necessary wrapper for source API calls.
@@ -16,167 +12,287 @@ type HTTPSourceRequest struct {
 }
 
 // HTTPSource can various types of sources including Cloudtrail and S3.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
 type HTTPSource struct {
-	ID                         int      `json:"id,omitempty"`
-	Name                       string   `json:"name"`
-	CollectorID                int      `json:"CollectorId,omitempty"`
-	Description                string   `json:"description,omitempty"`
-	Category                   string   `json:"category,omitempty"`
-	TimeZone                   string   `json:"timezone,omitempty"`
-	SourceType                 string   `json:"sourceType,omitempty"`
-	MessagePerRequest          bool     `json:"messagePerRequest"`
-	MultilineProcessingEnabled bool     `json:"multilineProcessingEnabled"`
-	UseAutolineMatching        bool     `json:"useAutolineMatching,"`
-	ManualPrefixRegexp         string   `json:"manualPrefixRegexp,omitempty"`
-	Url                        string   `json:"url,omitempty"`
-	Filters                    []Filter `json:"filters,omitempty"`
+	ID                         int          `json:"id,omitempty"`
+	Name                       string       `json:"name"`
+	CollectorID                int          `json:"CollectorId,omitempty"`
+	Description                string       `json:"description,omitempty"`
+	Category                   string       `json:"category,omitempty"`
+	TimeZone                   string       `json:"timezone,omitempty"`
+	SourceType                 string       `json:"sourceType,omitempty"`
+	MessagePerRequest          bool         `json:"messagePerRequest,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	Url                        string       `json:"url,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	// AllowedOrigins lists the origins browsers may send data from (CORS), e.g. for
+	// ingesting from web applications.
+	AllowedOrigins []string `json:"allowedOrigins,omitempty"`
+	// TokenAuthEnabled requires clients to send Token in the X-Sumo-Token header instead of
+	// authenticating with the secret embedded in Url.
+	TokenAuthEnabled bool `json:"tokenAuthEnabled,omitempty"`
+	// Token is generated by Sumo Logic when TokenAuthEnabled is set.
+	Token string `json:"token,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
 }
 
-// GetHTTPSource gets the source with the specified ID.
-func (s *Client) GetHTTPSource(collectorID int, id int) (*HTTPSource, string, error) {
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s HTTPSource) MarshalJSON() ([]byte, error) {
+	type httpSource HTTPSource
+	return marshalForceSend(httpSource(s), s.ForceSendFields)
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, id))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *HTTPSource) UnmarshalJSON(data []byte) error {
+	type httpSource HTTPSource
+	return unmarshalLenient(data, (*httpSource)(s))
+}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+// Validate checks the fields validated for all sources and the combinations of settings the
+// API rejects, without calling the API. A *ValidationError naming the offending field is
+// returned otherwise.
+func (s HTTPSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	if !s.MessagePerRequest {
+		return nil
+	}
+	// With one message per request, messages are never split into lines or joined, so the
+	// multiline settings can't apply.
+	switch {
+	case s.MultilineProcessingEnabled:
+		return &ValidationError{Field: "multilineProcessingEnabled", Message: "must be false when messagePerRequest is true; each request is already a single message"}
+	case s.UseAutolineMatching:
+		return &ValidationError{Field: "useAutolineMatching", Message: "must be false when messagePerRequest is true; each request is already a single message"}
+	case s.ManualPrefixRegexp != "":
+		return &ValidationError{Field: "manualPrefixRegexp", Message: "must be unset when messagePerRequest is true; each request is already a single message"}
+	}
+	return nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID, the ingestion URL and token) are ignored.
+func (s HTTPSource) Equivalent(other HTTPSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s HTTPSource) userManaged() HTTPSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Url = ""
+	s.Token = ""
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.AllowedOrigins) == 0 {
+		s.AllowedOrigins = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetHTTPSource gets the source with the specified ID.
+// If the client was created with WithMaskedSourceURLs, the source's URL and token are masked.
+func (s *Client) GetHTTPSource(collectorID int, id int) (*HTTPSource, string, error) {
+	source, etag, err := s.getHTTPSource(collectorID, id)
 	if err != nil {
 		return nil, "", err
 	}
-	defer resp.Body.Close()
+	s.maskHTTPSource(source)
+	return source, etag, nil
+}
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
+// GetHTTPSourceURL gets the unmasked ingestion URL of the source with the specified ID,
+// regardless of WithMaskedSourceURLs.
+func (s *Client) GetHTTPSourceURL(collectorID int, id int) (string, error) {
+	source, _, err := s.getHTTPSource(collectorID, id)
+	if err != nil {
+		return "", err
+	}
+	return source.Url, nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var r = new(HTTPSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, "", err
+// RegenerateHTTPSourceURL replaces the ingestion URL of the HTTP source with the specified ID,
+// e.g. after it leaked, and returns the new URL regardless of WithMaskedSourceURLs. Data sent
+// to the old URL is rejected from then on.
+func (s *Client) RegenerateHTTPSourceURL(collectorID int, id int) (string, error) {
+	path, err := formatPath("collectors/%d/sources/%d/regenerateUrl", collectorID, id)
+	if err != nil {
+		return "", err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeSource, id, collectorID, nil); err != nil {
+		return "", err
+	}
+	var r = new(HTTPSourceRequest)
+	if _, err := s.do("POST", path, nil, r); err != nil {
+		if _, ok := badRequest(err); ok {
+			return "", err
 		}
+		return "", errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
+	}
 
-		return &r.Source, resp.Header.Get("ETag"), nil
-	case http.StatusUnauthorized:
-		return nil, "", ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, "", ErrSourceNotFound
-	default:
-		return nil, "", fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, id, collectorID, nil)
+	return r.Source.Url, nil
+}
+
+func (s *Client) getHTTPSource(collectorID int, id int) (*HTTPSource, string, error) {
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	var r = new(HTTPSourceRequest)
+	resp, err := s.do("GET", path, nil, r)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
 	}
+
+	return &r.Source, resp.Header.Get("ETag"), nil
 }
 
-// CreateHTTPSource creates a new HTTPSource.
+// CreateHTTPSource creates a new HTTPSource. The returned source's Url is the ingestion URL
+// generated by Sumo Logic.
+// If the client was created with WithMaskedSourceURLs, the returned URL and token are masked.
 func (s *Client) CreateHTTPSource(collectorID int, source HTTPSource) (*HTTPSource, error) {
+	created, _, err := s.CreateHTTPSourceWithResponse(collectorID, source)
+	return created, err
+}
+
+// CreateHTTPSourceWithResponse creates a new HTTPSource like CreateHTTPSource, and also returns
+// the response, whose ETag can be passed to a later update without a Get.
+func (s *Client) CreateHTTPSourceWithResponse(collectorID int, source HTTPSource) (*HTTPSource, *Response, error) {
+	if err := source.Validate(); err != nil {
+		return nil, nil, err
+	}
 
 	request := HTTPSourceRequest{
 		Source: source,
 	}
 
-	log.Printf("Sumologic API Request: %+v", request)
-
-	body, _ := json.Marshal(request)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources", collectorID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-
-	resp, err := http.DefaultClient.Do(req)
+	path, err := formatPath("collectors/%d/sources", collectorID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var r = new(HTTPSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, err
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeSource, nil, collectorID, source); err != nil {
+		return nil, nil, err
+	}
+	var r = new(HTTPSourceRequest)
+	resp, err := s.do("POST", path, request, r)
+	if err != nil {
+		e, ok := badRequest(err)
+		if !ok {
+			return nil, nil, sourceBadRequest(err, source.Name)
 		}
-
-		return &r.Source, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		var e = new(Error)
-		return nil, fmt.Errorf("Bad Request. %s", e.Message)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+		if err := planLimitError(ResourceTypeSource, e); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, err
 	}
+
+	s.maskHTTPSource(&r.Source)
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, s.newResponse(resp), nil
 }
 
 // UpdateHTTPSource updates an existing HTTP source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+// A masked URL or token, as returned with WithMaskedSourceURLs, is not sent back.
 func (s *Client) UpdateHTTPSource(collectorID int, source HTTPSource, etag string) (*HTTPSource, error) {
-	request := HTTPSourceRequest{
-		Source: source,
-	}
-
-	body, _ := json.Marshal(request)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, source.ID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-	req.Header.Add("If-Match", etag)
+	updated, _, err := s.UpdateHTTPSourceWithResponse(collectorID, source, etag)
+	return updated, err
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// UpdateHTTPSourceWithResponse updates an existing HTTP source like UpdateHTTPSource, and also
+// returns the response, whose ETag is the source's new ETag.
+func (s *Client) UpdateHTTPSourceWithResponse(collectorID int, source HTTPSource, etag string) (*HTTPSource, *Response, error) {
+	etag, err := s.resolveETag(etag, "collectors/%d/sources/%d", collectorID, source.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var r = new(HTTPSourceRequest)
-		err = json.Unmarshal(responseBody, &r)
-		if err != nil {
-			return nil, err
-		}
+	if err := source.Validate(); err != nil {
+		return nil, nil, err
+	}
+	unmaskHTTPSource(&source)
 
-		return &r.Source, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		return nil, fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", source.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, source.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeSource, source.ID, collectorID, source); err != nil {
+		return nil, nil, err
 	}
+	var r = new(HTTPSourceRequest)
+	resp, err := s.doIfMatch("PUT", path, etag, HTTPSourceRequest{Source: source}, r)
+	if err != nil {
+		return nil, nil, sourceBadRequest(err, source.Name)
+	}
+
+	s.maskHTTPSource(&r.Source)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, s.newResponse(resp), nil
 }
 
 // DeleteHTTPSource deletes the source with the specified ID.
 func (s *Client) DeleteHTTPSource(collectorID int, id int) error {
-	c, _ := url.Parse(fmt.Sprintf("collectors/%d/sources/%d", collectorID, id))
-	req, err := http.NewRequest("DELETE", s.EndpointURL.ResolveReference(c).String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteHTTPSourceWithETag deletes the source with the specified ID only if it hasn't changed since
+// the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteHTTPSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// DeleteHTTPSourceWithResponse deletes the source with the specified ID like DeleteHTTPSource,
+// or like DeleteHTTPSourceWithETag if etag is not empty, and also returns the response.
+func (s *Client) DeleteHTTPSourceWithResponse(collectorID int, id int, etag string) (*Response, error) {
+	resp, err := s.deleteSourceWithResponse(context.Background(), collectorID, id, etag)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return s.newResponse(resp), nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
+// DeleteHTTPSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteHTTPSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteHTTPSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteHTTPSource(collectorID, id)
+	if err == ErrSourceNotFound {
 		return nil
-	case http.StatusNotFound:
-		return ErrSourceNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
 	}
+	return err
 }
@@ -3,7 +3,7 @@ package sumologic
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -88,7 +88,7 @@ func TestCreateHTTPSourceOK(t *testing.T) {
 		if ctype := r.Header.Get("Content-Type"); ctype != "application/json" {
 			t.Errorf("Expected response to be content-type ‘application/json’, got ‘%s’", ctype)
 		}
-		body, _ := ioutil.ReadAll(r.Body)
+		body, _ := io.ReadAll(r.Body)
 		sr := new(HTTPSourceRequest)
 		err := json.Unmarshal(body, &sr)
 		if err != nil {
@@ -282,3 +282,222 @@ func TestDeleteHTTPSourceDoesntExist(t *testing.T) {
 		return
 	}
 }
+
+func TestHTTPSourceEquivalent(t *testing.T) {
+	live := defaultHTTPSource
+	live.Url = "https://endpoint1.collection.sumologic.com/receiver/v1/http/secret"
+	live.Filters = []Filter{}
+
+	desired := HTTPSource{
+		Name: "test",
+	}
+	if !desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected sources differing only in server-managed fields to be equivalent")
+	}
+
+	desired.MessagePerRequest = true
+	if desired.Equivalent(live) {
+		t.Errorf("Equivalent() expected sources with different messagePerRequest to differ")
+	}
+}
+
+func TestDeleteHTTPSourceIfExistsDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d/sources/%d", defaultHTTPSource.CollectorID, defaultHTTPSource.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteHTTPSourceIfExists(defaultHTTPSource.CollectorID, defaultHTTPSource.ID)
+	if err != nil {
+		t.Errorf("DeleteHTTPSourceIfExists() returned an error: %s", err)
+		return
+	}
+}
+
+func TestHTTPSourceForceSendFields(t *testing.T) {
+	body, _ := json.Marshal(HTTPSource{Name: "test", ForceSendFields: []string{"messagePerRequest"}})
+	var fields map[string]interface{}
+	json.Unmarshal(body, &fields)
+	if v, ok := fields["messagePerRequest"]; !ok || v != false {
+		t.Errorf("Expected `\"messagePerRequest\": false` with ForceSendFields, got `%s`", body)
+	}
+	if _, ok := fields["useAutolineMatching"]; ok {
+		t.Errorf("Expected unset field `useAutolineMatching` to be omitted, got `%s`", body)
+	}
+}
+
+func TestUpdateHTTPSourceMissingETag(t *testing.T) {
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.UpdateHTTPSource(defaultHTTPSource.CollectorID, defaultHTTPSource, "")
+	if err != ErrMissingETag {
+		t.Errorf("UpdateHTTPSource() returned the wrong error: %v", err)
+	}
+}
+
+func TestHTTPSourceValidateMessagePerRequest(t *testing.T) {
+	valid := HTTPSource{Name: "test", MessagePerRequest: true}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() returned an error: %s", err)
+	}
+
+	cases := map[string]HTTPSource{
+		"multilineProcessingEnabled": {Name: "test", MessagePerRequest: true, MultilineProcessingEnabled: true},
+		"useAutolineMatching":        {Name: "test", MessagePerRequest: true, UseAutolineMatching: true},
+		"manualPrefixRegexp":         {Name: "test", MessagePerRequest: true, ManualPrefixRegexp: `\d{4}.*`},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+
+	// The conflict is reported before any request is made.
+	c, _ := NewClient("accessToken", "http://127.0.0.1:0/")
+	if _, err := c.CreateHTTPSource(1, cases["multilineProcessingEnabled"]); err == nil {
+		t.Errorf("CreateHTTPSource() expected a ValidationError")
+	} else if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("CreateHTTPSource() expected a ValidationError, got %v", err)
+	}
+}
+
+func TestHTTPSourceCORSAndTokenRoundTrip(t *testing.T) {
+	stored := `{"source":{"id":1234567890,"name":"browser","allowedOrigins":["https://app.example.com"],"tokenAuthEnabled":true,"token":"c3VtbzpzZWNyZXQ="}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body, _ := io.ReadAll(r.Body)
+			var sent map[string]map[string]interface{}
+			json.Unmarshal(body, &sent)
+			origins, _ := sent["source"]["allowedOrigins"].([]interface{})
+			if len(origins) != 1 || origins[0] != "https://app.example.com" {
+				t.Errorf("Expected allowedOrigins to be sent back, got %v", sent["source"]["allowedOrigins"])
+			}
+			if sent["source"]["tokenAuthEnabled"] != true {
+				t.Errorf("Expected tokenAuthEnabled to be sent back, got %v", sent["source"]["tokenAuthEnabled"])
+			}
+		}
+		w.Header().Set("ETag", "etag")
+		w.Write([]byte(stored))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+
+	source, etag, err := c.GetHTTPSource(1, 1234567890)
+	if err != nil {
+		t.Errorf("GetHTTPSource() returned an error: %s", err)
+		return
+	}
+	if !source.TokenAuthEnabled || source.Token == "" || len(source.AllowedOrigins) != 1 {
+		t.Errorf("GetHTTPSource() did not decode the CORS and token fields, got %+v", source)
+		return
+	}
+
+	source.Description = "updated"
+	if _, err := c.UpdateHTTPSource(1, *source, etag); err != nil {
+		t.Errorf("UpdateHTTPSource() returned an error: %s", err)
+	}
+}
+
+func TestDeleteHTTPSourceWithETag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		if r.Header.Get("If-Match") != "current" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+
+	if err := c.DeleteHTTPSourceWithETag(defaultHTTPSource.CollectorID, defaultHTTPSource.ID, "current"); err != nil {
+		t.Errorf("DeleteHTTPSourceWithETag() returned an error: %s", err)
+	}
+	if err := c.DeleteHTTPSourceWithETag(defaultHTTPSource.CollectorID, defaultHTTPSource.ID, "stale"); err != ErrPreconditionFailed {
+		t.Errorf("DeleteHTTPSourceWithETag() expected ErrPreconditionFailed, got %v", err)
+	}
+	if err := c.DeleteHTTPSourceWithETag(defaultHTTPSource.CollectorID, defaultHTTPSource.ID, ""); err != ErrMissingETag {
+		t.Errorf("DeleteHTTPSourceWithETag() expected ErrMissingETag, got %v", err)
+	}
+}
+
+func TestRegenerateHTTPSourceURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d/sources/%d/regenerateUrl", defaultHTTPSource.CollectorID, defaultHTTPSource.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"source":{"id":1234567890,"name":"test","sourceType":"HTTP","url":"https://endpoint1.collection.sumologic.com/receiver/v1/http/rotated"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	url, err := c.RegenerateHTTPSourceURL(defaultHTTPSource.CollectorID, defaultHTTPSource.ID)
+	if err != nil {
+		t.Errorf("RegenerateHTTPSourceURL() returned an error: %s", err)
+		return
+	}
+	if url != "https://endpoint1.collection.sumologic.com/receiver/v1/http/rotated" {
+		t.Errorf("RegenerateHTTPSourceURL() expected the unmasked new URL, got ‘%s’", url)
+	}
+	if _, err := c.RegenerateHTTPSourceURL(defaultHTTPSource.CollectorID, 1); err != ErrSourceNotFound {
+		t.Errorf("RegenerateHTTPSourceURL() expected ErrSourceNotFound, got %v", err)
+	}
+}
+
+func TestHTTPSourceTimestampParsing(t *testing.T) {
+	source := HTTPSource{
+		Name:               "test",
+		ForceTimeZone:      true,
+		TimeZone:           "UTC",
+		DefaultDateFormats: []DateFormat{{Format: "yyyy-MM-dd HH:mm:ss,SSS", Locator: `^\[(.*?)\]`}},
+		ForceSendFields:    []string{"automaticDateParsing"},
+	}
+	body, _ := json.Marshal(source)
+	var sent map[string]interface{}
+	json.Unmarshal(body, &sent)
+	if sent["automaticDateParsing"] != false || sent["forceTimeZone"] != true {
+		t.Errorf("Expected `\"automaticDateParsing\": false` and `\"forceTimeZone\": true`, got `%s`", body)
+	}
+
+	var decoded HTTPSource
+	if err := json.Unmarshal(body, &decoded); err != nil || !source.Equivalent(decoded) {
+		t.Errorf("Expected the date formats to round-trip, got %+v and %v", decoded, err)
+	}
+
+	source.DefaultDateFormats = append(source.DefaultDateFormats, DateFormat{Locator: "(.*)"})
+	if err, ok := source.Validate().(*ValidationError); !ok || err.Field != "defaultDateFormats" {
+		t.Errorf("Validate() expected a defaultDateFormats ValidationError, got %v", source.Validate())
+	}
+}
@@ -0,0 +1,27 @@
+package sumologic
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Newer Sumo Logic APIs (e.g. content, health events, monitors and dashboards) identify
+// resources with hexadecimal string IDs, while the collector management API uses int IDs.
+// Resources from the newer APIs keep their IDs as strings; HexID and ParseHexID convert
+// collector and source IDs between the two schemes.
+
+// HexID formats an int ID, such as a collector or source ID, as the 16 digit hexadecimal
+// string used to refer to it in newer APIs.
+func HexID(id int) string {
+	return fmt.Sprintf("%016X", id)
+}
+
+// ParseHexID parses a hexadecimal string ID from a newer API into the int ID used by the
+// collector management API.
+func ParseHexID(id string) (int, error) {
+	n, err := strconv.ParseInt(id, 16, 64)
+	if err != nil || n <= 0 {
+		return 0, &ValidationError{Field: "id", Message: fmt.Sprintf("`%s` is not a hexadecimal ID", id)}
+	}
+	return int(n), nil
+}
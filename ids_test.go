@@ -0,0 +1,29 @@
+package sumologic
+
+import "testing"
+
+func TestHexID(t *testing.T) {
+	if id := HexID(108448215); id != "000000000676C9D7" {
+		t.Errorf("HexID() expected ‘000000000676C9D7’, got ‘%s’", id)
+	}
+}
+
+func TestParseHexID(t *testing.T) {
+	id, err := ParseHexID("000000000676C9D7")
+	if err != nil {
+		t.Errorf("ParseHexID() returned an error: %s", err)
+		return
+	}
+	if id != 108448215 {
+		t.Errorf("ParseHexID() expected ‘108448215’, got ‘%d’", id)
+	}
+	if id, _ := ParseHexID(HexID(42)); id != 42 {
+		t.Errorf("ParseHexID() did not round trip HexID(), got ‘%d’", id)
+	}
+
+	for _, invalid := range []string{"", "XYZ", "-1", "0000000000000000"} {
+		if _, err := ParseHexID(invalid); err == nil {
+			t.Errorf("ParseHexID() did not return an error for ‘%s’", invalid)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Ingest budget actions once the capacity is reached.
+const (
+	BudgetActionStopCollecting = "stopCollecting"
+	BudgetActionKeepCollecting = "keepCollecting"
+)
+
+// ErrIngestBudgetNotFound is returned when an ingest budget doesn't exist on a Get, Update,
+// Delete or usage reset.
+var ErrIngestBudgetNotFound = errors.New("Ingest budget not found")
+
+// IngestBudgetV2 is a scope-based ingest budget. Data whose metadata matches Scope counts
+// towards the budget's daily capacity.
+type IngestBudgetV2 struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Scope selects the data governed by the budget, e.g. `_sourceCategory=prod/*`.
+	// See BudgetScope.
+	Scope         string `json:"scope"`
+	CapacityBytes int64  `json:"capacityBytes"`
+	TimeZone      string `json:"timezone"`
+	// ResetTime is the time of day the usage is reset, in `HH:MM` format.
+	ResetTime string `json:"resetTime"`
+	// Action is `stopCollecting` or `keepCollecting` once the capacity is reached.
+	Action         string `json:"action"`
+	AuditThreshold int    `json:"auditThreshold,omitempty"`
+	BudgetType     string `json:"budgetType,omitempty"`
+	UsageBytes     int64  `json:"usageBytes,omitempty"`
+	UsageStatus    string `json:"usageStatus,omitempty"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	ModifiedAt     string `json:"modifiedAt,omitempty"`
+	ModifiedBy     string `json:"modifiedBy,omitempty"`
+}
+
+// Validate checks the budget for errors the API would reject, without sending a request.
+func (b IngestBudgetV2) Validate() error {
+	if _, _, err := ParseBudgetScope(b.Scope); err != nil {
+		return err
+	}
+	return validateBudget(b.Name, b.CapacityBytes, b.TimeZone, b.ResetTime, b.Action, b.AuditThreshold)
+}
+
+// budgetResetTimePattern matches an ingest budget reset time of day.
+var budgetResetTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// validateBudget checks the settings shared by v1 and v2 ingest budgets.
+func validateBudget(name string, capacityBytes int64, timeZone, resetTime, action string, auditThreshold int) error {
+	if name == "" {
+		return &ValidationError{Field: "name", Message: "must not be empty"}
+	}
+	if capacityBytes < 0 {
+		return &ValidationError{Field: "capacityBytes", Message: fmt.Sprintf("must not be negative, got %d", capacityBytes)}
+	}
+	if err := ValidateTimeZone(timeZone); err != nil {
+		return err
+	}
+	if !budgetResetTimePattern.MatchString(resetTime) {
+		return &ValidationError{Field: "resetTime", Message: fmt.Sprintf("`%s` must be a time of day in `HH:MM` format", resetTime)}
+	}
+	if action != BudgetActionStopCollecting && action != BudgetActionKeepCollecting {
+		return &ValidationError{Field: "action", Message: fmt.Sprintf("must be `%s` or `%s`, got `%s`", BudgetActionStopCollecting, BudgetActionKeepCollecting, action)}
+	}
+	if auditThreshold < 0 || auditThreshold > 100 {
+		return &ValidationError{Field: "auditThreshold", Message: fmt.Sprintf("must be a percentage between 0 and 100, got %d", auditThreshold)}
+	}
+	return nil
+}
+
+// ListIngestBudgetsV2 returns all scope-based ingest budgets, following pagination
+// transparently.
+func (s *Client) ListIngestBudgetsV2() ([]IngestBudgetV2, error) {
+	budgets := []IngestBudgetV2{}
+	err := s.listAllPages("../v2/ingestBudgets", nil, func(data json.RawMessage) error {
+		var page []IngestBudgetV2
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		budgets = append(budgets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// CreateIngestBudgetV2 creates a new scope-based ingest budget.
+func (s *Client) CreateIngestBudgetV2(budget IngestBudgetV2) (*IngestBudgetV2, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeIngestBudget, nil, nil, budget); err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudgetV2)
+	if _, err := s.do("POST", "../v2/ingestBudgets", budget, b); err != nil {
+		return nil, budgetBadRequest(err, budget.Name)
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeIngestBudget, b.ID, nil, b)
+	return b, nil
+}
+
+// GetIngestBudgetV2 gets the scope-based ingest budget with the specified ID.
+func (s *Client) GetIngestBudgetV2(id string) (*IngestBudgetV2, error) {
+	path, err := formatPath("../v2/ingestBudgets/%s", id)
+	if err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudgetV2)
+	if _, err := s.do("GET", path, nil, b); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound)
+	}
+	return b, nil
+}
+
+// UpdateIngestBudgetV2 updates the scope-based ingest budget with the ID of budget.
+func (s *Client) UpdateIngestBudgetV2(budget IngestBudgetV2) (*IngestBudgetV2, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	path, err := formatPath("../v2/ingestBudgets/%s", budget.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeIngestBudget, budget.ID, nil, budget); err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudgetV2)
+	if _, err := s.do("PUT", path, budget, b); err != nil {
+		return nil, budgetBadRequest(errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound), budget.Name)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeIngestBudget, b.ID, nil, b)
+	return b, nil
+}
+
+// DeleteIngestBudgetV2 deletes the scope-based ingest budget with the specified ID.
+func (s *Client) DeleteIngestBudgetV2(id string) error {
+	return s.deleteIngestBudget("../v2/ingestBudgets/%s", id)
+}
+
+// ResetIngestBudgetV2Usage resets the usage of the scope-based ingest budget with the
+// specified ID to zero, e.g. to resume collection after the capacity was reached.
+func (s *Client) ResetIngestBudgetV2Usage(id string) error {
+	return s.resetIngestBudgetUsage("../v2/ingestBudgets/%s/usage/reset", id)
+}
+
+// deleteIngestBudget deletes the v1 or v2 ingest budget at the path format with id.
+func (s *Client) deleteIngestBudget(format, id string) error {
+	path, err := formatPath(format, id)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeDelete, ResourceTypeIngestBudget, id, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do("DELETE", path, nil, nil); err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound)
+	}
+
+	s.recordChange(ChangeDelete, ResourceTypeIngestBudget, id, nil, nil)
+	return nil
+}
+
+// resetIngestBudgetUsage resets the usage of the v1 or v2 ingest budget at the path format
+// with id.
+func (s *Client) resetIngestBudgetUsage(format, id string) error {
+	path, err := formatPath(format, id)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeIngestBudget, id, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do("POST", path, nil, nil); err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeIngestBudget, id, nil, nil)
+	return nil
+}
+
+// budgetBadRequest explains a 400 response to creating or updating an ingest budget named
+// name, unless the API returned a message.
+func budgetBadRequest(err error, name string) error {
+	if _, ok := badRequest(err); ok {
+		return err
+	}
+	return errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if an ingest budget named `%s` already exists", name))
+}
+
+// ListBudgetsMatchingSource returns the scope-based ingest budgets that govern data from
+// source on collector, answering "which budget caps this source". A source without a category
+// inherits the collector's category, and the collector's and source's fields are matched
+// against field scopes.
+func (s *Client) ListBudgetsMatchingSource(collector Collector, source Source) ([]IngestBudgetV2, error) {
+	metadata, err := sourceMetadata(collector, source)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := s.ListIngestBudgetsV2()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := []IngestBudgetV2{}
+	for _, budget := range budgets {
+		if BudgetScopeMatches(budget.Scope, metadata) {
+			matching = append(matching, budget)
+		}
+	}
+	return matching, nil
+}
+
+// sourceMetadata returns the metadata and fields attached to messages from source on collector.
+func sourceMetadata(collector Collector, source Source) (map[string]string, error) {
+	var definition struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if source.raw != nil {
+		if err := source.Decode(&definition); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := map[string]string{}
+	for key, value := range collector.Fields {
+		metadata[strings.ToLower(key)] = value
+	}
+	for key, value := range definition.Fields {
+		metadata[strings.ToLower(key)] = value
+	}
+	category := source.Category
+	if category == "" {
+		category = collector.Category
+	}
+	metadata[ScopeSourceCategory] = category
+	metadata[ScopeCollector] = collector.Name
+	metadata[ScopeSource] = source.Name
+	return metadata, nil
+}
@@ -0,0 +1,136 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListBudgetsMatchingSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v2/ingestBudgets" {
+			t.Errorf("Expected request to ‘/api/v2/ingestBudgets’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"data": []IngestBudgetV2{
+				{ID: "1", Name: "payments", Scope: "_sourceCategory=prod/payments/*"},
+				{ID: "2", Name: "billing", Scope: "_sourceCategory=prod/billing/*"},
+				{ID: "3", Name: "team", Scope: "team=payments"},
+			},
+		})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var source Source
+	if err := json.Unmarshal([]byte(`{"id":2,"name":"api","sourceType":"HTTP","fields":{"team":"payments"}}`), &source); err != nil {
+		t.Errorf("json.Unmarshal() returned an error: %s", err)
+		return
+	}
+	collector := Collector{ID: 1, Name: "payments", Category: "prod/payments/api"}
+
+	budgets, err := c.ListBudgetsMatchingSource(collector, source)
+	if err != nil {
+		t.Errorf("ListBudgetsMatchingSource() returned an error: %s", err)
+		return
+	}
+	if len(budgets) != 2 || budgets[0].ID != "1" || budgets[1].ID != "3" {
+		t.Errorf("ListBudgetsMatchingSource() expected budgets 1 and 3, got %+v", budgets)
+	}
+}
+
+var defaultIngestBudgetV2 = IngestBudgetV2{
+	ID:            "0000000000000D01",
+	Name:          "payments",
+	Scope:         "_sourceCategory=prod/payments/*",
+	CapacityBytes: 10 << 30,
+	TimeZone:      "Etc/UTC",
+	ResetTime:     "00:00",
+	Action:        BudgetActionStopCollecting,
+}
+
+func TestIngestBudgetV2CRUD(t *testing.T) {
+	var reset, deleted bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budgetPath := "/api/v2/ingestBudgets/" + defaultIngestBudgetV2.ID
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/api/v2/ingestBudgets":
+			body, _ := io.ReadAll(r.Body)
+			b := new(IngestBudgetV2)
+			json.Unmarshal(body, &b)
+			b.ID = defaultIngestBudgetV2.ID
+			js, _ := json.Marshal(b)
+			w.Write(js)
+		case (r.Method == "GET" || r.Method == "PUT") && r.URL.EscapedPath() == budgetPath:
+			js, _ := json.Marshal(defaultIngestBudgetV2)
+			w.Write(js)
+		case r.Method == "POST" && r.URL.EscapedPath() == budgetPath+"/usage/reset":
+			reset = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == budgetPath:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	budget := defaultIngestBudgetV2
+	budget.ID = ""
+	created, err := c.CreateIngestBudgetV2(budget)
+	if err != nil || created.ID != defaultIngestBudgetV2.ID {
+		t.Errorf("CreateIngestBudgetV2() returned %+v and %v", created, err)
+		return
+	}
+	if _, err := c.GetIngestBudgetV2(created.ID); err != nil {
+		t.Errorf("GetIngestBudgetV2() returned an error: %s", err)
+	}
+	if _, err := c.UpdateIngestBudgetV2(*created); err != nil {
+		t.Errorf("UpdateIngestBudgetV2() returned an error: %s", err)
+	}
+	if err := c.ResetIngestBudgetV2Usage(created.ID); err != nil || !reset {
+		t.Errorf("ResetIngestBudgetV2Usage() returned %v, reset %t", err, reset)
+	}
+	if err := c.DeleteIngestBudgetV2(created.ID); err != nil || !deleted {
+		t.Errorf("DeleteIngestBudgetV2() returned %v, deleted %t", err, deleted)
+	}
+	if _, err := c.GetIngestBudgetV2("missing"); err != ErrIngestBudgetNotFound {
+		t.Errorf("GetIngestBudgetV2() expected ErrIngestBudgetNotFound, got %v", err)
+	}
+}
+
+func TestIngestBudgetV2Validate(t *testing.T) {
+	cases := map[string]func(b *IngestBudgetV2){
+		"scope":          func(b *IngestBudgetV2) { b.Scope = "prod" },
+		"name":           func(b *IngestBudgetV2) { b.Name = "" },
+		"capacityBytes":  func(b *IngestBudgetV2) { b.CapacityBytes = -1 },
+		"resetTime":      func(b *IngestBudgetV2) { b.ResetTime = "24:00" },
+		"action":         func(b *IngestBudgetV2) { b.Action = "stop" },
+		"auditThreshold": func(b *IngestBudgetV2) { b.AuditThreshold = 101 },
+	}
+	for field, invalidate := range cases {
+		b := defaultIngestBudgetV2
+		invalidate(&b)
+		if verr, ok := b.Validate().(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ‘%s’ error, got %v", field, b.Validate())
+		}
+	}
+	if err := defaultIngestBudgetV2.Validate(); err != nil {
+		t.Errorf("Validate() returned an error for a valid budget: %s", err)
+	}
+}
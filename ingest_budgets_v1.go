@@ -0,0 +1,182 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// IngestBudget is a v1 ingest budget. Data from collectors assigned to the budget, with
+// AssignCollectorToIngestBudget or the collector's `_budget` field set to FieldValue, counts
+// towards the budget's daily capacity. New budgets should normally be scope-based
+// (IngestBudgetV2).
+type IngestBudget struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// FieldValue is the value of the `_budget` field that assigns collectors to the budget.
+	FieldValue    string `json:"fieldValue"`
+	CapacityBytes int64  `json:"capacityBytes"`
+	TimeZone      string `json:"timezone"`
+	// ResetTime is the time of day the usage is reset, in `HH:MM` format.
+	ResetTime string `json:"resetTime"`
+	// Action is BudgetActionStopCollecting or BudgetActionKeepCollecting once the capacity is
+	// reached.
+	Action             string `json:"action"`
+	AuditThreshold     int    `json:"auditThreshold,omitempty"`
+	NumberOfCollectors int    `json:"numberOfCollectors,omitempty"`
+	UsageBytes         int64  `json:"usageBytes,omitempty"`
+	UsageStatus        string `json:"usageStatus,omitempty"`
+	CreatedAt          string `json:"createdAt,omitempty"`
+	CreatedBy          string `json:"createdBy,omitempty"`
+	ModifiedAt         string `json:"modifiedAt,omitempty"`
+	ModifiedBy         string `json:"modifiedBy,omitempty"`
+}
+
+// IngestBudgetCollector is a collector assigned to a v1 ingest budget.
+type IngestBudgetCollector struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Validate checks the budget for errors the API would reject, without sending a request.
+func (b IngestBudget) Validate() error {
+	if b.FieldValue == "" {
+		return &ValidationError{Field: "fieldValue", Message: "must not be empty"}
+	}
+	return validateBudget(b.Name, b.CapacityBytes, b.TimeZone, b.ResetTime, b.Action, b.AuditThreshold)
+}
+
+// ListIngestBudgets returns all v1 ingest budgets, following pagination transparently.
+func (s *Client) ListIngestBudgets() ([]IngestBudget, error) {
+	budgets := []IngestBudget{}
+	err := s.listAllPages("ingestBudgets", nil, func(data json.RawMessage) error {
+		var page []IngestBudget
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		budgets = append(budgets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// CreateIngestBudget creates a new v1 ingest budget.
+func (s *Client) CreateIngestBudget(budget IngestBudget) (*IngestBudget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeIngestBudget, nil, nil, budget); err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudget)
+	if _, err := s.do("POST", "ingestBudgets", budget, b); err != nil {
+		return nil, budgetBadRequest(err, budget.Name)
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeIngestBudget, b.ID, nil, b)
+	return b, nil
+}
+
+// GetIngestBudget gets the v1 ingest budget with the specified ID.
+func (s *Client) GetIngestBudget(id string) (*IngestBudget, error) {
+	path, err := formatPath("ingestBudgets/%s", id)
+	if err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudget)
+	if _, err := s.do("GET", path, nil, b); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound)
+	}
+	return b, nil
+}
+
+// UpdateIngestBudget updates the v1 ingest budget with the ID of budget.
+func (s *Client) UpdateIngestBudget(budget IngestBudget) (*IngestBudget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	path, err := formatPath("ingestBudgets/%s", budget.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeIngestBudget, budget.ID, nil, budget); err != nil {
+		return nil, err
+	}
+	var b = new(IngestBudget)
+	if _, err := s.do("PUT", path, budget, b); err != nil {
+		return nil, budgetBadRequest(errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound), budget.Name)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeIngestBudget, b.ID, nil, b)
+	return b, nil
+}
+
+// DeleteIngestBudget deletes the v1 ingest budget with the specified ID. Its collectors are
+// unassigned.
+func (s *Client) DeleteIngestBudget(id string) error {
+	return s.deleteIngestBudget("ingestBudgets/%s", id)
+}
+
+// ResetIngestBudgetUsage resets the usage of the v1 ingest budget with the specified ID to
+// zero, e.g. to resume collection after the capacity was reached.
+func (s *Client) ResetIngestBudgetUsage(id string) error {
+	return s.resetIngestBudgetUsage("ingestBudgets/%s/usage/reset", id)
+}
+
+// ListIngestBudgetCollectors returns the collectors assigned to the v1 ingest budget with the
+// specified ID, following pagination transparently.
+func (s *Client) ListIngestBudgetCollectors(id string) ([]IngestBudgetCollector, error) {
+	path, err := formatPath("ingestBudgets/%s/collectors", id)
+	if err != nil {
+		return nil, err
+	}
+	collectors := []IngestBudgetCollector{}
+	err = s.listAllPages(path, nil, func(data json.RawMessage) error {
+		var page []IngestBudgetCollector
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		collectors = append(collectors, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrIngestBudgetNotFound)
+	}
+	return collectors, nil
+}
+
+// AssignCollectorToIngestBudget assigns the collector with the specified ID to the v1 ingest
+// budget with the ID budgetID. A collector can only be assigned to one budget.
+func (s *Client) AssignCollectorToIngestBudget(budgetID string, collectorID int) error {
+	return s.setIngestBudgetCollector("PUT", budgetID, collectorID)
+}
+
+// RemoveCollectorFromIngestBudget unassigns the collector with the specified ID from the v1
+// ingest budget with the ID budgetID.
+func (s *Client) RemoveCollectorFromIngestBudget(budgetID string, collectorID int) error {
+	return s.setIngestBudgetCollector("DELETE", budgetID, collectorID)
+}
+
+// setIngestBudgetCollector assigns (PUT) or unassigns (DELETE) a collector to a v1 budget.
+func (s *Client) setIngestBudgetCollector(method, budgetID string, collectorID int) error {
+	path, err := formatPath("ingestBudgets/%s/collectors/%d", budgetID, collectorID)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeIngestBudget, budgetID, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do(method, path, nil, nil); err != nil {
+		if _, ok := badRequest(err); ok {
+			return err
+		}
+		return errorForStatus(err, http.StatusNotFound, fmt.Errorf("Ingest budget `%s` or collector `%d` not found", budgetID, collectorID))
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeIngestBudget, budgetID, nil, nil)
+	return nil
+}
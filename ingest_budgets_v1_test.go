@@ -0,0 +1,109 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultIngestBudget = IngestBudget{
+	ID:            "0000000000000E01",
+	Name:          "payments",
+	FieldValue:    "payments",
+	CapacityBytes: 10 << 30,
+	TimeZone:      "Etc/UTC",
+	ResetTime:     "00:00",
+	Action:        BudgetActionKeepCollecting,
+}
+
+func TestIngestBudgetCRUD(t *testing.T) {
+	assigned := map[string]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budgetPath := "/ingestBudgets/" + defaultIngestBudget.ID
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/ingestBudgets":
+			body, _ := io.ReadAll(r.Body)
+			b := new(IngestBudget)
+			json.Unmarshal(body, &b)
+			b.ID = defaultIngestBudget.ID
+			js, _ := json.Marshal(b)
+			w.Write(js)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/ingestBudgets":
+			js, _ := json.Marshal(map[string]interface{}{"data": []IngestBudget{defaultIngestBudget}})
+			w.Write(js)
+		case (r.Method == "GET" || r.Method == "PUT") && r.URL.EscapedPath() == budgetPath:
+			js, _ := json.Marshal(defaultIngestBudget)
+			w.Write(js)
+		case r.Method == "POST" && r.URL.EscapedPath() == budgetPath+"/usage/reset":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.EscapedPath() == budgetPath+"/collectors":
+			js, _ := json.Marshal(map[string]interface{}{"data": []IngestBudgetCollector{{ID: "101", Name: "payments-api"}}})
+			w.Write(js)
+		case r.Method == "PUT" && r.URL.EscapedPath() == budgetPath+"/collectors/101":
+			assigned["101"] = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == budgetPath+"/collectors/101":
+			delete(assigned, "101")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == budgetPath:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	budget := defaultIngestBudget
+	budget.ID = ""
+	created, err := c.CreateIngestBudget(budget)
+	if err != nil || created.ID != defaultIngestBudget.ID {
+		t.Errorf("CreateIngestBudget() returned %+v and %v", created, err)
+		return
+	}
+	if budgets, err := c.ListIngestBudgets(); err != nil || len(budgets) != 1 {
+		t.Errorf("ListIngestBudgets() returned %+v and %v", budgets, err)
+	}
+	if _, err := c.GetIngestBudget(created.ID); err != nil {
+		t.Errorf("GetIngestBudget() returned an error: %s", err)
+	}
+	if _, err := c.UpdateIngestBudget(*created); err != nil {
+		t.Errorf("UpdateIngestBudget() returned an error: %s", err)
+	}
+
+	if err := c.AssignCollectorToIngestBudget(created.ID, 101); err != nil || !assigned["101"] {
+		t.Errorf("AssignCollectorToIngestBudget() returned %v, assigned %v", err, assigned)
+	}
+	collectors, err := c.ListIngestBudgetCollectors(created.ID)
+	if err != nil || len(collectors) != 1 || collectors[0].Name != "payments-api" {
+		t.Errorf("ListIngestBudgetCollectors() returned %+v and %v", collectors, err)
+	}
+	if err := c.RemoveCollectorFromIngestBudget(created.ID, 101); err != nil || assigned["101"] {
+		t.Errorf("RemoveCollectorFromIngestBudget() returned %v, assigned %v", err, assigned)
+	}
+	if err := c.AssignCollectorToIngestBudget(created.ID, 102); err == nil {
+		t.Errorf("AssignCollectorToIngestBudget() expected an error for an unknown collector")
+	}
+
+	if err := c.ResetIngestBudgetUsage(created.ID); err != nil {
+		t.Errorf("ResetIngestBudgetUsage() returned an error: %s", err)
+	}
+	if err := c.DeleteIngestBudget(created.ID); err != nil {
+		t.Errorf("DeleteIngestBudget() returned an error: %s", err)
+	}
+}
+
+func TestIngestBudgetValidate(t *testing.T) {
+	b := defaultIngestBudget
+	b.FieldValue = ""
+	if verr, ok := b.Validate().(*ValidationError); !ok || verr.Field != "fieldValue" {
+		t.Errorf("Validate() expected a fieldValue error, got %v", b.Validate())
+	}
+}
@@ -0,0 +1,184 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Collector types, as returned in Collector.CollectorType.
+const (
+	CollectorTypeHosted    = "Hosted"
+	CollectorTypeInstalled = "Installable"
+)
+
+// Source sync modes of installed collectors, for InstalledCollector.SourceSyncMode.
+const (
+	// SourceSyncModeUI manages the collector's sources with the API and the Sumo Logic UI.
+	SourceSyncModeUI = "UI"
+	// SourceSyncModeJSON manages the collector's sources with a local JSON file, such as one
+	// generated by GenerateLocalSourcesJSON.
+	SourceSyncModeJSON = "Json"
+)
+
+// InstalledCollectorRequest is a necessary wrapper for installed collector API calls.
+type InstalledCollectorRequest struct {
+	Collector InstalledCollector `json:"collector"`
+}
+
+// InstalledCollector is a collector installed as an agent on a server. Installed collectors
+// register themselves when the agent is installed, so they can't be created with the API.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// collector, so an omitted field takes its server default; start from the collector
+// returned by a Get to keep its current settings. To explicitly send a zero value (e.g. to
+// make a collector no longer ephemeral), list the field's JSON name in ForceSendFields.
+type InstalledCollector struct {
+	ID               int              `json:"id,omitempty"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description,omitempty"`
+	Category         string           `json:"category,omitempty"`
+	TimeZone         string           `json:"timezone,omitempty"`
+	Links            []CollectorLinks `json:"links,omitempty"`
+	CollectorType    string           `json:"collectorType,omitempty"`
+	CollectorVersion string           `json:"collectorVersion,omitempty"`
+	LastSeenAlive    int64            `json:"lastSeenAlive,omitempty"`
+	Alive            bool             `json:"alive,omitempty"`
+	// Ephemeral collectors are deleted automatically after being offline for 12 hours.
+	Ephemeral bool `json:"ephemeral,omitempty"`
+	// TargetCPU is the CPU usage, in percent, the collector tries to stay under.
+	TargetCPU int `json:"targetCpu,omitempty"`
+	// SourceSyncMode is SourceSyncModeUI or SourceSyncModeJSON.
+	SourceSyncMode string `json:"sourceSyncMode,omitempty"`
+	// HostName is the host name reported by the collector, which sources default to.
+	HostName string `json:"hostName,omitempty"`
+	// CutoffTimestamp, in milliseconds since the epoch, makes new sources skip older data.
+	CutoffTimestamp int64  `json:"cutoffTimestamp,omitempty"`
+	OSName          string `json:"osName,omitempty"`
+	OSVersion       string `json:"osVersion,omitempty"`
+	OSArch          string `json:"osArch,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (c InstalledCollector) MarshalJSON() ([]byte, error) {
+	type installedCollector InstalledCollector
+	return marshalForceSend(installedCollector(c), c.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (c *InstalledCollector) UnmarshalJSON(data []byte) error {
+	type installedCollector InstalledCollector
+	return unmarshalLenient(data, (*installedCollector)(c))
+}
+
+// GetID returns the collector's ID.
+func (c InstalledCollector) GetID() string { return intResourceID(c.ID) }
+
+// GetName returns the collector's name.
+func (c InstalledCollector) GetName() string { return c.Name }
+
+// ResourceType returns ResourceTypeCollector.
+func (c InstalledCollector) ResourceType() string { return ResourceTypeCollector }
+
+// Endpoint returns the collector's API path.
+func (c InstalledCollector) Endpoint() string { return resourceEndpoint("collectors", c.GetID()) }
+
+// GetInstalledCollector gets the installed collector with the specified ID.
+// An error is returned if the collector is of another type, such as a hosted collector.
+func (s *Client) GetInstalledCollector(id int) (*InstalledCollector, string, error) {
+	path, err := formatPath("collectors/%d", id)
+	if err != nil {
+		return nil, "", err
+	}
+	var cr = new(InstalledCollectorRequest)
+	resp, err := s.do("GET", path, nil, cr)
+	if err != nil {
+		return nil, "", errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+	}
+	if cr.Collector.CollectorType != CollectorTypeInstalled {
+		return nil, "", fmt.Errorf("Collector `%d` is not an installed collector, but of type `%s`", id, cr.Collector.CollectorType)
+	}
+	return &cr.Collector, resp.Header.Get("ETag"), nil
+}
+
+// ListInstalledCollectors returns all installed collectors, following offset pagination
+// transparently.
+func (s *Client) ListInstalledCollectors() ([]InstalledCollector, error) {
+	collectors := []InstalledCollector{}
+	err := s.listCollectorPages(CollectorFilterInstalled, func(data json.RawMessage) (int, error) {
+		var page []InstalledCollector
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		collectors = append(collectors, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collectors, nil
+}
+
+// UpdateInstalledCollector updates an existing installed collector.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateInstalledCollector(collector InstalledCollector, etag string) (*InstalledCollector, error) {
+	etag, err := s.resolveETag(etag, "collectors/%d", collector.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateNameAndCategory(collector.Name, collector.Category); err != nil {
+		return nil, err
+	}
+	if err := ValidateTimeZone(collector.TimeZone); err != nil {
+		return nil, err
+	}
+	if err := validateFields(collector.Fields); err != nil {
+		return nil, err
+	}
+	if collector.TargetCPU < 0 || collector.TargetCPU > 100 {
+		return nil, &ValidationError{Field: "targetCpu", Message: fmt.Sprintf("must be a percentage between 0 and 100, got %d", collector.TargetCPU)}
+	}
+	if m := collector.SourceSyncMode; m != "" && m != SourceSyncModeUI && m != SourceSyncModeJSON {
+		return nil, &ValidationError{Field: "sourceSyncMode", Message: fmt.Sprintf("must be `%s` or `%s`, got `%s`", SourceSyncModeUI, SourceSyncModeJSON, m)}
+	}
+
+	path, err := formatPath("collectors/%d", collector.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeCollector, collector.ID, nil, collector); err != nil {
+		return nil, err
+	}
+	var cr = new(InstalledCollectorRequest)
+	if _, err := s.doIfMatch("PUT", path, etag, InstalledCollectorRequest{Collector: collector}, cr); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name))
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeCollector, cr.Collector.ID, nil, cr.Collector)
+	return &cr.Collector, nil
+}
+
+// DeleteInstalledCollector deletes the installed collector with the specified ID. The agent
+// stops collecting, but isn't uninstalled from its server.
+func (s *Client) DeleteInstalledCollector(id int) error {
+	return s.deleteCollector(context.Background(), id, "")
+}
+
+// DeleteInstalledCollectorWithETag deletes the installed collector with the specified ID only
+// if it hasn't changed since the Get that returned etag. ErrPreconditionFailed is returned if
+// it has.
+func (s *Client) DeleteInstalledCollectorWithETag(id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteCollector(context.Background(), id, etag)
+}
@@ -0,0 +1,178 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultInstalledCollector = InstalledCollector{
+	ID:             1234567891,
+	Name:           "web-01",
+	CollectorType:  CollectorTypeInstalled,
+	Ephemeral:      true,
+	TargetCPU:      20,
+	SourceSyncMode: SourceSyncModeJSON,
+	HostName:       "web-01.example.com",
+}
+
+func TestGetInstalledCollectorOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedURL := fmt.Sprintf("/collectors/%d", defaultInstalledCollector.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"collector":{"id":1234567891,"name":"web-01","collectorType":"Installable","ephemeral":true,"targetCpu":20,"sourceSyncMode":"Json","hostName":"web-01.example.com","cutoffTimestamp":1500000000000}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, etag, err := c.GetInstalledCollector(defaultInstalledCollector.ID)
+	if err != nil {
+		t.Errorf("GetInstalledCollector() returned an error: %s", err)
+		return
+	}
+	if etag != `"1"` {
+		t.Errorf("GetInstalledCollector() expected ETag ‘\"1\"’, got ‘%s’", etag)
+	}
+	if !collector.Ephemeral || collector.TargetCPU != 20 || collector.SourceSyncMode != SourceSyncModeJSON ||
+		collector.HostName != "web-01.example.com" || collector.CutoffTimestamp != 1500000000000 {
+		t.Errorf("GetInstalledCollector() returned unexpected installed collector fields: %+v", collector)
+	}
+}
+
+func TestGetInstalledCollectorHosted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, _, err := c.GetInstalledCollector(defaultCollector.ID); err == nil {
+		t.Errorf("GetInstalledCollector() expected an error for a hosted collector")
+	}
+}
+
+func TestListInstalledCollectors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") != CollectorFilterInstalled {
+			t.Errorf("Expected filter ‘%s’, got ‘%s’", CollectorFilterInstalled, r.URL.Query().Get("filter"))
+		}
+		body, _ := json.Marshal(map[string][]InstalledCollector{"collectors": {defaultInstalledCollector}})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collectors, err := c.ListInstalledCollectors()
+	if err != nil {
+		t.Errorf("ListInstalledCollectors() returned an error: %s", err)
+		return
+	}
+	if len(collectors) != 1 || collectors[0].HostName != defaultInstalledCollector.HostName {
+		t.Errorf("ListInstalledCollectors() returned unexpected collectors: %+v", collectors)
+	}
+}
+
+func TestUpdateInstalledCollectorOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.Header.Get("If-Match") != `"1"` {
+			t.Errorf("Expected If-Match ‘\"1\"’, got ‘%s’", r.Header.Get("If-Match"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Collector map[string]interface{} `json:"collector"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal InstalledCollector, got `%s`", body)
+		}
+		if request.Collector["ephemeral"] != false {
+			t.Errorf("Expected ephemeral to be sent as false, got %v", request.Collector["ephemeral"])
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector := defaultInstalledCollector
+	collector.Ephemeral = false
+	collector.ForceSendFields = []string{"ephemeral"}
+	updated, err := c.UpdateInstalledCollector(collector, `"1"`)
+	if err != nil {
+		t.Errorf("UpdateInstalledCollector() returned an error: %s", err)
+		return
+	}
+	if updated.Ephemeral {
+		t.Errorf("UpdateInstalledCollector() expected the collector to no longer be ephemeral")
+	}
+}
+
+func TestUpdateInstalledCollectorValidation(t *testing.T) {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+
+	invalid := map[string]InstalledCollector{
+		"targetCpu":      {ID: 1, Name: "web-01", TargetCPU: 101},
+		"sourceSyncMode": {ID: 1, Name: "web-01", SourceSyncMode: "Local"},
+	}
+	for field, collector := range invalid {
+		_, err := c.UpdateInstalledCollector(collector, `"1"`)
+		if e, ok := err.(*ValidationError); !ok || e.Field != field {
+			t.Errorf("UpdateInstalledCollector() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+	if _, err := c.UpdateInstalledCollector(defaultInstalledCollector, ""); err != ErrMissingETag {
+		t.Errorf("UpdateInstalledCollector() expected ErrMissingETag, got %v", err)
+	}
+}
+
+func TestDeleteInstalledCollectorDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteInstalledCollector(defaultInstalledCollector.ID); err != ErrCollectorNotFound {
+		t.Errorf("DeleteInstalledCollector() expected ErrCollectorNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// ErrJobTimeout is returned when an asynchronous job, such as a content export or a search
+// job, doesn't complete within the maximum wait of the job polling policy.
+var ErrJobTimeout = errors.New("Timed out waiting for job to complete")
+
+// defaultJobPolling polls asynchronous jobs quickly at first, backs off with jitter so that
+// long-running jobs don't hammer the API, and gives up after 30 minutes.
+var defaultJobPolling = backoff.Policy{
+	Initial:        500 * time.Millisecond,
+	Max:            10 * time.Second,
+	Multiplier:     1.5,
+	Jitter:         0.2,
+	MaxElapsedTime: 30 * time.Minute,
+}
+
+// WithJobPolling sets how the client polls asynchronous jobs, such as content exports and
+// imports and search jobs, for completion. The delay after each poll follows the policy
+// unless the API suggests one with a Retry-After header, and waiting fails with
+// ErrJobTimeout once the policy's MaxElapsedTime has passed. MaxAttempts is ignored.
+func WithJobPolling(policy backoff.Policy) ClientOption {
+	return func(s *Client) error {
+		s.jobPolling = &policy
+		return nil
+	}
+}
+
+// pollHinter is implemented by job statuses that record the API's suggested poll delay.
+type pollHinter interface {
+	setPollHint(time.Duration)
+}
+
+// recordPollHint passes the Retry-After delay of a job status response to out.
+func recordPollHint(out interface{}, header http.Header) {
+	if h, ok := out.(pollHinter); ok {
+		h.setPollHint(retryAfter(header))
+	}
+}
+
+// retryAfter parses a Retry-After header in seconds or as an HTTP date. It returns 0 if
+// the header is missing or invalid.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// pollJob calls check until it reports the job done, returns an error, the job polling
+// policy's maximum wait is exceeded or ctx is done. check returns the API's suggested delay
+// before the next poll, or 0 to use the policy.
+func (s *Client) pollJob(ctx context.Context, check func() (bool, time.Duration, error)) error {
+	return pollJobWith(ctx, s.jobPollingPolicy(), check)
+}
+
+// jobPollingPolicy returns the client's job polling policy.
+func (s *Client) jobPollingPolicy() backoff.Policy {
+	if s.jobPolling != nil {
+		return *s.jobPolling
+	}
+	return defaultJobPolling
+}
+
+// pollJobWith is like pollJob, but polls with the given policy.
+func pollJobWith(ctx context.Context, policy backoff.Policy, check func() (bool, time.Duration, error)) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		done, hint, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		delay := hint
+		if delay <= 0 {
+			delay = policy.Delay(attempt)
+		}
+		if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+			return ErrJobTimeout
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
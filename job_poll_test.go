@@ -0,0 +1,90 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestPollJobTimeout(t *testing.T) {
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithJobPolling(backoff.Policy{Initial: time.Millisecond, MaxElapsedTime: 20 * time.Millisecond}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	polls := 0
+	err = c.pollJob(context.Background(), func() (bool, time.Duration, error) {
+		polls++
+		return false, 0, nil
+	})
+	if err != ErrJobTimeout {
+		t.Errorf("pollJob() expected ErrJobTimeout, got %v", err)
+	}
+	if polls < 2 {
+		t.Errorf("pollJob() expected to poll more than once, got %d", polls)
+	}
+}
+
+func TestPollJobHonorsPollHint(t *testing.T) {
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithJobPolling(backoff.Policy{Initial: time.Hour, MaxElapsedTime: 2 * time.Hour}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	polls := 0
+	err = c.pollJob(context.Background(), func() (bool, time.Duration, error) {
+		polls++
+		return polls > 2, time.Millisecond, nil
+	})
+	if err != nil || polls != 3 {
+		t.Errorf("pollJob() expected to use the poll hint and finish after 3 polls, got %d polls and %v", polls, err)
+	}
+}
+
+func TestPollJobContextDone(t *testing.T) {
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = c.pollJob(ctx, func() (bool, time.Duration, error) {
+		cancel()
+		return false, 0, nil
+	})
+	if err != context.Canceled {
+		t.Errorf("pollJob() expected context.Canceled, got %v", err)
+	}
+}
+
+func TestContentJobRetryAfter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.Write([]byte(`{"status":"InProgress"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var status = new(ContentJobStatus)
+	if err := c.contentRequest("GET", "../v2/content/1/export/job/status", nil, nil, status); err != nil {
+		t.Errorf("contentRequest() returned an error: %s", err)
+		return
+	}
+	if status.PollHint != 3*time.Second {
+		t.Errorf("contentRequest() expected a poll hint of 3s, got %s", status.PollHint)
+	}
+}
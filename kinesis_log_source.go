@@ -0,0 +1,293 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// KinesisLogSourceRequest is a necessary wrapper for source API calls.
+type KinesisLogSourceRequest struct {
+	Source KinesisLogSource `json:"source"`
+}
+
+// KinesisLogSource is a source on a hosted collector that receives logs delivered by an
+// Amazon Kinesis Data Firehose delivery stream to its Url.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type KinesisLogSource struct {
+	ID                         int          `json:"id,omitempty"`
+	Name                       string       `json:"name"`
+	CollectorID                int          `json:"CollectorId,omitempty"`
+	Description                string       `json:"description,omitempty"`
+	Category                   string       `json:"category,omitempty"`
+	TimeZone                   string       `json:"timezone,omitempty"`
+	SourceType                 string       `json:"sourceType,omitempty"`
+	ContentType                string       `json:"contentType,omitempty"`
+	MessagePerRequest          bool         `json:"messagePerRequest,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	// Url is the endpoint generated by Sumo Logic for the delivery stream. It can't be
+	// changed and isn't sent on update.
+	Url string `json:"url,omitempty"`
+	// ThirdPartyRef configures the S3 bucket Firehose backs failed deliveries up to, if any.
+	// The resources' ServiceType, path type and authentication type are set by
+	// CreateKinesisLogSource if empty.
+	ThirdPartyRef KinesisLogThirdPartyRef `json:"thirdPartyRef,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// KinesisLogThirdPartyRef contains the AWS configuration of a KinesisLogSource.
+type KinesisLogThirdPartyRef struct {
+	Resources []KinesisLogResource `json:"resources,omitempty"`
+}
+
+// KinesisLogResource contains the AWS configuration of a KinesisLogSource, including
+// authentication.
+type KinesisLogResource struct {
+	ServiceType    string                   `json:"serviceType"`
+	Path           KinesisLogPath           `json:"path"`
+	Authentication KinesisLogAuthentication `json:"authentication"`
+}
+
+// KinesisLogPath is the S3 bucket failed deliveries are backed up to. Empty BucketName means
+// there's no backup bucket.
+type KinesisLogPath struct {
+	Type           string `json:"type"`
+	BucketName     string `json:"bucketName,omitempty"`
+	PathExpression string `json:"pathExpression,omitempty"`
+}
+
+// KinesisLogAuthentication is how Sumo Logic reads the backup bucket of a KinesisLogSource.
+type KinesisLogAuthentication struct {
+	Type    string `json:"type"`
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+const (
+	// kinesisLogContentType is the contentType and serviceType of Kinesis log sources.
+	kinesisLogContentType = "KinesisLog"
+	// kinesisLogPathType is the path type of Kinesis log sources.
+	kinesisLogPathType = "KinesisLogPath"
+)
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s KinesisLogSource) MarshalJSON() ([]byte, error) {
+	type kinesisLogSource KinesisLogSource
+	return marshalForceSend(kinesisLogSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *KinesisLogSource) UnmarshalJSON(data []byte) error {
+	type kinesisLogSource KinesisLogSource
+	return unmarshalLenient(data, (*kinesisLogSource)(s))
+}
+
+// Validate checks the source's resources and the fields validated for all sources, without
+// calling the API. A *ValidationError naming the offending field is returned otherwise.
+func (s KinesisLogSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	for _, r := range s.ThirdPartyRef.Resources {
+		if r.ServiceType != "" && r.ServiceType != kinesisLogContentType {
+			return &ValidationError{Field: "thirdPartyRef.resources.serviceType", Message: fmt.Sprintf("must be `%s`, got `%s`", kinesisLogContentType, r.ServiceType)}
+		}
+		if r.Path.BucketName == "" && r.Authentication.RoleARN != "" {
+			return &ValidationError{Field: "thirdPartyRef.resources.path.bucketName", Message: "must be set when authentication.roleARN is set"}
+		}
+		if r.Authentication.RoleARN != "" {
+			if err := ValidateAWSRoleARN(r.Authentication.RoleARN); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and the URL) are ignored.
+func (s KinesisLogSource) Equivalent(other KinesisLogSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s KinesisLogSource) userManaged() KinesisLogSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Url = ""
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.ThirdPartyRef.Resources) == 0 {
+		s.ThirdPartyRef.Resources = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// withDefaults returns the source with the sourceType, contentType and resource of Kinesis
+// log sources set where they're empty.
+func (s KinesisLogSource) withDefaults() KinesisLogSource {
+	if s.SourceType == "" {
+		s.SourceType = "HTTP"
+	}
+	if s.ContentType == "" {
+		s.ContentType = kinesisLogContentType
+	}
+	resources := s.ThirdPartyRef.Resources
+	if len(resources) == 0 {
+		resources = []KinesisLogResource{{}}
+	}
+	s.ThirdPartyRef.Resources = make([]KinesisLogResource, len(resources))
+	for i, r := range resources {
+		if r.ServiceType == "" {
+			r.ServiceType = kinesisLogContentType
+		}
+		if r.Path.Type == "" {
+			r.Path.Type = kinesisLogPathType
+		}
+		if r.Authentication.Type == "" {
+			r.Authentication.Type = "NoAuthentication"
+			if r.Authentication.RoleARN != "" {
+				r.Authentication.Type = "AWSRoleBasedAuthentication"
+			}
+		}
+		s.ThirdPartyRef.Resources[i] = r
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s KinesisLogSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s KinesisLogSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s KinesisLogSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s KinesisLogSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+func (source KinesisLogSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateKinesisLogSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// GetKinesisLogSource gets the source with the specified ID.
+// If the client was created with WithMaskedSourceURLs, the source's URL is masked.
+func (s *Client) GetKinesisLogSource(collectorID int, id int) (*KinesisLogSource, string, error) {
+	var r = new(KinesisLogSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	s.maskKinesisLogSource(&r.Source)
+	return &r.Source, etag, nil
+}
+
+// GetKinesisLogSourceURL gets the unmasked URL of the source with the specified ID,
+// regardless of WithMaskedSourceURLs, e.g. to configure the delivery stream's destination.
+func (s *Client) GetKinesisLogSourceURL(collectorID int, id int) (string, error) {
+	var r = new(KinesisLogSourceRequest)
+	if _, err := s.getSource(collectorID, id, r); err != nil {
+		return "", err
+	}
+	return r.Source.Url, nil
+}
+
+// CreateKinesisLogSource creates a new KinesisLogSource. Empty SourceType, ContentType and
+// resource types are set to those of Kinesis log sources. The returned source holds the
+// generated URL, masked if the client was created with WithMaskedSourceURLs.
+// ErrAwsAuthenticationError is returned if Sumo Logic can't assume the backup bucket's role.
+func (s *Client) CreateKinesisLogSource(collectorID int, source KinesisLogSource) (*KinesisLogSource, error) {
+	source = source.withDefaults()
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Url = ""
+
+	var r = new(KinesisLogSourceRequest)
+	if err := s.createSource(collectorID, source.Name, KinesisLogSourceRequest{Source: source}, r); err != nil {
+		return nil, awsAuthenticationError(err)
+	}
+
+	s.maskKinesisLogSource(&r.Source)
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateKinesisLogSource updates an existing Kinesis log source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateKinesisLogSource(collectorID int, source KinesisLogSource, etag string) (*KinesisLogSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	source.Url = ""
+
+	var r = new(KinesisLogSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, KinesisLogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.maskKinesisLogSource(&r.Source)
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteKinesisLogSource deletes the source with the specified ID.
+func (s *Client) DeleteKinesisLogSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteKinesisLogSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteKinesisLogSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteKinesisLogSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteKinesisLogSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteKinesisLogSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteKinesisLogSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
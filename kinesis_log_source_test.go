@@ -0,0 +1,78 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const kinesisLogSourceURL = "https://endpoint1.collection.sumologic.com/receiver/v1/kinesis/log/ZaVnC4dhaV2ZFQ"
+
+func TestCreateKinesisLogSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request KinesisLogSourceRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal KinesisLogSource, got `%s`", body)
+		}
+		resources := request.Source.ThirdPartyRef.Resources
+		if request.Source.SourceType != "HTTP" || request.Source.ContentType != "KinesisLog" || len(resources) != 1 {
+			t.Errorf("Expected an HTTP KinesisLog source with one resource, got %+v", request.Source)
+			return
+		}
+		expected := KinesisLogResource{
+			ServiceType:    "KinesisLog",
+			Path:           KinesisLogPath{Type: "KinesisLogPath"},
+			Authentication: KinesisLogAuthentication{Type: "NoAuthentication"},
+		}
+		if resources[0] != expected {
+			t.Errorf("Expected resource %+v, got %+v", expected, resources[0])
+		}
+		request.Source.ID = 2
+		request.Source.Url = kinesisLogSourceURL
+		body, _ = json.Marshal(request)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+	source, err := c.CreateKinesisLogSource(1, KinesisLogSource{Name: "firehose", Category: "aws/firehose"})
+	if err != nil {
+		t.Errorf("CreateKinesisLogSource() returned an error: %s", err)
+		return
+	}
+	if source.ID != 2 || source.Url != MaskIngestionURL(kinesisLogSourceURL) {
+		t.Errorf("CreateKinesisLogSource() expected the masked URL, got %+v", source)
+	}
+}
+
+func TestKinesisLogSourceValidate(t *testing.T) {
+	backup := func(bucket, roleARN string) KinesisLogThirdPartyRef {
+		return KinesisLogThirdPartyRef{Resources: []KinesisLogResource{{
+			Path:           KinesisLogPath{BucketName: bucket},
+			Authentication: KinesisLogAuthentication{RoleARN: roleARN},
+		}}}
+	}
+	valid := KinesisLogSource{Name: "firehose", ThirdPartyRef: backup("failed-deliveries", "arn:aws:iam::123456789012:role/SumoLogic")}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() returned an error: %s", err)
+	}
+	if r := valid.withDefaults().ThirdPartyRef.Resources[0]; r.Authentication.Type != "AWSRoleBasedAuthentication" {
+		t.Errorf("Expected role based authentication for a backup bucket, got %+v", r)
+	}
+
+	cases := map[string]KinesisLogSource{
+		"thirdPartyRef.resources.path.bucketName": {Name: "firehose", ThirdPartyRef: backup("", "arn:aws:iam::123456789012:role/SumoLogic")},
+		"authentication.roleARN":                  {Name: "firehose", ThirdPartyRef: backup("failed-deliveries", "role")},
+		"manualPrefixRegexp":                      {Name: "firehose", ManualPrefixRegexp: "("},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+}
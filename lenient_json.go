@@ -0,0 +1,45 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// lenientNumberFields are the numeric fields that some API versions encode as strings, e.g.
+// `"id": "101"`.
+var lenientNumberFields = []string{"id", "CollectorId", "scanInterval"}
+
+// unmarshalLenient decodes data into v like json.Unmarshal, but also accepts the
+// lenientNumberFields of the object as strings holding an integer. It's used by the
+// UnmarshalJSON methods of collectors and sources, with v the address of an alias type so
+// that it doesn't recurse.
+func unmarshalLenient(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if _, ok := err.(*json.UnmarshalTypeError); !ok {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return err
+	}
+	normalized := false
+	for _, name := range lenientNumberFields {
+		var s string
+		if raw, ok := fields[name]; !ok || json.Unmarshal(raw, &s) != nil {
+			continue
+		}
+		if _, parseErr := strconv.ParseInt(s, 10, 64); parseErr == nil {
+			fields[name] = json.RawMessage(s)
+			normalized = true
+		}
+	}
+	if !normalized {
+		return err
+	}
+	data, marshalErr := json.Marshal(fields)
+	if marshalErr != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,68 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// The same definitions as returned by current API versions and by older ones, which encode
+// some numeric fields as strings.
+var lenientNumberFixtures = map[string][]string{
+	"collector": {
+		`{"collector":{"id":101,"name":"collector","collectorType":"Hosted"}}`,
+		`{"collector":{"id":"101","name":"collector","collectorType":"Hosted"}}`,
+	},
+	"source": {
+		`{"source":{"id":102,"name":"cloudtrail","CollectorId":101,"sourceType":"Polling","scanInterval":300000}}`,
+		`{"source":{"id":"102","name":"cloudtrail","CollectorId":"101","sourceType":"Polling","scanInterval":"300000"}}`,
+	},
+}
+
+func TestLenientNumberDecoding(t *testing.T) {
+	for _, fixture := range lenientNumberFixtures["collector"] {
+		var r CollectorRequest
+		if err := json.Unmarshal([]byte(fixture), &r); err != nil {
+			t.Errorf("Unable to unmarshal `%s`: %s", fixture, err)
+			continue
+		}
+		if r.Collector.ID != 101 || r.Collector.Name != "collector" {
+			t.Errorf("Unexpected collector decoded from `%s`: %+v", fixture, r.Collector)
+		}
+	}
+
+	for _, fixture := range lenientNumberFixtures["source"] {
+		var r AWSLogSourceRequest
+		if err := json.Unmarshal([]byte(fixture), &r); err != nil {
+			t.Errorf("Unable to unmarshal `%s`: %s", fixture, err)
+			continue
+		}
+		if r.Source.ID != 102 || r.Source.CollectorID != 101 || r.Source.ScanInterval != 300000 {
+			t.Errorf("Unexpected source decoded from `%s`: %+v", fixture, r.Source)
+		}
+
+		var sources struct {
+			Source Source `json:"source"`
+		}
+		if err := json.Unmarshal([]byte(fixture), &sources); err != nil {
+			t.Errorf("Unable to unmarshal `%s` as a Source: %s", fixture, err)
+			continue
+		}
+		var decoded AWSLogSource
+		if err := sources.Source.Decode(&decoded); err != nil || sources.Source.ID != 102 || decoded.ScanInterval != 300000 {
+			t.Errorf("Unexpected Source decoded from `%s`: %+v, %v", fixture, decoded, err)
+		}
+	}
+}
+
+func TestLenientNumberDecodingErrors(t *testing.T) {
+	invalid := []string{
+		`{"id":"one hundred","name":"collector"}`,
+		`{"id":101,"name":7}`,
+	}
+	for _, data := range invalid {
+		var c Collector
+		if err := json.Unmarshal([]byte(data), &c); err == nil {
+			t.Errorf("Expected an error unmarshalling `%s`, got %+v", data, c)
+		}
+	}
+}
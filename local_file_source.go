@@ -0,0 +1,201 @@
+package sumologic
+
+import (
+	"context"
+	"reflect"
+)
+
+// LocalFileSourceRequest is a necessary wrapper for source API calls.
+type LocalFileSourceRequest struct {
+	Source LocalFileSource `json:"source"`
+}
+
+// LocalFileSource is a source on an installed collector that reads log files on the
+// collector's host.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type LocalFileSource struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	CollectorID int    `json:"CollectorId,omitempty"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	HostName    string `json:"hostName,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+	SourceType  string `json:"sourceType,omitempty"`
+	// PathExpression is the path of the files to read, which may use wildcards, e.g.
+	// `/var/log/nginx/*.log`.
+	PathExpression string `json:"pathExpression"`
+	// Blacklist lists path expressions of files to skip, e.g. `/var/log/nginx/*.gz`.
+	Blacklist []string `json:"blacklist,omitempty"`
+	// Encoding is the character set of the files, e.g. `UTF-8` (the default) or `UTF-16LE`.
+	Encoding                   string       `json:"encoding,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	CutoffRelativeTime         string       `json:"cutoffRelativeTime,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	Alive                      bool         `json:"alive,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// localFileSourceType is the sourceType of local file sources.
+const localFileSourceType = "LocalFile"
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s LocalFileSource) MarshalJSON() ([]byte, error) {
+	type localFileSource LocalFileSource
+	return marshalForceSend(localFileSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *LocalFileSource) UnmarshalJSON(data []byte) error {
+	type localFileSource LocalFileSource
+	return unmarshalLenient(data, (*localFileSource)(s))
+}
+
+// Validate checks the source's path expressions and the fields validated for all sources,
+// without calling the API.
+func (s LocalFileSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	if s.PathExpression == "" {
+		return &ValidationError{Field: "pathExpression", Message: "must not be empty"}
+	}
+	for _, path := range s.Blacklist {
+		if path == "" {
+			return &ValidationError{Field: "blacklist", Message: "must not contain empty path expressions"}
+		}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and liveness) are ignored.
+func (s LocalFileSource) Equivalent(other LocalFileSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s LocalFileSource) userManaged() LocalFileSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Alive = false
+	if len(s.Blacklist) == 0 {
+		s.Blacklist = nil
+	}
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s LocalFileSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s LocalFileSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s LocalFileSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s LocalFileSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetLocalFileSource gets the source with the specified ID.
+func (s *Client) GetLocalFileSource(collectorID int, id int) (*LocalFileSource, string, error) {
+	var r = new(LocalFileSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	return &r.Source, etag, nil
+}
+
+// CreateLocalFileSource creates a new LocalFileSource on an installed collector. An empty
+// SourceType is set to `LocalFile`.
+func (s *Client) CreateLocalFileSource(collectorID int, source LocalFileSource) (*LocalFileSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = localFileSourceType
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(LocalFileSourceRequest)
+	if err := s.createSource(collectorID, source.Name, LocalFileSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateLocalFileSource updates an existing local file source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateLocalFileSource(collectorID int, source LocalFileSource, etag string) (*LocalFileSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(LocalFileSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, LocalFileSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteLocalFileSource deletes the source with the specified ID.
+func (s *Client) DeleteLocalFileSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteLocalFileSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteLocalFileSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteLocalFileSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteLocalFileSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteLocalFileSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteLocalFileSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
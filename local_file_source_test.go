@@ -0,0 +1,65 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateLocalFileSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/collectors/1/sources" {
+			t.Errorf("Expected request to ‘/collectors/1/sources’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request LocalFileSourceRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal LocalFileSource, got `%s`", body)
+		}
+		if request.Source.SourceType != "LocalFile" || request.Source.PathExpression != "/var/log/nginx/*.log" || len(request.Source.Blacklist) != 1 {
+			t.Errorf("Expected a LocalFile source with a path expression and blacklist, got %+v", request.Source)
+		}
+		request.Source.ID = 2
+		body, _ = json.Marshal(request)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source := LocalFileSource{
+		Name:           "nginx",
+		PathExpression: "/var/log/nginx/*.log",
+		Blacklist:      []string{"/var/log/nginx/*.gz"},
+		Encoding:       "UTF-8",
+	}
+	created, err := c.CreateLocalFileSource(1, source)
+	if err != nil {
+		t.Errorf("CreateLocalFileSource() returned an error: %s", err)
+		return
+	}
+	source.SourceType = "LocalFile"
+	if created.ID != 2 || !created.Equivalent(source) {
+		t.Errorf("CreateLocalFileSource() returned an unexpected source: %+v", created)
+	}
+}
+
+func TestLocalFileSourceValidate(t *testing.T) {
+	cases := map[string]LocalFileSource{
+		"pathExpression": {Name: "nginx"},
+		"blacklist":      {Name: "nginx", PathExpression: "/var/log/*.log", Blacklist: []string{""}},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.UpdateLocalFileSource(1, LocalFileSource{Name: "nginx", PathExpression: "/var/log/*.log"}, ""); err != ErrMissingETag {
+		t.Errorf("UpdateLocalFileSource() expected ErrMissingETag, got %v", err)
+	}
+}
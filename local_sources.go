@@ -0,0 +1,96 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LocalSourcesAPIVersion is the schema version written to generated local configuration files.
+const LocalSourcesAPIVersion = "v1"
+
+// LocalSourcesConfig is the JSON file schema read by installed collectors configured with
+// sourceSyncMode=JSON. It matches the output of DownloadSourcesJSON.
+type LocalSourcesConfig struct {
+	APIVersion string            `json:"api.version"`
+	Sources    []json.RawMessage `json:"sources"`
+}
+
+// localSourceServerFields are populated by Sumo Logic and must not appear in local configuration.
+var localSourceServerFields = []string{"id", "CollectorId", "url", "alive"}
+
+// localSourceTypes are the sourceTypes that installed collectors run. Other types, such as
+// `HTTP` or `Polling`, only run on hosted collectors.
+var localSourceTypes = map[string]bool{
+	localFileSourceType:        true,
+	remoteFileSourceType:       true,
+	syslogSourceType:           true,
+	windowsEventLogSourceType:  true,
+	"RemoteWindowsEventLog":    true,
+	"LocalWindowsPerformance":  true,
+	"RemoteWindowsPerformance": true,
+	"Script":                   true,
+	"DockerLog":                true,
+	"DockerStats":              true,
+	"SystemStats":              true,
+	"StreamingMetrics":         true,
+}
+
+// validator is implemented by sources that check their own fields, such as LocalFileSource.
+type validator interface {
+	Validate() error
+}
+
+// GenerateLocalSourcesJSON converts source structs (e.g. LocalFileSource or SyslogSource) to
+// the local sources.json schema used by installed collectors. Every source must have a name
+// and a sourceType that installed collectors run, names must be unique, and sources with a
+// Validate method must be valid. Server-managed fields such as IDs are stripped.
+func GenerateLocalSourcesJSON(sources ...interface{}) ([]byte, error) {
+	config := LocalSourcesConfig{
+		APIVersion: LocalSourcesAPIVersion,
+		Sources:    make([]json.RawMessage, 0, len(sources)),
+	}
+	names := make(map[string]bool, len(sources))
+
+	for i, source := range sources {
+		if v, ok := source.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, fmt.Errorf("Source %d is invalid: %w", i, err)
+			}
+		}
+		body, err := json.Marshal(source)
+		if err != nil {
+			return nil, fmt.Errorf("Source %d could not be serialized: %s", i, err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("Source %d is not a JSON object: %s", i, err)
+		}
+
+		name, _ := fields["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("Source %d is missing a name", i)
+		}
+		sourceType, _ := fields["sourceType"].(string)
+		if sourceType == "" {
+			return nil, fmt.Errorf("Source `%s` is missing a sourceType", name)
+		}
+		if !localSourceTypes[sourceType] {
+			return nil, fmt.Errorf("Source `%s` has sourceType `%s`, which only runs on hosted collectors", name, sourceType)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("Source name `%s` is used more than once", name)
+		}
+		names[name] = true
+
+		for _, field := range localSourceServerFields {
+			delete(fields, field)
+		}
+		body, err = json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		config.Sources = append(config.Sources, body)
+	}
+
+	return json.MarshalIndent(config, "", "  ")
+}
@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGenerateLocalSourcesJSONOK(t *testing.T) {
+	body, err := GenerateLocalSourcesJSON(
+		LocalFileSource{ID: 1234567890, Name: "nginx", SourceType: "LocalFile", PathExpression: "/var/log/nginx/*.log", Alive: true},
+		SyslogSource{Name: "syslog", SourceType: "Syslog", Protocol: "UDP", Port: 514},
+	)
+	if err != nil {
+		t.Errorf("GenerateLocalSourcesJSON() returned an error: %s", err)
+		return
+	}
+
+	var config struct {
+		APIVersion string                   `json:"api.version"`
+		Sources    []map[string]interface{} `json:"sources"`
+	}
+	if err := json.Unmarshal(body, &config); err != nil {
+		t.Errorf("Unable to unmarshal local sources, got `%s`", body)
+		return
+	}
+	if config.APIVersion != LocalSourcesAPIVersion {
+		t.Errorf("GenerateLocalSourcesJSON() expected api.version `%s`, got `%s`", LocalSourcesAPIVersion, config.APIVersion)
+	}
+	if len(config.Sources) != 2 {
+		t.Errorf("GenerateLocalSourcesJSON() expected 2 sources, got %d", len(config.Sources))
+		return
+	}
+	if _, ok := config.Sources[0]["id"]; ok {
+		t.Errorf("GenerateLocalSourcesJSON() did not strip the source ID")
+	}
+	if _, ok := config.Sources[0]["alive"]; ok {
+		t.Errorf("GenerateLocalSourcesJSON() did not strip the source's liveness")
+	}
+	if config.Sources[1]["protocol"] != "UDP" {
+		t.Errorf("GenerateLocalSourcesJSON() expected protocol `UDP`, got `%v`", config.Sources[1]["protocol"])
+	}
+}
+
+func TestGenerateLocalSourcesJSONInvalid(t *testing.T) {
+	cases := map[string][]interface{}{
+		"missing name":       {map[string]string{"sourceType": "LocalFile"}},
+		"missing sourceType": {map[string]string{"name": "file"}},
+		"duplicate name":     {map[string]string{"name": "file", "sourceType": "LocalFile"}, map[string]string{"name": "file", "sourceType": "Syslog"}},
+		"not an object":      {"file"},
+		"hosted-only HTTP":   {HTTPSource{Name: "http", SourceType: "HTTP"}},
+		"hosted-only AWS":    {AWSLogSource{Name: "cloudtrail", SourceType: "Polling", ContentType: "AwsCloudTrailBucket"}},
+		"invalid LocalFile":  {LocalFileSource{Name: "nginx", SourceType: "LocalFile"}},
+		"invalid Syslog":     {SyslogSource{Name: "syslog", SourceType: "Syslog", Port: 70000}},
+	}
+	for name, sources := range cases {
+		if _, err := GenerateLocalSourcesJSON(sources...); err == nil {
+			t.Errorf("GenerateLocalSourcesJSON() did not return an error for %s", name)
+		}
+	}
+
+	_, err := GenerateLocalSourcesJSON(LocalFileSource{Name: "nginx", SourceType: "LocalFile"})
+	if verr, ok := errors.Unwrap(err).(*ValidationError); !ok || verr.Field != "pathExpression" {
+		t.Errorf("GenerateLocalSourcesJSON() returned ‘%v’, expected the source's pathExpression ValidationError", err)
+	}
+}
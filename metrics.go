@@ -0,0 +1,99 @@
+package sumologic
+
+import (
+	"expvar"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// EndpointMetrics are aggregate counters for calls to a single API endpoint.
+type EndpointMetrics struct {
+	// Calls is the number of requests sent, including failed requests.
+	Calls int64 `json:"calls"`
+	// Errors is the number of requests that failed to connect or returned a 4xx or 5xx status.
+	Errors int64 `json:"errors"`
+	// Retries is the number of requests that were sent again after a failed attempt.
+	Retries int64 `json:"retries"`
+}
+
+// clientMetrics counts API calls per endpoint. The zero value is ready to use.
+type clientMetrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+func (m *clientMetrics) record(endpoint string, resp *http.Response, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.endpoint(endpoint)
+	e.Calls++
+	if err != nil || resp.StatusCode >= 400 {
+		e.Errors++
+	}
+}
+
+func (m *clientMetrics) recordRetry(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoint(endpoint).Retries++
+}
+
+// endpoint returns the counters for an endpoint. m.mu must be held.
+func (m *clientMetrics) endpoint(endpoint string) *EndpointMetrics {
+	if m.endpoints == nil {
+		m.endpoints = make(map[string]*EndpointMetrics)
+	}
+	e, ok := m.endpoints[endpoint]
+	if !ok {
+		e = new(EndpointMetrics)
+		m.endpoints[endpoint] = e
+	}
+	return e
+}
+
+func (m *clientMetrics) snapshot() map[string]EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[string]EndpointMetrics, len(m.endpoints))
+	for endpoint, e := range m.endpoints {
+		snapshot[endpoint] = *e
+	}
+	return snapshot
+}
+
+// Metrics returns a snapshot of the API calls made by the client, keyed by endpoint
+// (e.g. `GET collectors/{id}`).
+func (s *Client) Metrics() map[string]EndpointMetrics {
+	return s.root().metrics.snapshot()
+}
+
+// PublishMetrics publishes the client's metrics with expvar under the given name, making
+// them available at /debug/vars alongside the process's other variables.
+// Like expvar.Publish, it panics if the name is already in use.
+func (s *Client) PublishMetrics(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return s.Metrics()
+	}))
+}
+
+// endpointIDPattern matches path segments that are resource IDs: decimal IDs used by
+// collectors and sources, and hexadecimal IDs used by newer APIs.
+var endpointIDPattern = regexp.MustCompile(`^([0-9]+|[0-9A-Fa-f]{16})$`)
+
+// endpointName returns the method and path of req relative to the client's endpoint URL,
+// with resource IDs replaced by `{id}` so calls to the same endpoint are grouped together.
+func (s *Client) endpointName(req *http.Request) string {
+	path := req.URL.Path
+	if s.EndpointURL != nil {
+		path = strings.TrimPrefix(path, s.EndpointURL.Path)
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if endpointIDPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
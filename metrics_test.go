@@ -0,0 +1,38 @@
+package sumologic
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL+"/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	c.GetHostedCollector(1)
+	c.GetHostedCollector(2)
+	c.DeleteHTTPSource(1, 2)
+
+	metrics := c.Metrics()
+	if m := metrics["GET collectors/{id}"]; m.Calls != 2 || m.Errors != 2 {
+		t.Errorf("Metrics() expected 2 calls and 2 errors for `GET collectors/{id}`, got %+v", m)
+	}
+	if m := metrics["DELETE collectors/{id}/sources/{id}"]; m.Calls != 1 {
+		t.Errorf("Metrics() expected 1 call for `DELETE collectors/{id}/sources/{id}`, got %+v", m)
+	}
+
+	c.PublishMetrics("sumologic_test_metrics")
+	if expvar.Get("sumologic_test_metrics") == nil {
+		t.Errorf("PublishMetrics() did not publish an expvar variable")
+	}
+}
@@ -0,0 +1,44 @@
+package sumologic
+
+import (
+	"net/http"
+)
+
+// Middleware wraps the transport used for API requests, e.g. to sign requests, add headers
+// or write audit logs. It must return a RoundTripper that eventually calls next.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface, which is convenient
+// when writing a Middleware.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware appends middleware to the client's chain. The first middleware added is the
+// outermost, i.e. it sees each request first and each response last.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(s *Client) error {
+		s.middleware = append(s.middleware, middleware...)
+		return nil
+	}
+}
+
+// withMiddleware returns client with its transport wrapped by the client's middleware chain.
+func (s *Client) withMiddleware(client *http.Client) *http.Client {
+	if len(s.middleware) == 0 {
+		return client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		transport = s.middleware[i](transport)
+	}
+	wrapped := *client
+	wrapped.Transport = transport
+	return &wrapped
+}
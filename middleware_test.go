@@ -0,0 +1,46 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signature") != "outer,inner" {
+			t.Errorf("Expected middleware to run outermost first, got ‘%s’", r.Header.Get("X-Signature"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var responses []string
+	sign := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if v := req.Header.Get("X-Signature"); v != "" {
+					name = v + "," + name
+				}
+				req.Header.Set("X-Signature", name)
+				resp, err := next.RoundTrip(req)
+				responses = append(responses, name)
+				return resp, err
+			})
+		}
+	}
+
+	c, err := NewClient("accessToken", ts.URL, WithMiddleware(sign("outer")), WithMiddleware(sign("inner")))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+		return
+	}
+	if len(responses) != 2 || responses[0] != "outer,inner" || responses[1] != "outer" {
+		t.Errorf("Expected the inner middleware to see the response first, got %v", responses)
+	}
+}
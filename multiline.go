@@ -0,0 +1,45 @@
+package sumologic
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ValidateBoundaryRegex checks that expr, a source's ManualPrefixRegexp, compiles. An empty
+// expression is valid.
+//
+// Sumo Logic evaluates boundary regexes with Java's regex engine, but they're checked here
+// with Go's RE2 syntax, which the common subset of both is. Java-only syntax, such as
+// lookarounds, backreferences and possessive quantifiers, is reported as invalid.
+func ValidateBoundaryRegex(expr string) error {
+	_, err := compileBoundaryRegex(expr)
+	return err
+}
+
+// MatchBoundaryRegex reports whether line starts a new message according to the boundary
+// regex expr, so that it can be tested against sample log lines before creating a source.
+// As with Sumo Logic, expr must match the entire line.
+func MatchBoundaryRegex(expr, line string) (bool, error) {
+	re, err := compileBoundaryRegex(expr)
+	if err != nil {
+		return false, err
+	}
+	if re == nil {
+		return false, &ValidationError{Field: "manualPrefixRegexp", Message: "must not be empty"}
+	}
+	return re.MatchString(line), nil
+}
+
+// compileBoundaryRegex compiles expr anchored to the entire line. It returns nil for an empty
+// expression.
+func compileBoundaryRegex(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	if _, err := regexp.Compile(expr); err != nil {
+		return nil, &ValidationError{Field: "manualPrefixRegexp", Message: fmt.Sprintf("`%s` is not a valid regular expression: %s. Note that it's checked with Go's RE2 syntax, which doesn't support Java-only syntax such as lookarounds, backreferences and possessive quantifiers", expr, err)}
+	}
+	// A valid expression is still valid when grouped and anchored.
+	re := regexp.MustCompile(`^(?:` + expr + `)$`)
+	return re, nil
+}
@@ -0,0 +1,51 @@
+package sumologic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBoundaryRegex(t *testing.T) {
+	if err := ValidateBoundaryRegex(""); err != nil {
+		t.Errorf("ValidateBoundaryRegex() of an empty regex returned an error: %s", err)
+	}
+	if err := ValidateBoundaryRegex(`\d{4}-\d{2}-\d{2}.*`); err != nil {
+		t.Errorf("ValidateBoundaryRegex() returned an error: %s", err)
+	}
+
+	err := ValidateBoundaryRegex(`(?=\d{4}).*`)
+	if e, ok := err.(*ValidationError); !ok || e.Field != "manualPrefixRegexp" || !strings.Contains(e.Message, "lookarounds") {
+		t.Errorf("ValidateBoundaryRegex() of a lookahead expected a ValidationError mentioning Java syntax, got %v", err)
+	}
+
+	source := SyslogSource{Name: "syslog", Port: 514, MultilineProcessingEnabled: true, ManualPrefixRegexp: `[`}
+	if e, ok := source.Validate().(*ValidationError); !ok || e.Field != "manualPrefixRegexp" {
+		t.Errorf("Validate() expected a ValidationError for `manualPrefixRegexp`, got %v", source.Validate())
+	}
+}
+
+func TestMatchBoundaryRegex(t *testing.T) {
+	cases := map[string]bool{
+		"2019-08-20 12:00:00 INFO started":   true,
+		"\tat com.example.Main(Main.java:1)": false,
+		"prefix 2019-08-20 12:00:00":         false,
+	}
+	for line, expected := range cases {
+		matched, err := MatchBoundaryRegex(`\d{4}-\d{2}-\d{2} .*`, line)
+		if err != nil {
+			t.Errorf("MatchBoundaryRegex() returned an error: %s", err)
+			return
+		}
+		if matched != expected {
+			t.Errorf("MatchBoundaryRegex() of ‘%s’ expected %t, got %t", line, expected, matched)
+		}
+	}
+
+	// The regex must match the entire line, not just a prefix.
+	if matched, _ := MatchBoundaryRegex(`\d{4}`, "2019-08-20"); matched {
+		t.Errorf("MatchBoundaryRegex() expected a partial match not to match")
+	}
+	if _, err := MatchBoundaryRegex("", "line"); err == nil {
+		t.Errorf("MatchBoundaryRegex() of an empty regex expected an error")
+	}
+}
@@ -0,0 +1,105 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Mutation describes a create, update or delete of a Sumo Logic resource passed to the
+// client's mutation hooks.
+type Mutation struct {
+	// Operation is ChangeCreate, ChangeUpdate or ChangeDelete.
+	Operation string
+	// ResourceType is the kind of resource, e.g. ResourceTypeCollector or ResourceTypeSource.
+	ResourceType string
+	// ResourceID is empty for a create that hasn't been made yet.
+	ResourceID string
+	// ParentID is the ID of the containing resource, e.g. the collector of a source.
+	ParentID string
+	// Payload is the resource to be sent to Sumo Logic for a pre-mutation hook, and the
+	// resource returned by Sumo Logic for a post-mutation hook. It's empty for deletes.
+	// It's redacted with the paths given to WithChangeRedaction, like a Change, and
+	// otherwise includes credentials such as RemoteFileSource.RemotePassword.
+	Payload json.RawMessage
+}
+
+// WithPreMutationHook adds a hook called before every create, update and delete made by the
+// client, e.g. to enforce change windows or approval checks in every tool built on the SDK.
+// If the hook returns an error, the request isn't sent and the method returns a
+// *MutationVetoedError. Hooks run in the order they were added, and may be called from
+// multiple goroutines at once. Hooks see the credentials in payloads unless they are
+// redacted with WithChangeRedaction.
+func WithPreMutationHook(hook func(Mutation) error) ClientOption {
+	return func(s *Client) error {
+		s.preMutationHooks = append(s.preMutationHooks, hook)
+		return nil
+	}
+}
+
+// WithPostMutationHook adds a hook called after every successful create, update and delete
+// made by the client. Hooks run in the order they were added, and may be called from
+// multiple goroutines at once.
+func WithPostMutationHook(hook func(Mutation)) ClientOption {
+	return func(s *Client) error {
+		s.postMutationHooks = append(s.postMutationHooks, hook)
+		return nil
+	}
+}
+
+// MutationVetoedError is returned when a pre-mutation hook rejects a create, update or delete.
+type MutationVetoedError struct {
+	Mutation Mutation
+	Err      error
+}
+
+func (e *MutationVetoedError) Error() string {
+	return fmt.Sprintf("%s of %s vetoed: %s", e.Mutation.Operation, e.Mutation.ResourceType, e.Err)
+}
+
+// Unwrap returns the error returned by the hook.
+func (e *MutationVetoedError) Unwrap() error {
+	return e.Err
+}
+
+// newMutation returns a Mutation with the payload serialized to JSON and redacted; nil IDs
+// and payloads are left out.
+func (s *Client) newMutation(operation, resourceType string, id, parentID, payload interface{}) Mutation {
+	m := Mutation{Operation: operation, ResourceType: resourceType}
+	if id != nil {
+		m.ResourceID = fmt.Sprint(id)
+	}
+	if parentID != nil {
+		m.ParentID = fmt.Sprint(parentID)
+	}
+	if payload != nil {
+		m.Payload, _ = json.Marshal(payload)
+		m.Payload = s.redact(m.Payload)
+	}
+	return m
+}
+
+// beforeMutation calls the client's pre-mutation hooks, returning a *MutationVetoedError for
+// the first that rejects the mutation.
+func (s *Client) beforeMutation(operation, resourceType string, id, parentID, payload interface{}) error {
+	if len(s.preMutationHooks) == 0 {
+		return nil
+	}
+	m := s.newMutation(operation, resourceType, id, parentID, payload)
+	for _, hook := range s.preMutationHooks {
+		if err := hook(m); err != nil {
+			return &MutationVetoedError{Mutation: m, Err: err}
+		}
+	}
+	return nil
+}
+
+// afterMutation calls the client's post-mutation hooks.
+func (s *Client) afterMutation(operation, resourceType string, id, parentID, payload interface{}) {
+	if len(s.postMutationHooks) == 0 {
+		return
+	}
+	m := s.newMutation(operation, resourceType, id, parentID, payload)
+	for _, hook := range s.postMutationHooks {
+		hook(m)
+	}
+}
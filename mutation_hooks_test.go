@@ -0,0 +1,154 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPreMutationHookVeto(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	outsideWindow := errors.New("outside the change window")
+	var vetoed []Mutation
+	c, err := NewClient("accessToken", ts.URL, WithPreMutationHook(func(m Mutation) error {
+		vetoed = append(vetoed, m)
+		return outsideWindow
+	}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateSyslogSource(1, SyslogSource{Name: "syslog", Port: 514})
+	e, ok := err.(*MutationVetoedError)
+	if !ok {
+		t.Errorf("CreateSyslogSource() returned ‘%v’, expected a *MutationVetoedError", err)
+		return
+	}
+	if e.Err != outsideWindow {
+		t.Errorf("MutationVetoedError.Err was ‘%v’, expected ‘%v’", e.Err, outsideWindow)
+	}
+	if err := c.DeleteHostedCollector(2); err == nil {
+		t.Errorf("DeleteHostedCollector() returned no error")
+	}
+	if requests != 0 {
+		t.Errorf("%d requests were sent, expected none", requests)
+	}
+
+	if len(vetoed) != 2 {
+		t.Errorf("the hook was called %d times, expected 2", len(vetoed))
+		return
+	}
+	create := vetoed[0]
+	if create.Operation != ChangeCreate || create.ResourceType != ResourceTypeSource || create.ResourceID != "" || create.ParentID != "1" {
+		t.Errorf("the create mutation was ‘%+v’", create)
+	}
+	var source SyslogSource
+	if err := json.Unmarshal(create.Payload, &source); err != nil || source.Name != "syslog" {
+		t.Errorf("the create payload was ‘%s’, expected the syslog source", create.Payload)
+	}
+	del := vetoed[1]
+	if del.Operation != ChangeDelete || del.ResourceType != ResourceTypeCollector || del.ResourceID != "2" || del.Payload != nil {
+		t.Errorf("the delete mutation was ‘%+v’", del)
+	}
+}
+
+func TestPostMutationHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sr := new(HTTPSourceRequest)
+		json.Unmarshal(body, &sr)
+		sr.Source.ID = 3
+		js, _ := json.Marshal(sr)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(js)
+	}))
+	defer ts.Close()
+
+	var pre, post []Mutation
+	c, err := NewClient("accessToken", ts.URL,
+		WithPreMutationHook(func(m Mutation) error {
+			pre = append(pre, m)
+			return nil
+		}),
+		WithPostMutationHook(func(m Mutation) {
+			post = append(post, m)
+		}),
+		WithChangeRedaction("name"),
+	)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.WithAuth("other").CreateHTTPSource(1, HTTPSource{Name: "secret", Category: "prod"}); err != nil {
+		t.Errorf("CreateHTTPSource() returned an error: %s", err)
+		return
+	}
+	if len(pre) != 1 || len(post) != 1 {
+		t.Errorf("the hooks were called %d and %d times, expected once each", len(pre), len(post))
+		return
+	}
+	if post[0].Operation != ChangeCreate || post[0].ResourceID != "3" || post[0].ParentID != "1" {
+		t.Errorf("the post-mutation was ‘%+v’", post[0])
+	}
+	for _, m := range []Mutation{pre[0], post[0]} {
+		if strings.Contains(string(m.Payload), "secret") || !strings.Contains(string(m.Payload), "prod") {
+			t.Errorf("the payload ‘%s’ wasn't redacted", m.Payload)
+		}
+	}
+}
+
+func TestMutationHookRedaction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	source := RemoteFileSource{
+		Name:           "remote",
+		RemoteHosts:    []string{"host"},
+		RemotePort:     22,
+		RemoteUser:     "user",
+		AuthMethod:     RemoteFileAuthMethodPassword,
+		RemotePassword: "secret",
+		PathExpression: "/var/log/*.log",
+	}
+	vetoed := errors.New("vetoed")
+	for _, redact := range []bool{false, true} {
+		var payload RemoteFileSource
+		options := []ClientOption{WithPreMutationHook(func(m Mutation) error {
+			json.Unmarshal(m.Payload, &payload)
+			return vetoed
+		})}
+		if redact {
+			options = append(options, WithChangeRedaction("remotePassword", "keyPassword"))
+		}
+		c, err := NewClient("accessToken", ts.URL, options...)
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			return
+		}
+
+		if _, err := c.CreateRemoteFileSource(1, source); !errors.Is(err, vetoed) {
+			t.Errorf("CreateRemoteFileSource() returned ‘%v’, expected the hook's error", err)
+		}
+		expected := "secret"
+		if redact {
+			expected = maskedSecret
+		}
+		if payload.RemotePassword != expected || payload.RemoteUser != "user" {
+			t.Errorf("the hook's payload had password ‘%s’ and user ‘%s’ with redaction %t", payload.RemotePassword, payload.RemoteUser, redact)
+		}
+	}
+}
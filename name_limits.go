@@ -0,0 +1,53 @@
+package sumologic
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits on collector and source metadata enforced by Sumo Logic, whose 400 response to
+// violations doesn't say which field is at fault.
+const (
+	// MaxNameLength is the maximum length of collector and source names, in characters.
+	MaxNameLength = 128
+	// MaxCategoryLength is the maximum length of collector and source categories, in
+	// characters.
+	MaxCategoryLength = 1024
+)
+
+// ValidateName checks that name can be used as the name of a collector or source: it must
+// not be longer than MaxNameLength or contain control characters such as newlines.
+func ValidateName(name string) error {
+	return validateMetadata("name", name, MaxNameLength)
+}
+
+// ValidateCategory checks that category can be used as the category of a collector or
+// source: it must not be longer than MaxCategoryLength or contain control characters. An
+// empty category is valid.
+func ValidateCategory(category string) error {
+	return validateMetadata("category", category, MaxCategoryLength)
+}
+
+func validateMetadata(field, value string, maxLength int) error {
+	if n := utf8.RuneCountInString(value); n > maxLength {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("must be at most %d characters, got %d", maxLength, n)}
+	}
+	for i, r := range value {
+		if r == utf8.RuneError {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("`%s` is not valid UTF-8", value)}
+		}
+		if unicode.IsControl(r) {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("must not contain control characters, got %q at offset %d", r, i)}
+		}
+	}
+	return nil
+}
+
+// validateNameAndCategory checks the name and category of a collector or source.
+func validateNameAndCategory(name, category string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	return ValidateCategory(category)
+}
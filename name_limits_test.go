@@ -0,0 +1,41 @@
+package sumologic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNameAndCategory(t *testing.T) {
+	valid := []struct{ name, category string }{
+		{"collector", ""},
+		{strings.Repeat("é", MaxNameLength), strings.Repeat("c", MaxCategoryLength)},
+		{"prod/api", "prod/api/nginx"},
+	}
+	for _, v := range valid {
+		if err := validateNameAndCategory(v.name, v.category); err != nil {
+			t.Errorf("validateNameAndCategory() returned an error for ‘%s’ and ‘%s’: %s", v.name, v.category, err)
+		}
+	}
+
+	invalid := []struct{ name, category, field string }{
+		{strings.Repeat("n", MaxNameLength+1), "", "name"},
+		{"line\nbreak", "", "name"},
+		{"\xff", "", "name"},
+		{"source", strings.Repeat("c", MaxCategoryLength+1), "category"},
+		{"source", "prod\tapi", "category"},
+	}
+	for _, v := range invalid {
+		err := validateNameAndCategory(v.name, v.category)
+		if e, ok := err.(*ValidationError); !ok || e.Field != v.field {
+			t.Errorf("validateNameAndCategory() expected a ValidationError for `%s`, got %v", v.field, err)
+		}
+	}
+}
+
+func TestCreateHostedCollectorNameTooLong(t *testing.T) {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	_, err := c.CreateHostedCollector(Collector{Name: strings.Repeat("n", MaxNameLength+1), CollectorType: "Hosted"})
+	if e, ok := err.(*ValidationError); !ok || e.Field != "name" {
+		t.Errorf("CreateHostedCollector() expected a ValidationError for `name` before the request, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Outcomes of an operation reported in an OperationResult.
+const (
+	OperationCreated = "created"
+	OperationUpdated = "updated"
+	OperationDeleted = "deleted"
+	OperationSkipped = "skipped"
+	OperationFailed  = "failed"
+)
+
+// operationOutcomes orders outcomes in OperationResults.Summary.
+var operationOutcomes = []string{OperationCreated, OperationUpdated, OperationDeleted, OperationSkipped, OperationFailed}
+
+// OperationResult is the outcome for a single resource of a helper that changes many
+// resources, such as BulkDeleteSources. It marshals to JSON, with Err as a string, so
+// automation can publish a summary of what changed.
+type OperationResult struct {
+	// ResourceType is one of the ResourceType constants.
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	// Outcome is one of the Operation constants.
+	Outcome string `json:"outcome"`
+	// Reason explains a skipped or failed operation.
+	Reason string `json:"reason,omitempty"`
+	// Err is the error of a failed operation.
+	Err error `json:"-"`
+}
+
+// MarshalJSON includes Err as `error`.
+func (r OperationResult) MarshalJSON() ([]byte, error) {
+	type operationResult OperationResult
+	v := struct {
+		operationResult
+		Error string `json:"error,omitempty"`
+	}{operationResult: operationResult(r)}
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	}
+	return json.Marshal(v)
+}
+
+// OperationResults are the outcomes of a helper that changes many resources.
+type OperationResults []OperationResult
+
+// Failed returns the results of failed operations.
+func (results OperationResults) Failed() OperationResults {
+	var failed OperationResults
+	for _, r := range results {
+		if r.Outcome == OperationFailed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Summary counts the results by outcome, e.g. `2 created, 1 skipped, 1 failed`.
+func (results OperationResults) Summary() string {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Outcome]++
+	}
+	var parts []string
+	for _, outcome := range operationOutcomes {
+		if counts[outcome] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[outcome], outcome))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
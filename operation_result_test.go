@@ -0,0 +1,44 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOperationResultMarshalJSON(t *testing.T) {
+	results := OperationResults{
+		{ResourceType: ResourceTypeCollector, ResourceID: "1", Name: "collector", Outcome: OperationCreated},
+		{ResourceType: ResourceTypeSource, ResourceID: "2", Outcome: OperationFailed, Reason: "boom", Err: errors.New("boom")},
+	}
+
+	b, err := json.Marshal(results)
+	if err != nil {
+		t.Errorf("json.Marshal() returned an error: %s", err)
+		return
+	}
+	expected := `[{"resourceType":"collector","resourceId":"1","name":"collector","outcome":"created"},` +
+		`{"resourceType":"source","resourceId":"2","outcome":"failed","reason":"boom","error":"boom"}]`
+	if string(b) != expected {
+		t.Errorf("json.Marshal() expected ‘%s’, got ‘%s’", expected, b)
+	}
+}
+
+func TestOperationResultsSummary(t *testing.T) {
+	if summary := (OperationResults{}).Summary(); summary != "no changes" {
+		t.Errorf("Summary() expected ‘no changes’, got ‘%s’", summary)
+	}
+
+	results := OperationResults{
+		{Outcome: OperationFailed},
+		{Outcome: OperationCreated},
+		{Outcome: OperationSkipped, Reason: "unchanged"},
+		{Outcome: OperationCreated},
+	}
+	if summary := results.Summary(); summary != "2 created, 1 skipped, 1 failed" {
+		t.Errorf("Summary() expected ‘2 created, 1 skipped, 1 failed’, got ‘%s’", summary)
+	}
+	if failed := results.Failed(); len(failed) != 1 || failed[0].Outcome != OperationFailed {
+		t.Errorf("Failed() expected one failed result, got %v", failed)
+	}
+}
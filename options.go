@@ -0,0 +1,167 @@
+package sumologic
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client) error
+
+// defaultHTTPTimeout is the time limit of requests made by clients without their own
+// *http.Client. See WithTimeout.
+const defaultHTTPTimeout = time.Minute
+
+// defaultHTTPClient is used by clients without their own *http.Client.
+var defaultHTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+
+// newHTTPClient returns the *http.Client of a client created without WithHTTPClient. It
+// has its own cookie jar, since the Search Job API requires the cookies set when a job is
+// created to be sent when polling its status and fetching its results.
+func newHTTPClient() *http.Client {
+	jar, _ := cookiejar.New(nil)
+	return &http.Client{Timeout: defaultHTTPTimeout, Jar: jar}
+}
+
+// WithHTTPClient makes the client send requests with c, e.g. to use a custom transport,
+// proxy or TLS configuration. Options applied after WithHTTPClient, such as WithTimeout or
+// WithTLSConfig, change a copy of c and its *http.Transport, never c itself, so c may be
+// shared, e.g. http.DefaultClient. Search jobs require c to have a cookie Jar.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(s *Client) error {
+		if c == nil {
+			return &ValidationError{Field: "httpClient", Message: "must not be nil"}
+		}
+		s.httpClient = c
+		s.ownsHTTPClient = false
+		s.ownsTransport = false
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of each request, including reading the response body.
+// Zero means no timeout. The default is one minute.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(s *Client) error {
+		if d < 0 {
+			return &ValidationError{Field: "timeout", Message: "must not be negative"}
+		}
+		s.ownHTTPClient().Timeout = d
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of API connections, e.g. to trust a private CA
+// or present a client certificate. config is copied.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig = config.Clone()
+		return nil
+	}
+}
+
+// WithProxy sets the function that returns the proxy for each request, such as
+// http.ProxyURL. By default, the proxy is taken from the environment (see
+// http.ProxyFromEnvironment).
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.Proxy = proxy
+		return nil
+	}
+}
+
+// ownHTTPClient returns the client's own *http.Client, creating it with newHTTPClient,
+// or copying the one set with WithHTTPClient, the first time an option changes it.
+func (s *Client) ownHTTPClient() *http.Client {
+	if !s.ownsHTTPClient {
+		if s.httpClient == nil {
+			s.httpClient = newHTTPClient()
+		} else {
+			c := *s.httpClient
+			s.httpClient = &c
+		}
+		s.ownsHTTPClient = true
+	}
+	return s.httpClient
+}
+
+// transport returns the client's own *http.Transport, cloning http.DefaultTransport or the
+// transport of the client set with WithHTTPClient the first time a transport option is
+// applied.
+func (s *Client) transport() (*http.Transport, error) {
+	c := s.ownHTTPClient()
+	if !s.ownsTransport {
+		shared := c.Transport
+		if shared == nil {
+			shared = http.DefaultTransport
+		}
+		t, ok := shared.(*http.Transport)
+		if !ok {
+			return nil, errors.New("Transport options require the HTTP client to use an *http.Transport")
+		}
+		c.Transport = t.Clone()
+		s.ownsTransport = true
+	}
+	return c.Transport.(*http.Transport), nil
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle (keep-alive) connections kept
+// open to the API. Raise it when making many concurrent calls.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.MaxIdleConnsPerHost = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection is kept open.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.IdleConnTimeout = d
+		return nil
+	}
+}
+
+// WithDisableKeepAlives disables HTTP keep-alives so every request uses a new connection.
+func WithDisableKeepAlives(disable bool) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.DisableKeepAlives = disable
+		return nil
+	}
+}
+
+// WithDisableCompression stops the transport from requesting gzip-compressed responses.
+func WithDisableCompression(disable bool) ClientOption {
+	return func(s *Client) error {
+		t, err := s.transport()
+		if err != nil {
+			return err
+		}
+		t.DisableCompression = disable
+		return nil
+	}
+}
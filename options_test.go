@@ -0,0 +1,196 @@
+package sumologic
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTransportOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "" {
+			t.Errorf("Expected no Accept-Encoding with compression disabled, got ‘%s’", r.Header.Get("Accept-Encoding"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL,
+		WithMaxIdleConnsPerHost(32),
+		WithIdleConnTimeout(time.Minute),
+		WithDisableKeepAlives(true),
+		WithDisableCompression(true),
+	)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	transport := c.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 32 {
+		t.Errorf("Expected MaxIdleConnsPerHost 32, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("Expected IdleConnTimeout 1m, got %s", transport.IdleConnTimeout)
+	}
+	if !transport.DisableKeepAlives || !transport.DisableCompression {
+		t.Errorf("Expected keep-alives and compression to be disabled")
+	}
+	if transport == http.DefaultTransport {
+		t.Errorf("Transport options modified http.DefaultTransport")
+	}
+
+	err = c.DeleteHTTPSource(1, 2)
+	if err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	transport := new(countingTransport)
+	c, err := NewClient("accessToken", ts.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+	if transport.requests != 1 {
+		t.Errorf("WithHTTPClient() expected the request to use the client, got %d requests", transport.requests)
+	}
+
+	if _, err := NewClient("accessToken", ts.URL, WithHTTPClient(nil)); err == nil {
+		t.Errorf("WithHTTPClient() expected an error for a nil client")
+	}
+	if _, err := NewClient("accessToken", ts.URL, WithHTTPClient(&http.Client{Transport: transport}), WithDisableKeepAlives(true)); err == nil {
+		t.Errorf("WithDisableKeepAlives() expected an error for a client without an *http.Transport")
+	}
+}
+
+func TestWithHTTPClientUnchanged(t *testing.T) {
+	tlsConfig := &tls.Config{}
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+	shared := &http.Client{Transport: transport, Timeout: time.Minute}
+	c, err := NewClient("accessToken", "http://localhost", WithHTTPClient(shared), WithTimeout(time.Second), WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}), WithDisableKeepAlives(true))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if shared.Timeout != time.Minute || shared.Transport != transport {
+		t.Errorf("WithHTTPClient() expected the caller's client to be unchanged, got %+v", shared)
+	}
+	if transport.TLSClientConfig != tlsConfig || tlsConfig.MinVersion != 0 || transport.DisableKeepAlives {
+		t.Errorf("WithHTTPClient() expected the caller's transport to be unchanged, got %+v", transport)
+	}
+	if c.httpClient.Timeout != time.Second {
+		t.Errorf("WithTimeout() expected ‘%s’, got ‘%s’", time.Second, c.httpClient.Timeout)
+	}
+	own, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok || own == transport || !own.DisableKeepAlives || own.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("WithTLSConfig() expected a configured copy of the transport, got %+v", c.httpClient.Transport)
+	}
+
+	if _, err := NewClient("accessToken", "http://localhost", WithHTTPClient(http.DefaultClient), WithTimeout(time.Second), WithDisableKeepAlives(true)); err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if http.DefaultClient.Timeout != 0 || http.DefaultClient.Transport != nil || http.DefaultTransport.(*http.Transport).DisableKeepAlives {
+		t.Errorf("WithHTTPClient() expected http.DefaultClient to be unchanged")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	c, err := NewClient("accessToken", ts.URL, WithTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.httpClient.Timeout != 10*time.Millisecond {
+		t.Errorf("WithTimeout() expected a 10ms timeout, got %s", c.httpClient.Timeout)
+	}
+	if err := c.DeleteHTTPSource(1, 2); err == nil {
+		t.Errorf("DeleteHTTPSource() expected a timeout error")
+	}
+
+	if _, err := NewClient("accessToken", ts.URL, WithTimeout(-time.Second)); err == nil {
+		t.Errorf("WithTimeout() expected an error for a negative timeout")
+	}
+	if defaultHTTPClient.Timeout != defaultHTTPTimeout {
+		t.Errorf("Expected the default client to time out after %s, got %s", defaultHTTPTimeout, defaultHTTPClient.Timeout)
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSource(1, 2); err == nil {
+		t.Errorf("DeleteHTTPSource() expected an error for an untrusted certificate")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	c, err = NewClient("accessToken", ts.URL, WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	c, err := NewClient("accessToken", "http://127.0.0.1:1/api/v1/", WithProxy(http.ProxyURL(proxyURL)))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+	if proxied != "http://127.0.0.1:1/api/v1/collectors/1/sources/2" {
+		t.Errorf("WithProxy() expected the request to go through the proxy, got ‘%s’", proxied)
+	}
+}
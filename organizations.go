@@ -0,0 +1,127 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Organization is a child organization managed by a parent (multi-tenant) organization.
+type Organization struct {
+	OrgID            string `json:"orgId,omitempty"`
+	OrganizationName string `json:"organizationName"`
+	DeploymentID     string `json:"deploymentId,omitempty"`
+	Email            string `json:"email"`
+	FirstName        string `json:"firstName,omitempty"`
+	LastName         string `json:"lastName,omitempty"`
+	SubscriptionType string `json:"subscriptionType,omitempty"`
+	IsActive         bool   `json:"isActive,omitempty"`
+	CreatedAt        string `json:"createdAt,omitempty"`
+}
+
+// OrganizationCredentials is an access key for a child organization. It can be used with
+// NewClient to configure collectors in the child organization.
+type OrganizationCredentials struct {
+	AccessID  string `json:"accessId"`
+	AccessKey string `json:"accessKey"`
+}
+
+// ErrOrganizationNotFound is returned when an organization doesn't exist on a Read or Deactivate.
+var ErrOrganizationNotFound = errors.New("Organization not found")
+
+// CreateOrganization creates a new child organization.
+func (s *Client) CreateOrganization(organization Organization) (*Organization, error) {
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeOrganization, nil, nil, organization); err != nil {
+		return nil, err
+	}
+	var o = new(Organization)
+	if _, err := s.do("POST", "organizations", organization, o); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if an organization named `%s` already exists", organization.OrganizationName))
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeOrganization, o.OrgID, nil, o)
+	return o, nil
+}
+
+// ListOrganizations returns all child organizations, following pagination transparently.
+func (s *Client) ListOrganizations() ([]Organization, error) {
+	organizations := []Organization{}
+	err := s.listAllPages("organizations", nil, func(data json.RawMessage) error {
+		var page []Organization
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		organizations = append(organizations, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// ListOrganizationsPage returns the page of child organizations at cursor, and the cursor of
+// the next page, which is empty after the last page.
+func (s *Client) ListOrganizationsPage(cursor Cursor) ([]Organization, Cursor, error) {
+	data, next, err := s.listTokenPage("organizations", nil, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var page []Organization
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, "", err
+	}
+	return page, next, nil
+}
+
+// GetOrganization gets the child organization with the specified ID.
+func (s *Client) GetOrganization(orgID string) (*Organization, error) {
+	path, err := formatPath("organizations/%s", orgID)
+	if err != nil {
+		return nil, err
+	}
+	var o = new(Organization)
+	if err := s.getOrganizationResource(path, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// GetOrganizationCredentials gets an access key for the child organization with the specified ID.
+func (s *Client) GetOrganizationCredentials(orgID string) (*OrganizationCredentials, error) {
+	path, err := formatPath("organizations/%s/credentials", orgID)
+	if err != nil {
+		return nil, err
+	}
+	var c = new(OrganizationCredentials)
+	if err := s.getOrganizationResource(path, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeactivateOrganization deactivates the child organization with the specified ID.
+func (s *Client) DeactivateOrganization(orgID string) error {
+	path, err := formatPath("organizations/%s/deactivate", orgID)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeOrganization, orgID, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do("POST", path, nil, nil); err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrOrganizationNotFound)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeOrganization, orgID, nil, nil)
+	return nil
+}
+
+func (s *Client) getOrganizationResource(path string, out interface{}) error {
+	_, err := s.do("GET", path, nil, out)
+	return errorForStatus(err, http.StatusNotFound, ErrOrganizationNotFound)
+}
@@ -0,0 +1,103 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultOrganization = Organization{
+	OrgID:            "000000000000000A",
+	OrganizationName: "customer",
+	Email:            "admin@customer.example.com",
+}
+
+func TestCreateOrganizationOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/organizations" {
+			t.Errorf("Expected request to ‘/organizations’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := io.ReadAll(r.Body)
+		o := new(Organization)
+		if err := json.Unmarshal(body, &o); err != nil {
+			t.Errorf("Unable to unmarshal Organization, got `%s`", body)
+		}
+		o.OrgID = defaultOrganization.OrgID
+		js, _ := json.Marshal(o)
+		w.WriteHeader(http.StatusOK)
+		w.Write(js)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	o, err := c.CreateOrganization(Organization{OrganizationName: "customer", Email: defaultOrganization.Email})
+	if err != nil {
+		t.Errorf("CreateOrganization() returned an error: %s", err)
+		return
+	}
+	if o.OrgID != defaultOrganization.OrgID {
+		t.Errorf("CreateOrganization() expected ID `%s`, got `%s`", defaultOrganization.OrgID, o.OrgID)
+	}
+}
+
+func TestGetOrganizationCredentialsOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedURL := "/organizations/" + defaultOrganization.OrgID + "/credentials"
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"accessId":"id","accessKey":"key"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	creds, err := c.GetOrganizationCredentials(defaultOrganization.OrgID)
+	if err != nil {
+		t.Errorf("GetOrganizationCredentials() returned an error: %s", err)
+		return
+	}
+	if creds.AccessID != "id" || creds.AccessKey != "key" {
+		t.Errorf("GetOrganizationCredentials() returned unexpected credentials: %+v", creds)
+	}
+}
+
+func TestDeactivateOrganizationDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := "/organizations/" + defaultOrganization.OrgID + "/deactivate"
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeactivateOrganization(defaultOrganization.OrgID)
+	if err != ErrOrganizationNotFound {
+		t.Errorf("DeactivateOrganization() returned the wrong error: %s", err)
+	}
+}
@@ -0,0 +1,126 @@
+package sumologic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageLimit is the page size requested from token-paginated list endpoints.
+const defaultPageLimit = 100
+
+// tokenPage is the envelope returned by token-paginated list endpoints such as users and roles.
+type tokenPage struct {
+	Data json.RawMessage `json:"data"`
+	Next string          `json:"next"`
+}
+
+// ErrRepeatedPageToken is returned when a paginated list returns the token of a page it
+// already returned, which would otherwise list the same pages forever.
+var ErrRepeatedPageToken = errors.New("Paginated list returned a page token it already returned")
+
+// listAllPages requests every page of a token-paginated endpoint and calls fn with each page's data.
+func (s *Client) listAllPages(path string, query url.Values, fn func(data json.RawMessage) error) error {
+	token := ""
+	seen := map[string]bool{}
+	for {
+		page, err := s.getTokenPage(path, query, token)
+		if err != nil {
+			return err
+		}
+		if err := fn(page.Data); err != nil {
+			return err
+		}
+		if page.Next == "" {
+			return nil
+		}
+		if seen[page.Next] {
+			return ErrRepeatedPageToken
+		}
+		seen[page.Next] = true
+		token = page.Next
+	}
+}
+
+// getTokenPage requests a single page of a token-paginated endpoint. A page without data,
+// such as an empty last page, has an empty JSON array as Data.
+func (s *Client) getTokenPage(path string, query url.Values, token string) (*tokenPage, error) {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("limit", strconv.Itoa(defaultPageLimit))
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	var page = new(tokenPage)
+	if _, err := s.do("GET", path+"?"+q.Encode(), nil, page); err != nil {
+		return nil, err
+	}
+	if len(page.Data) == 0 || string(page.Data) == "null" {
+		page.Data = json.RawMessage("[]")
+	}
+	return page, nil
+}
+
+// Cursor is an opaque position in a paginated list, as returned by the Page variants of the
+// List methods, such as ListCollectorsPage. Persist it to resume a long listing where it
+// stopped, e.g. after a restart, instead of starting over. The empty Cursor starts at the
+// first page, and the empty Cursor is returned after the last page.
+type Cursor string
+
+// ErrInvalidCursor is returned when a Cursor is malformed or was returned for another list.
+var ErrInvalidCursor = errors.New("Invalid pagination cursor")
+
+// cursorState is the position encoded in a Cursor. The list's path and query are included
+// so that a cursor can't be resumed against another list.
+type cursorState struct {
+	Path   string `json:"p"`
+	Query  string `json:"q,omitempty"`
+	Offset int    `json:"o,omitempty"`
+	Token  string `json:"t,omitempty"`
+}
+
+func (c cursorState) encode() Cursor {
+	data, _ := json.Marshal(c)
+	return Cursor(base64.RawURLEncoding.EncodeToString(data))
+}
+
+// decodeCursor decodes cursor for the list at path with query. The empty cursor decodes to
+// the first page.
+func decodeCursor(cursor Cursor, path string, query url.Values) (cursorState, error) {
+	state := cursorState{Path: path, Query: query.Encode()}
+	if cursor == "" {
+		return state, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil {
+		return state, ErrInvalidCursor
+	}
+	var decoded cursorState
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Path != state.Path || decoded.Query != state.Query {
+		return state, ErrInvalidCursor
+	}
+	return decoded, nil
+}
+
+// listTokenPage requests the page of a token-paginated endpoint at cursor and returns its
+// data and the cursor of the next page.
+func (s *Client) listTokenPage(path string, query url.Values, cursor Cursor) (json.RawMessage, Cursor, error) {
+	state, err := decodeCursor(cursor, path, query)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := s.getTokenPage(path, query, state.Token)
+	if err != nil {
+		return nil, "", err
+	}
+	if page.Next == "" {
+		return page.Data, "", nil
+	}
+	state.Token = page.Next
+	return page.Data, state.encode(), nil
+}
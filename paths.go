@@ -0,0 +1,31 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// formatPath formats a relative API path, validating the IDs substituted into it so that
+// they can't produce an unexpected path. int IDs must be positive, and string IDs must be
+// non-empty and are path escaped, with `.` and `..` rejected since they would be resolved
+// as path traversal.
+func formatPath(format string, ids ...interface{}) (string, error) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		switch id := id.(type) {
+		case int:
+			if id <= 0 {
+				return "", &ValidationError{Field: "id", Message: fmt.Sprintf("`%d` must be a positive integer", id)}
+			}
+			args[i] = id
+		case string:
+			if id == "" || id == "." || id == ".." {
+				return "", &ValidationError{Field: "id", Message: fmt.Sprintf("`%s` is not a valid ID", id)}
+			}
+			args[i] = url.PathEscape(id)
+		default:
+			return "", &ValidationError{Field: "id", Message: fmt.Sprintf("unsupported ID type %T", id)}
+		}
+	}
+	return fmt.Sprintf(format, args...), nil
+}
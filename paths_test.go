@@ -0,0 +1,49 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatPath(t *testing.T) {
+	path, err := formatPath("collectors/%d/sources/%d", 1, 2)
+	if err != nil || path != "collectors/1/sources/2" {
+		t.Errorf("formatPath() expected ‘collectors/1/sources/2’, got ‘%s’ (%v)", path, err)
+	}
+
+	path, err = formatPath("organizations/%s", "a/../b?c")
+	if err != nil || path != "organizations/a%2F..%2Fb%3Fc" {
+		t.Errorf("formatPath() expected the ID to be escaped, got ‘%s’ (%v)", path, err)
+	}
+
+	for _, id := range []interface{}{0, -1, "", ".", "..", 1.5} {
+		if _, err := formatPath("collectors/%v", id); err == nil {
+			t.Errorf("formatPath() did not return an error for ‘%v’", id)
+		} else if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("formatPath() expected a ValidationError for ‘%v’, got %T", id, err)
+		}
+	}
+}
+
+func TestInvalidIDsAreNotSent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request should not have been sent, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("accessToken", ts.URL)
+
+	if _, _, err := client.GetHostedCollector(-1); err == nil {
+		t.Errorf("GetHostedCollector() did not return an error for a negative ID")
+	}
+	if err := client.DeleteHTTPSource(1, 0); err == nil {
+		t.Errorf("DeleteHTTPSource() did not return an error for a zero ID")
+	}
+	if _, err := client.GetOrganization(".."); err == nil {
+		t.Errorf("GetOrganization() did not return an error for ‘..’")
+	}
+	if _, err := client.GetSearchJobStatus(""); err == nil {
+		t.Errorf("GetSearchJobStatus() did not return an error for an empty ID")
+	}
+}
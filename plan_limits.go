@@ -0,0 +1,59 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrPlanLimitExceeded matches, with errors.Is, every *PlanLimitError.
+var ErrPlanLimitExceeded = errors.New("Sumo Logic plan limit exceeded")
+
+// PlanLimitError is returned when a resource can't be created because the organization has
+// reached a limit of its plan, e.g. the maximum number of collectors on a trial or free plan.
+type PlanLimitError struct {
+	// ResourceType is the kind of resource that was limited, e.g. ResourceTypeCollector.
+	ResourceType string
+	// Limit is the plan's maximum, or 0 if the API didn't say.
+	Limit int
+	// Code and Message are the API's error code and message.
+	Code    string
+	Message string
+}
+
+func (e *PlanLimitError) Error() string {
+	if e.Limit > 0 {
+		return fmt.Sprintf("%s: the organization's plan allows at most %d %ss. Delete unused %ss or upgrade the plan. %s", ErrPlanLimitExceeded, e.Limit, e.ResourceType, e.ResourceType, e.Message)
+	}
+	return fmt.Sprintf("%s: the organization's plan limits %ss. Delete unused %ss or upgrade the plan. %s", ErrPlanLimitExceeded, e.ResourceType, e.ResourceType, e.Message)
+}
+
+// Is reports whether target is ErrPlanLimitExceeded.
+func (e *PlanLimitError) Is(target error) bool {
+	return target == ErrPlanLimitExceeded
+}
+
+// planLimitNumber finds the limit in messages such as `Maximum number of collectors (10) reached`.
+var planLimitNumber = regexp.MustCompile(`\d+`)
+
+// planLimitError returns a *PlanLimitError if e reports that creating a resource of
+// resourceType exceeded a plan limit, and nil otherwise.
+func planLimitError(resourceType string, e *Error) error {
+	if e == nil {
+		return nil
+	}
+	code, message := strings.ToLower(e.Code), strings.ToLower(e.Message)
+	limitCode := strings.Contains(code, "limit") || strings.Contains(code, "quota")
+	limitMessage := (strings.Contains(message, "limit") || strings.Contains(message, "maximum")) &&
+		(strings.Contains(message, "reached") || strings.Contains(message, "exceeded"))
+	if !limitCode && !limitMessage {
+		return nil
+	}
+	err := &PlanLimitError{ResourceType: resourceType, Code: e.Code, Message: e.Message}
+	if n := planLimitNumber.FindString(e.Message); n != "" {
+		err.Limit, _ = strconv.Atoi(n)
+	}
+	return err
+}
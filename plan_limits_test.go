@@ -0,0 +1,55 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateHostedCollectorPlanLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":400,"code":"collectors.limit.reached","message":"Maximum number of collectors (10) reached for this organization."}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateHostedCollector(Collector{Name: "collector"})
+	if !errors.Is(err, ErrPlanLimitExceeded) {
+		t.Errorf("CreateHostedCollector() expected ErrPlanLimitExceeded, got %v", err)
+		return
+	}
+	var limitErr *PlanLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != 10 || limitErr.ResourceType != ResourceTypeCollector {
+		t.Errorf("CreateHostedCollector() expected a limit of 10 collectors, got %+v", limitErr)
+	}
+}
+
+func TestPlanLimitError(t *testing.T) {
+	cases := []struct {
+		e     Error
+		limit int
+		match bool
+	}{
+		{Error{Code: "sources.quota.exceeded", Message: "Source quota exceeded"}, 0, true},
+		{Error{Code: "source.invalid", Message: "The maximum of 1000 sources per collector has been reached"}, 1000, true},
+		{Error{Code: "collector.name.duplicate", Message: "A collector with this name already exists"}, 0, false},
+		{Error{Code: "source.invalid", Message: "Message per request must not exceed the limit"}, 0, false},
+	}
+	for _, tc := range cases {
+		err := planLimitError(ResourceTypeSource, &tc.e)
+		if (err != nil) != tc.match {
+			t.Errorf("planLimitError(%+v) expected a match: %t, got %v", tc.e, tc.match, err)
+			continue
+		}
+		if limitErr, ok := err.(*PlanLimitError); ok && limitErr.Limit != tc.limit {
+			t.Errorf("planLimitError(%+v) expected limit %d, got %d", tc.e, tc.limit, limitErr.Limit)
+		}
+	}
+}
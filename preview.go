@@ -0,0 +1,127 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// UpdatePreview is the change an Update of a resource would make, as returned by
+// PreviewUpdate.
+type UpdatePreview struct {
+	// Live is the resource's current definition.
+	Live json.RawMessage
+	// ETag is the ETag of Live. Pass it to the Update to apply exactly the previewed change.
+	ETag string
+	// Patch is the JSON merge patch (RFC 7386) from Live to the definition the Update would
+	// send: changed fields hold their new value and fields the Update would reset are null.
+	// It's `{}` if the Update wouldn't change anything.
+	Patch json.RawMessage
+}
+
+// Empty reports whether the Update wouldn't change anything.
+func (p UpdatePreview) Empty() bool {
+	return string(p.Patch) == "{}"
+}
+
+// previewServerFields are populated by Sumo Logic, so they're missing from the definitions
+// sent by Updates without being reset by them.
+var previewServerFields = []string{
+	"id", "links", "collectorVersion", "lastSeenAlive", "alive", "osName", "osVersion", "osArch",
+	"CollectorId", "url", "token",
+}
+
+// PreviewUpdate fetches the live definition of resource, a collector or source, and returns
+// the JSON merge patch the corresponding Update would apply to it, without sending the
+// Update, so that the exact change can be reviewed first.
+func (s *Client) PreviewUpdate(resource Resource) (*UpdatePreview, error) {
+	key := resource.ResourceType()
+	if key != ResourceTypeCollector && key != ResourceTypeSource {
+		return nil, fmt.Errorf("Updates of %s resources can't be previewed", key)
+	}
+	if resource.GetID() == "" {
+		return nil, &ValidationError{Field: "id", Message: "is required to preview an update"}
+	}
+
+	switch r := resource.(type) {
+	case HTTPSource:
+		unmaskHTTPSource(&r)
+		resource = r
+	case *HTTPSource:
+		source := *r
+		unmaskHTTPSource(&source)
+		resource = source
+	}
+	desired, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	path := resource.Endpoint()
+	if path == "" {
+		return nil, &ValidationError{Field: "collectorId", Message: "must be a positive integer to preview an update of a source"}
+	}
+	var live map[string]json.RawMessage
+	resp, err := s.do("GET", path, nil, &live)
+	if err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, notFoundError(key))
+	}
+	patch, err := mergePatch(live[key], desired, previewServerFields)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatePreview{Live: live[key], ETag: resp.Header.Get("ETag"), Patch: patch}, nil
+}
+
+// notFoundError is the error returned when a resource of type resourceType doesn't exist.
+func notFoundError(resourceType string) error {
+	if resourceType == ResourceTypeSource {
+		return ErrSourceNotFound
+	}
+	return ErrCollectorNotFound
+}
+
+// mergePatch returns the JSON merge patch that turns the object original into modified.
+// Top-level fields named in ignore are left out of the patch.
+func mergePatch(original, modified []byte, ignore []string) (json.RawMessage, error) {
+	var from, to map[string]interface{}
+	if err := json.Unmarshal(original, &from); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &to); err != nil {
+		return nil, err
+	}
+	for _, field := range ignore {
+		delete(from, field)
+		delete(to, field)
+	}
+	return json.Marshal(diffObjects(from, to))
+}
+
+func diffObjects(from, to map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for name, value := range to {
+		old, ok := from[name]
+		if !ok {
+			patch[name] = value
+			continue
+		}
+		oldObject, oldIsObject := old.(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		switch {
+		case oldIsObject && isObject:
+			if nested := diffObjects(oldObject, object); len(nested) > 0 {
+				patch[name] = nested
+			}
+		case !reflect.DeepEqual(old, value):
+			patch[name] = value
+		}
+	}
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			patch[name] = nil
+		}
+	}
+	return patch
+}
@@ -0,0 +1,88 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPreviewUpdate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/collectors/1/sources/2" {
+			t.Errorf("Expected request to ‘/collectors/1/sources/2’, got ‘%s’", r.URL.EscapedPath())
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"source":{"id":2,"name":"http","sourceType":"HTTP","category":"old","alive":true,"url":"https://collectors.sumologic.com/receiver/v1/http/secret","fields":{"env":"prod","team":"web"},"filters":[{"name":"drop","filterType":"Exclude","regexp":".*debug.*"}]}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source := HTTPSource{
+		ID:          2,
+		CollectorID: 1,
+		Name:        "http",
+		SourceType:  "HTTP",
+		Category:    "new",
+		Url:         "https://collectors.sumologic.com/receiver/v1/http/" + maskedSecret,
+		Fields:      map[string]string{"env": "prod", "team": "api"},
+	}
+	preview, err := c.PreviewUpdate(source)
+	if err != nil {
+		t.Errorf("PreviewUpdate() returned an error: %s", err)
+		return
+	}
+	expected := `{"category":"new","fields":{"team":"api"},"filters":null}`
+	if string(preview.Patch) != expected {
+		t.Errorf("PreviewUpdate() expected patch ‘%s’, got ‘%s’", expected, preview.Patch)
+	}
+	if preview.ETag != `"1"` || preview.Empty() {
+		t.Errorf("PreviewUpdate() expected ETag ‘\"1\"’ and a non-empty patch, got ‘%s’", preview.ETag)
+	}
+}
+
+func TestPreviewUpdateUnchanged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"collector":{"id":1234567890,"name":"test","collectorType":"Hosted","alive":true,"links":[{"rel":"sources","href":"/v1/collectors/1234567890/sources"}]}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	preview, err := c.PreviewUpdate(defaultCollector)
+	if err != nil {
+		t.Errorf("PreviewUpdate() returned an error: %s", err)
+		return
+	}
+	if !preview.Empty() {
+		t.Errorf("PreviewUpdate() expected an empty patch, got ‘%s’", preview.Patch)
+	}
+}
+
+func TestPreviewUpdateUnsupported(t *testing.T) {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.PreviewUpdate(Role{ID: "1"}); err == nil {
+		t.Errorf("PreviewUpdate() expected an error for a role")
+	}
+	if _, err := c.PreviewUpdate(Collector{Name: "new"}); err == nil {
+		t.Errorf("PreviewUpdate() expected an error for a collector without an ID")
+	}
+	if _, err := c.PreviewUpdate(HTTPSource{ID: 2, Name: "http"}); err == nil {
+		t.Errorf("PreviewUpdate() expected an error for a source without a collector ID")
+	} else if e, ok := err.(*ValidationError); !ok || e.Field != "collectorId" {
+		t.Errorf("PreviewUpdate() expected a collectorId ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package sumologic
+
+import (
+	"fmt"
+)
+
+// ApplyCollectorDefaults sets the category and time zone of source, which must be an
+// *HTTPSource or *AWSLogSource, to those of collector where the source leaves them unset.
+// Sumo Logic applies the same inheritance when ingesting, but only implicitly: an unset
+// source time zone otherwise reads back as empty, or as a server-side default, rather than
+// as the collector's.
+func ApplyCollectorDefaults(source interface{}, collector Collector) error {
+	switch source := source.(type) {
+	case *HTTPSource:
+		inheritCollectorDefaults(&source.Category, &source.TimeZone, collector)
+	case *AWSLogSource:
+		inheritCollectorDefaults(&source.Category, &source.TimeZone, collector)
+	default:
+		return fmt.Errorf("Collector defaults can't be applied to %T", source)
+	}
+	return nil
+}
+
+func inheritCollectorDefaults(category, timeZone *string, collector Collector) {
+	if *category == "" {
+		*category = collector.Category
+	}
+	if *timeZone == "" {
+		*timeZone = collector.TimeZone
+	}
+}
+
+// InheritCollectorDefaults wraps source so that CreateCollectorWithSources applies the
+// category and time zone of the created collector to it with ApplyCollectorDefaults.
+func InheritCollectorDefaults(source SourceSpec) SourceSpec {
+	return inheritingSourceSpec{source}
+}
+
+type inheritingSourceSpec struct {
+	SourceSpec
+}
+
+func (spec inheritingSourceSpec) createOn(s *Client, collector Collector) (int, error) {
+	switch source := spec.SourceSpec.(type) {
+	case HTTPSource:
+		inheritCollectorDefaults(&source.Category, &source.TimeZone, collector)
+		return source.createOn(s, collector)
+	case AWSLogSource:
+		inheritCollectorDefaults(&source.Category, &source.TimeZone, collector)
+		return source.createOn(s, collector)
+	default:
+		return spec.SourceSpec.createOn(s, collector)
+	}
+}
+
+// SourceSpec is a source that can be created on a collector, such as HTTPSource or AWSLogSource.
+type SourceSpec interface {
+	createOn(s *Client, collector Collector) (int, error)
+}
+
+func (source HTTPSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateHTTPSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+func (source AWSLogSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateAWSLogSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// CreateCollectorWithSources creates a hosted collector and then each of the sources on it,
+// returning the collector and the IDs of the created sources.
+// If a source can't be created and rollback is true, the collector (and with it any sources
+// already created) is deleted again so no half-provisioned collector is left behind.
+// Without rollback, the collector and the IDs of the sources created so far are returned
+// alongside the error.
+func (s *Client) CreateCollectorWithSources(collector Collector, sources []SourceSpec, rollback bool) (*Collector, []int, error) {
+	created, err := s.CreateHostedCollector(collector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sourceIDs := make([]int, 0, len(sources))
+	for i, source := range sources {
+		id, err := source.createOn(s, *created)
+		if err != nil {
+			err = fmt.Errorf("Source %d could not be created on collector `%d`: %w", i, created.ID, err)
+			if !rollback {
+				return created, sourceIDs, err
+			}
+			if deleteErr := s.DeleteHostedCollector(created.ID); deleteErr != nil {
+				return nil, nil, fmt.Errorf("%w; rollback of collector `%d` failed: %s", err, created.ID, deleteErr)
+			}
+			return nil, nil, err
+		}
+		sourceIDs = append(sourceIDs, id)
+	}
+
+	return created, sourceIDs, nil
+}
@@ -0,0 +1,205 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// provisioningServer creates collectors and sources, rejecting sources named `bad` and
+// `limited`, the latter for exceeding the plan's limit.
+func provisioningServer(t *testing.T, deleted *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/collectors":
+			body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		case r.Method == "POST" && r.URL.EscapedPath() == fmt.Sprintf("/collectors/%d/sources", defaultCollector.ID):
+			body, _ := io.ReadAll(r.Body)
+			sr := new(HTTPSourceRequest)
+			json.Unmarshal(body, &sr)
+			if sr.Source.Name == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if sr.Source.Name == "limited" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"status":400,"code":"sources.limit.reached","message":"Maximum number of sources (1) reached."}`))
+				return
+			}
+			sr.Source.ID = 1234567890
+			body, _ = json.Marshal(sr)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == fmt.Sprintf("/collectors/%d", defaultCollector.ID):
+			*deleted = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+}
+
+func TestCreateCollectorWithSourcesOK(t *testing.T) {
+	deleted := false
+	ts := provisioningServer(t, &deleted)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, sourceIDs, err := c.CreateCollectorWithSources(Collector{Name: "test"}, []SourceSpec{
+		HTTPSource{Name: "http"},
+		AWSLogSource{Name: "cloudtrail"},
+	}, true)
+	if err != nil {
+		t.Errorf("CreateCollectorWithSources() returned an error: %s", err)
+		return
+	}
+	if collector.ID != defaultCollector.ID {
+		t.Errorf("CreateCollectorWithSources() expected collector ID `%d`, got `%d`", defaultCollector.ID, collector.ID)
+	}
+	if len(sourceIDs) != 2 {
+		t.Errorf("CreateCollectorWithSources() expected 2 source IDs, got %v", sourceIDs)
+	}
+	if deleted {
+		t.Errorf("CreateCollectorWithSources() deleted the collector after success")
+	}
+}
+
+func TestCreateCollectorWithSourcesRollback(t *testing.T) {
+	deleted := false
+	ts := provisioningServer(t, &deleted)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, _, err = c.CreateCollectorWithSources(Collector{Name: "test"}, []SourceSpec{
+		HTTPSource{Name: "http"},
+		HTTPSource{Name: "bad"},
+	}, true)
+	if err == nil {
+		t.Errorf("CreateCollectorWithSources() did not return an error")
+	}
+	if !deleted {
+		t.Errorf("CreateCollectorWithSources() did not roll back the collector")
+	}
+}
+
+func TestCreateCollectorWithSourcesNoRollback(t *testing.T) {
+	deleted := false
+	ts := provisioningServer(t, &deleted)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, sourceIDs, err := c.CreateCollectorWithSources(Collector{Name: "test"}, []SourceSpec{
+		HTTPSource{Name: "http"},
+		HTTPSource{Name: "bad"},
+	}, false)
+	if err == nil {
+		t.Errorf("CreateCollectorWithSources() did not return an error")
+	}
+	if deleted {
+		t.Errorf("CreateCollectorWithSources() rolled back without rollback enabled")
+	}
+	if collector == nil || len(sourceIDs) != 1 {
+		t.Errorf("CreateCollectorWithSources() expected the collector and 1 source ID, got %v and %v", collector, sourceIDs)
+	}
+}
+
+func TestCreateCollectorWithSourcesPlanLimit(t *testing.T) {
+	deleted := false
+	ts := provisioningServer(t, &deleted)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, _, err = c.CreateCollectorWithSources(Collector{Name: "test"}, []SourceSpec{
+		HTTPSource{Name: "limited"},
+	}, true)
+	var limitErr *PlanLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != 1 {
+		t.Errorf("CreateCollectorWithSources() expected the source's PlanLimitError, got %v", err)
+	}
+	if !deleted {
+		t.Errorf("CreateCollectorWithSources() did not roll back the collector")
+	}
+}
+
+func TestApplyCollectorDefaults(t *testing.T) {
+	collector := Collector{Category: "prod/app", TimeZone: "America/New_York"}
+
+	source := HTTPSource{Name: "http", TimeZone: "UTC"}
+	if err := ApplyCollectorDefaults(&source, collector); err != nil {
+		t.Errorf("ApplyCollectorDefaults() returned an error: %s", err)
+		return
+	}
+	if source.Category != "prod/app" || source.TimeZone != "UTC" {
+		t.Errorf("ApplyCollectorDefaults() expected only unset fields to be inherited, got %+v", source)
+	}
+
+	aws := AWSLogSource{Name: "aws"}
+	ApplyCollectorDefaults(&aws, collector)
+	if aws.Category != "prod/app" || aws.TimeZone != "America/New_York" {
+		t.Errorf("ApplyCollectorDefaults() expected the collector's defaults, got %+v", aws)
+	}
+
+	if err := ApplyCollectorDefaults(source, collector); err == nil {
+		t.Errorf("ApplyCollectorDefaults() did not return an error for a non-pointer source")
+	}
+}
+
+func TestCreateCollectorWithSourcesInheritDefaults(t *testing.T) {
+	collector := Collector{ID: 1234567890, Name: "test", Category: "prod/app", TimeZone: "America/New_York"}
+	var categories []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/collectors" {
+			body, _ := json.Marshal(CollectorRequest{Collector: collector})
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+			return
+		}
+		sr := new(HTTPSourceRequest)
+		json.NewDecoder(r.Body).Decode(&sr)
+		categories = append(categories, sr.Source.Category+" "+sr.Source.TimeZone)
+		sr.Source.ID = 1
+		body, _ := json.Marshal(sr)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	_, _, err := c.CreateCollectorWithSources(collector, []SourceSpec{
+		InheritCollectorDefaults(HTTPSource{Name: "inherits"}),
+		HTTPSource{Name: "doesn't inherit"},
+	}, true)
+	if err != nil {
+		t.Errorf("CreateCollectorWithSources() returned an error: %s", err)
+		return
+	}
+	if len(categories) != 2 || categories[0] != "prod/app America/New_York" || categories[1] != " " {
+		t.Errorf("Expected only the wrapped source to inherit the collector's defaults, got %q", categories)
+	}
+}
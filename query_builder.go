@@ -0,0 +1,154 @@
+package sumologic
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// QueryBuilder builds a Sumo Logic search query from a scope and a pipeline of operators,
+// quoting values as needed, for use as SearchJobRequest.Query. Methods return the builder
+// so calls can be chained:
+//
+//	query := sumologic.NewQueryBuilder().
+//		SourceCategory("prod/web/nginx").
+//		Parse(`"GET * HTTP/1.1" *`, "path", "status").
+//		Where("status >= 500").
+//		Timeslice(5 * time.Minute).
+//		Count("_timeslice", "path").
+//		String()
+type QueryBuilder struct {
+	scope []string
+	steps []string
+}
+
+// NewQueryBuilder returns an empty QueryBuilder. Its query matches all messages.
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// SourceCategory limits the scope to messages with the source category, which may contain
+// `*` wildcards.
+func (q *QueryBuilder) SourceCategory(category string) *QueryBuilder {
+	return q.Metadata("_sourceCategory", category)
+}
+
+// Metadata limits the scope to messages with a metadata field (e.g. `_collector` or
+// `_sourceHost`) matching value, which may contain `*` wildcards.
+func (q *QueryBuilder) Metadata(field, value string) *QueryBuilder {
+	q.scope = append(q.scope, field+"="+quoteQueryValue(value))
+	return q
+}
+
+// Keyword limits the scope to messages containing the keyword.
+func (q *QueryBuilder) Keyword(keyword string) *QueryBuilder {
+	q.scope = append(q.scope, quoteQueryValue(keyword))
+	return q
+}
+
+// Parse adds a parse operator extracting fields from the wildcards in pattern, in order.
+// pattern is used as is, so literal text in it must be quoted as in the query language.
+func (q *QueryBuilder) Parse(pattern string, fields ...string) *QueryBuilder {
+	return q.Step(fmt.Sprintf("parse %s as %s", pattern, strings.Join(fields, ", ")))
+}
+
+// ParseRegex adds a parse regex operator extracting the named capture groups in pattern.
+func (q *QueryBuilder) ParseRegex(pattern string) *QueryBuilder {
+	return q.Step("parse regex " + quoteQueryString(pattern))
+}
+
+// JSON adds a json operator extracting fields from JSON messages.
+func (q *QueryBuilder) JSON(fields ...string) *QueryBuilder {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = quoteQueryString(field)
+	}
+	return q.Step("json " + strings.Join(quoted, ", "))
+}
+
+// Where adds a where operator keeping messages matching condition, e.g. `status >= 500`.
+func (q *QueryBuilder) Where(condition string) *QueryBuilder {
+	return q.Step("where " + condition)
+}
+
+// Timeslice adds a timeslice operator grouping messages into buckets of d.
+func (q *QueryBuilder) Timeslice(d time.Duration) *QueryBuilder {
+	return q.Step("timeslice " + formatQueryDuration(d))
+}
+
+// Count adds a count operator, grouped by the fields if any are given.
+func (q *QueryBuilder) Count(by ...string) *QueryBuilder {
+	return q.aggregate("count", by)
+}
+
+// Sum adds a sum operator for field, grouped by the fields if any are given.
+func (q *QueryBuilder) Sum(field string, by ...string) *QueryBuilder {
+	return q.aggregate(fmt.Sprintf("sum(%s)", field), by)
+}
+
+// Sort adds a sort operator ordering results by field, descending unless ascending is true.
+func (q *QueryBuilder) Sort(field string, ascending bool) *QueryBuilder {
+	if ascending {
+		return q.Step(fmt.Sprintf("sort by %s asc", field))
+	}
+	return q.Step(fmt.Sprintf("sort by %s", field))
+}
+
+// Limit adds a limit operator returning at most n results.
+func (q *QueryBuilder) Limit(n int) *QueryBuilder {
+	return q.Step(fmt.Sprintf("limit %d", n))
+}
+
+// Step adds an operator that the builder doesn't support, in query language syntax.
+func (q *QueryBuilder) Step(operator string) *QueryBuilder {
+	q.steps = append(q.steps, operator)
+	return q
+}
+
+// String renders the query.
+func (q *QueryBuilder) String() string {
+	scope := "*"
+	if len(q.scope) > 0 {
+		scope = strings.Join(q.scope, " AND ")
+	}
+	return strings.Join(append([]string{scope}, q.steps...), " | ")
+}
+
+func (q *QueryBuilder) aggregate(function string, by []string) *QueryBuilder {
+	if len(by) == 0 {
+		return q.Step(function)
+	}
+	return q.Step(fmt.Sprintf("%s by %s", function, strings.Join(by, ", ")))
+}
+
+// unquotedQueryValue matches values that can be used in a query without quotes.
+var unquotedQueryValue = regexp.MustCompile(`^[A-Za-z0-9_./*-]+$`)
+
+// quoteQueryValue quotes a scope value unless it only contains safe characters, so that
+// `*` wildcards in unquoted values keep working.
+func quoteQueryValue(value string) string {
+	if unquotedQueryValue.MatchString(value) {
+		return value
+	}
+	return quoteQueryString(value)
+}
+
+// quoteQueryString quotes s as a query language string literal.
+func quoteQueryString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// formatQueryDuration formats d in the largest whole unit supported by the query language.
+func formatQueryDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
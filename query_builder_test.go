@@ -0,0 +1,58 @@
+package sumologic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	cases := []struct {
+		query    *QueryBuilder
+		expected string
+	}{
+		{NewQueryBuilder(), `*`},
+		{NewQueryBuilder().SourceCategory("prod/web/*"), `_sourceCategory=prod/web/*`},
+		{
+			NewQueryBuilder().SourceCategory("prod/web/nginx").Keyword("GET").
+				Parse(`"GET * HTTP/1.1" *`, "path", "status").
+				Where("status >= 500").
+				Timeslice(5*time.Minute).
+				Count("_timeslice", "path").
+				Sort("_count", false),
+			`_sourceCategory=prod/web/nginx AND GET | parse "GET * HTTP/1.1" * as path, status | where status >= 500 | timeslice 5m | count by _timeslice, path | sort by _count`,
+		},
+		{
+			NewQueryBuilder().Metadata("_collector", `my "collector"`).Keyword("error OR warn"),
+			`_collector="my \"collector\"" AND "error OR warn"`,
+		},
+		{
+			NewQueryBuilder().JSON("user.id", "bytes").Sum("bytes", "user.id").Limit(10),
+			`* | json "user.id", "bytes" | sum(bytes) by user.id | limit 10`,
+		},
+		{
+			NewQueryBuilder().ParseRegex(`(?<ip>\d+\.\d+\.\d+\.\d+)`).Timeslice(24 * time.Hour).Count(),
+			`* | parse regex "(?<ip>\\d+\\.\\d+\\.\\d+\\.\\d+)" | timeslice 1d | count`,
+		},
+	}
+
+	for _, c := range cases {
+		if query := c.query.String(); query != c.expected {
+			t.Errorf("String() expected ‘%s’, got ‘%s’", c.expected, query)
+		}
+	}
+}
+
+func TestFormatQueryDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second: "30s",
+		90 * time.Second: "90s",
+		15 * time.Minute: "15m",
+		2 * time.Hour:    "2h",
+		48 * time.Hour:   "2d",
+	}
+	for d, expected := range cases {
+		if formatted := formatQueryDuration(d); formatted != expected {
+			t.Errorf("formatQueryDuration(%s) expected ‘%s’, got ‘%s’", d, expected, formatted)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited matches, with errors.Is, the *APIError returned for a 429 response. Its
+// Metadata says when the rate limit resets.
+var ErrRateLimited = errors.New("Rate limit exceeded with Sumo Logic")
+
+// ResponseMetadata holds the rate limit headers of an API response, so callers can throttle
+// themselves. Fields are zero if the response didn't have the header.
+type ResponseMetadata struct {
+	// RateLimitLimit is the number of requests allowed in the current window
+	// (X-RateLimit-Limit).
+	RateLimitLimit int
+	// RateLimitRemaining is the number of requests left in the current window
+	// (X-RateLimit-Remaining).
+	RateLimitRemaining int
+	// RateLimitReset is when the current window ends (X-RateLimit-Reset).
+	RateLimitReset time.Time
+	// RetryAfter is how long to wait before retrying a 429 response (Retry-After).
+	RetryAfter time.Duration
+}
+
+// HasRateLimit reports whether the response had rate limit headers.
+func (m ResponseMetadata) HasRateLimit() bool {
+	return m.RateLimitLimit != 0 || m.RateLimitRemaining != 0 || !m.RateLimitReset.IsZero()
+}
+
+// parseResponseMetadata reads the rate limit headers of a response received at now. A reset
+// of up to a day is taken as seconds until the reset, and a larger one as Unix seconds.
+func parseResponseMetadata(header http.Header, now time.Time) ResponseMetadata {
+	m := ResponseMetadata{
+		RateLimitLimit:     headerInt(header, "X-RateLimit-Limit"),
+		RateLimitRemaining: headerInt(header, "X-RateLimit-Remaining"),
+		RetryAfter:         retryAfter(header),
+	}
+	if reset := headerInt(header, "X-RateLimit-Reset"); reset > 24*60*60 {
+		m.RateLimitReset = time.Unix(int64(reset), 0).UTC()
+	} else if reset > 0 {
+		m.RateLimitReset = now.Add(time.Duration(reset) * time.Second).UTC()
+	}
+	return m
+}
+
+// headerInt returns the header parsed as a non-negative integer, or 0 if it's missing or
+// invalid.
+func headerInt(header http.Header, name string) int {
+	n, err := strconv.Atoi(header.Get(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Metadata returns the rate limit headers of the response.
+func (r *Response) Metadata() ResponseMetadata {
+	return parseResponseMetadata(r.Header, time.Now())
+}
+
+// rateLimitState holds the rate limit headers of the latest response that had them. The
+// zero value is ready to use.
+type rateLimitState struct {
+	mu       sync.Mutex
+	metadata ResponseMetadata
+}
+
+func (r *rateLimitState) record(resp *http.Response) {
+	m := parseResponseMetadata(resp.Header, time.Now())
+	if !m.HasRateLimit() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metadata = m
+}
+
+// RateLimit returns the rate limit headers of the latest response received by the client, or
+// by any client derived from it with WithAuth, that had them.
+func (s *Client) RateLimit() ResponseMetadata {
+	r := &s.root().rateLimit
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.metadata
+}
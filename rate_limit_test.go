@@ -0,0 +1,76 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseResponseMetadata(t *testing.T) {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "240")
+	header.Set("X-RateLimit-Remaining", "17")
+	header.Set("X-RateLimit-Reset", "30")
+	m := parseResponseMetadata(header, now)
+	if m.RateLimitLimit != 240 || m.RateLimitRemaining != 17 || !m.RateLimitReset.Equal(now.Add(30*time.Second)) {
+		t.Errorf("parseResponseMetadata() returned ‘%+v’", m)
+	}
+
+	header.Set("X-RateLimit-Reset", "1588338000")
+	if m := parseResponseMetadata(header, now); !m.RateLimitReset.Equal(time.Unix(1588338000, 0)) {
+		t.Errorf("parseResponseMetadata() expected an epoch reset, got ‘%s’", m.RateLimitReset)
+	}
+
+	if m := parseResponseMetadata(http.Header{}, now); m.HasRateLimit() {
+		t.Errorf("parseResponseMetadata() expected no rate limit without headers, got ‘%+v’", m)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "240")
+		switch r.URL.Path {
+		case "/throttled":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status":429,"code":"rate.limit.exceeded","message":"Rate limit exceeded"}`))
+		default:
+			w.Header().Set("X-RateLimit-Remaining", "239")
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.RateLimit().HasRateLimit() {
+		t.Errorf("RateLimit() expected no rate limit before any request")
+	}
+
+	if _, err := c.WithAuth("other").do("GET", "collectors", nil, nil); err != nil {
+		t.Errorf("do() returned an error: %s", err)
+		return
+	}
+	if m := c.RateLimit(); m.RateLimitLimit != 240 || m.RateLimitRemaining != 239 {
+		t.Errorf("RateLimit() returned ‘%+v’", m)
+	}
+
+	_, err = c.do("GET", "throttled", nil, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("do() expected ErrRateLimited for 429, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Metadata.RetryAfter != 5*time.Second || apiErr.Metadata.RateLimitRemaining != 0 {
+		t.Errorf("do() expected the 429 metadata, got %v", err)
+	}
+	if m := c.RateLimit(); m.RateLimitRemaining != 0 || m.RetryAfter != 5*time.Second {
+		t.Errorf("RateLimit() expected the latest response, got ‘%+v’", m)
+	}
+}
@@ -0,0 +1,99 @@
+package sumologic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// redactionSegment is a field of a redaction path. If each is true, the rest of the path is
+// applied to every element of the field's array.
+type redactionSegment struct {
+	name string
+	each bool
+}
+
+// WithChangeRedaction redacts the values at the given JSON paths from the After of every change
+// passed to the ChangeRecorder and the Payload of every Mutation passed to mutation hooks, so a
+// change journal or hook never sees credentials. Paths are dot-separated JSON field names, and `[]` after a name applies the rest
+// of the path to every element of an array, e.g. `thirdPartyRef.resources[].authentication`.
+// Redacted values are replaced with `********`; paths that don't exist are ignored.
+func WithChangeRedaction(paths ...string) ClientOption {
+	return func(s *Client) error {
+		for _, path := range paths {
+			segments, err := parseRedactionPath(path)
+			if err != nil {
+				return err
+			}
+			s.changeRedactions = append(s.changeRedactions, segments)
+		}
+		return nil
+	}
+}
+
+func parseRedactionPath(path string) ([]redactionSegment, error) {
+	var segments []redactionSegment
+	for _, name := range strings.Split(path, ".") {
+		segment := redactionSegment{name: strings.TrimSuffix(name, "[]")}
+		segment.each = segment.name != name
+		if segment.name == "" || strings.ContainsAny(segment.name, "[]") {
+			return nil, &ValidationError{Field: "redactionPath", Message: fmt.Sprintf("`%s` must be dot-separated field names, e.g. `thirdPartyRef.resources[].authentication`", path)}
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// redact returns data with the client's change redaction paths masked. data is returned
+// unchanged if there's nothing to redact or it can't be decoded.
+func (s *Client) redact(data json.RawMessage) json.RawMessage {
+	if len(s.changeRedactions) == 0 || data == nil {
+		return data
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return data
+	}
+	for _, segments := range s.changeRedactions {
+		redactPath(v, segments)
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redactPath(v interface{}, segments []redactionSegment) {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	segment := segments[0]
+	value, ok := object[segment.name]
+	if !ok || value == nil {
+		return
+	}
+	if !segment.each {
+		if len(segments) == 1 {
+			object[segment.name] = maskedSecret
+		} else {
+			redactPath(value, segments[1:])
+		}
+		return
+	}
+	elements, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+	for i := range elements {
+		if len(segments) == 1 {
+			elements[i] = maskedSecret
+		} else {
+			redactPath(elements[i], segments[1:])
+		}
+	}
+}
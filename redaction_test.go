@@ -0,0 +1,60 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithChangeRedaction(t *testing.T) {
+	recorder := &memoryRecorder{}
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithChangeRecorder(recorder),
+		WithChangeRedaction("thirdPartyRef.resources[].authentication", "url", "missing.field"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source := ALBAccessLogsSource(AWSBucketTemplate{
+		Name:       "alb",
+		BucketName: "bucket",
+		RoleARN:    "arn:aws:iam::123456789012:role/SumoLogic",
+	})
+	source.ID = 1
+	source.Url = "https://endpoint.collection.sumologic.com/receiver/v1/http/secret"
+	c.recordChange(ChangeCreate, ResourceTypeSource, source.ID, nil, source)
+
+	if len(recorder.changes) != 1 {
+		t.Errorf("recordChange() expected one change, got %d", len(recorder.changes))
+		return
+	}
+	var after struct {
+		Name          string `json:"name"`
+		Url           string `json:"url"`
+		ThirdPartyRef struct {
+			Resources []struct {
+				Path           AWSBucketPath `json:"path"`
+				Authentication interface{}   `json:"authentication"`
+			} `json:"resources"`
+		} `json:"thirdPartyRef"`
+	}
+	if err := json.Unmarshal(recorder.changes[0].After, &after); err != nil {
+		t.Errorf("json.Unmarshal() returned an error: %s", err)
+		return
+	}
+	if after.Url != maskedSecret || after.ThirdPartyRef.Resources[0].Authentication != maskedSecret {
+		t.Errorf("recordChange() expected the URL and authentication to be redacted, got %s", recorder.changes[0].After)
+	}
+	if after.Name != "alb" || after.ThirdPartyRef.Resources[0].Path.BucketName != "bucket" {
+		t.Errorf("recordChange() expected the rest of the source to be kept, got %s", recorder.changes[0].After)
+	}
+}
+
+func TestWithChangeRedactionInvalidPath(t *testing.T) {
+	for _, path := range []string{"", "a..b", "resources[0].authentication", "[]"} {
+		_, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithChangeRedaction(path))
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != "redactionPath" {
+			t.Errorf("WithChangeRedaction(%s) expected a redactionPath ValidationError, got %v", path, err)
+		}
+	}
+}
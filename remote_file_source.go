@@ -0,0 +1,239 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RemoteFileSourceRequest is a necessary wrapper for source API calls.
+type RemoteFileSourceRequest struct {
+	Source RemoteFileSource `json:"source"`
+}
+
+// RemoteFileSource is a source on an installed collector that reads log files on remote
+// hosts over SSH.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type RemoteFileSource struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	CollectorID int    `json:"CollectorId,omitempty"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	HostName    string `json:"hostName,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+	SourceType  string `json:"sourceType,omitempty"`
+	// RemoteHosts are the hosts to read the files from.
+	RemoteHosts []string `json:"remoteHosts"`
+	// RemotePort is the SSH port of the remote hosts, usually 22.
+	RemotePort int    `json:"remotePort"`
+	RemoteUser string `json:"remoteUser"`
+	// AuthMethod is RemoteFileAuthMethodKey or RemoteFileAuthMethodPassword.
+	AuthMethod string `json:"authMethod"`
+	// RemotePassword is the SSH password, for RemoteFileAuthMethodPassword.
+	RemotePassword string `json:"remotePassword,omitempty"`
+	// KeyPath is the path of the SSH private key on the collector's host, for
+	// RemoteFileAuthMethodKey.
+	KeyPath     string `json:"keyPath,omitempty"`
+	KeyPassword string `json:"keyPassword,omitempty"`
+	// PathExpression is the path of the files to read on the remote hosts, which may use
+	// wildcards, e.g. `/var/log/nginx/*.log`.
+	PathExpression string `json:"pathExpression"`
+	// Blacklist lists path expressions of files to skip, e.g. `/var/log/nginx/*.gz`.
+	Blacklist []string `json:"blacklist,omitempty"`
+	// Encoding is the character set of the files, e.g. `UTF-8` (the default) or `UTF-16LE`.
+	Encoding                   string       `json:"encoding,omitempty"`
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	CutoffRelativeTime         string       `json:"cutoffRelativeTime,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	Alive                      bool         `json:"alive,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// Authentication methods of RemoteFileSource, for RemoteFileSource.AuthMethod.
+const (
+	RemoteFileAuthMethodKey      = "key"
+	RemoteFileAuthMethodPassword = "password"
+)
+
+// remoteFileSourceType is the sourceType of remote file sources.
+const remoteFileSourceType = "RemoteFileV2"
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s RemoteFileSource) MarshalJSON() ([]byte, error) {
+	type remoteFileSource RemoteFileSource
+	return marshalForceSend(remoteFileSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *RemoteFileSource) UnmarshalJSON(data []byte) error {
+	type remoteFileSource RemoteFileSource
+	return unmarshalLenient(data, (*remoteFileSource)(s))
+}
+
+// Validate checks the source's hosts, authentication and path expressions and the fields
+// validated for all sources, without calling the API.
+func (s RemoteFileSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	if len(s.RemoteHosts) == 0 {
+		return &ValidationError{Field: "remoteHosts", Message: "must list at least one host"}
+	}
+	if s.RemotePort < 1 || s.RemotePort > 65535 {
+		return &ValidationError{Field: "remotePort", Message: fmt.Sprintf("must be between 1 and 65535, got %d", s.RemotePort)}
+	}
+	if s.RemoteUser == "" {
+		return &ValidationError{Field: "remoteUser", Message: "must not be empty"}
+	}
+	switch s.AuthMethod {
+	case RemoteFileAuthMethodKey:
+		if s.KeyPath == "" {
+			return &ValidationError{Field: "keyPath", Message: fmt.Sprintf("must be set when authMethod is `%s`", RemoteFileAuthMethodKey)}
+		}
+	case RemoteFileAuthMethodPassword:
+	default:
+		return &ValidationError{Field: "authMethod", Message: fmt.Sprintf("must be `%s` or `%s`, got `%s`", RemoteFileAuthMethodKey, RemoteFileAuthMethodPassword, s.AuthMethod)}
+	}
+	if s.PathExpression == "" {
+		return &ValidationError{Field: "pathExpression", Message: "must not be empty"}
+	}
+	for _, path := range s.Blacklist {
+		if path == "" {
+			return &ValidationError{Field: "blacklist", Message: "must not contain empty path expressions"}
+		}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and liveness) are ignored.
+func (s RemoteFileSource) Equivalent(other RemoteFileSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s RemoteFileSource) userManaged() RemoteFileSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Alive = false
+	if len(s.Blacklist) == 0 {
+		s.Blacklist = nil
+	}
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s RemoteFileSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s RemoteFileSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s RemoteFileSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s RemoteFileSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetRemoteFileSource gets the source with the specified ID.
+func (s *Client) GetRemoteFileSource(collectorID int, id int) (*RemoteFileSource, string, error) {
+	var r = new(RemoteFileSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	return &r.Source, etag, nil
+}
+
+// CreateRemoteFileSource creates a new RemoteFileSource on an installed collector. An empty
+// SourceType is set to `RemoteFileV2`.
+func (s *Client) CreateRemoteFileSource(collectorID int, source RemoteFileSource) (*RemoteFileSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = remoteFileSourceType
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(RemoteFileSourceRequest)
+	if err := s.createSource(collectorID, source.Name, RemoteFileSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateRemoteFileSource updates an existing remote file source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateRemoteFileSource(collectorID int, source RemoteFileSource, etag string) (*RemoteFileSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(RemoteFileSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, RemoteFileSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteRemoteFileSource deletes the source with the specified ID.
+func (s *Client) DeleteRemoteFileSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteRemoteFileSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteRemoteFileSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteRemoteFileSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteRemoteFileSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteRemoteFileSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteRemoteFileSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
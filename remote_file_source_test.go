@@ -0,0 +1,76 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultRemoteFileSource = RemoteFileSource{
+	Name:           "remote",
+	RemoteHosts:    []string{"web1.example.com", "web2.example.com"},
+	RemotePort:     22,
+	RemoteUser:     "sumo",
+	AuthMethod:     RemoteFileAuthMethodKey,
+	KeyPath:        "/home/sumo/.ssh/id_rsa",
+	PathExpression: "/var/log/app.log",
+}
+
+func TestUpdateRemoteFileSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.Header.Get("If-Match") != "etag" {
+			t.Errorf("Expected a ‘PUT’ request with If-Match, got ‘%s’", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request RemoteFileSourceRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal RemoteFileSource, got `%s`", body)
+		}
+		if len(request.Source.RemoteHosts) != 2 || request.Source.AuthMethod != "key" {
+			t.Errorf("Expected the remote hosts and auth method to be sent, got %+v", request.Source)
+		}
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source := defaultRemoteFileSource
+	source.ID = 2
+	updated, err := c.UpdateRemoteFileSource(1, source, "etag")
+	if err != nil {
+		t.Errorf("UpdateRemoteFileSource() returned an error: %s", err)
+		return
+	}
+	if !updated.Equivalent(source) {
+		t.Errorf("UpdateRemoteFileSource() returned an unexpected source: %+v", updated)
+	}
+}
+
+func TestRemoteFileSourceValidate(t *testing.T) {
+	invalid := func(f func(*RemoteFileSource)) RemoteFileSource {
+		source := defaultRemoteFileSource
+		f(&source)
+		return source
+	}
+	cases := map[string]RemoteFileSource{
+		"remoteHosts":    invalid(func(s *RemoteFileSource) { s.RemoteHosts = nil }),
+		"remotePort":     invalid(func(s *RemoteFileSource) { s.RemotePort = 0 }),
+		"remoteUser":     invalid(func(s *RemoteFileSource) { s.RemoteUser = "" }),
+		"authMethod":     invalid(func(s *RemoteFileSource) { s.AuthMethod = "certificate" }),
+		"keyPath":        invalid(func(s *RemoteFileSource) { s.KeyPath = "" }),
+		"pathExpression": invalid(func(s *RemoteFileSource) { s.PathExpression = "" }),
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+
+	password := invalid(func(s *RemoteFileSource) { s.AuthMethod, s.KeyPath = RemoteFileAuthMethodPassword, "" })
+	if err := password.Validate(); err != nil {
+		t.Errorf("Validate() returned an error for password authentication: %s", err)
+	}
+}
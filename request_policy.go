@@ -0,0 +1,119 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// Resource types of requests for API resources that aren't a Resource, for
+// WithRequestPolicy.
+const (
+	ResourceTypeSearchJob   = "searchJob"
+	ResourceTypeContent     = "content"
+	ResourceTypeHealthEvent = "healthEvent"
+	ResourceTypeArchiveJob  = "archiveJob"
+)
+
+// RequestPolicy overrides the client's timeout and transient retry policy for the requests
+// of one type of resource. See WithRequestPolicy.
+type RequestPolicy struct {
+	// Timeout limits each request, including retries and reading the response. Zero keeps
+	// the client's timeout, which also still applies to every attempt (see WithTimeout), so
+	// raise that too to allow longer requests.
+	Timeout time.Duration
+	// Retry replaces the policy set with WithTransientRetry. Nil keeps the client's policy.
+	Retry *backoff.Policy
+}
+
+// WithRequestPolicy applies policy to the requests for resources of resourceType, one of
+// the ResourceType constants, so that e.g. collector CRUD can fail fast while search job
+// requests tolerate long waits.
+func WithRequestPolicy(resourceType string, policy RequestPolicy) ClientOption {
+	return func(s *Client) error {
+		if policy.Timeout < 0 {
+			return &ValidationError{Field: "timeout", Message: "must not be negative"}
+		}
+		if s.requestPolicies == nil {
+			s.requestPolicies = map[string]RequestPolicy{}
+		}
+		s.requestPolicies[resourceType] = policy
+		return nil
+	}
+}
+
+// requestPolicy returns the policy configured for the resource type of req, if any.
+func (s *Client) requestPolicy(req *http.Request) (RequestPolicy, bool) {
+	if len(s.requestPolicies) == 0 {
+		return RequestPolicy{}, false
+	}
+	policy, ok := s.requestPolicies[requestResourceType(req.URL.Path)]
+	return policy, ok
+}
+
+// withRequestTimeout applies the Timeout of the request policy of req, if any. The returned
+// cancel func must be called once the response has been read.
+func (s *Client) withRequestTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	policy, ok := s.requestPolicy(req)
+	if !ok || policy.Timeout == 0 {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), policy.Timeout)
+	return req.WithContext(ctx), cancel
+}
+
+// transientRetryPolicy returns the transient retry policy for req, or nil if it must not be
+// retried.
+func (s *Client) transientRetryPolicy(req *http.Request) *backoff.Policy {
+	if policy, ok := s.requestPolicy(req); ok && policy.Retry != nil {
+		return policy.Retry
+	}
+	return s.transientRetry
+}
+
+// apiVersionPattern matches the `api` and `vN` segments that prefix API paths.
+var apiVersionPattern = regexp.MustCompile(`^(api|v[0-9]+|\.\.)$`)
+
+// requestResourceType returns the type of the resource requested at path, or an empty
+// string if it's unknown.
+func requestResourceType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for len(segments) > 0 && apiVersionPattern.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return ""
+	}
+	switch segments[0] {
+	case "collectors":
+		if len(segments) > 2 && segments[2] == "sources" {
+			return ResourceTypeSource
+		}
+		return ResourceTypeCollector
+	case "search":
+		return ResourceTypeSearchJob
+	case "content":
+		return ResourceTypeContent
+	case "ingestBudgets":
+		return ResourceTypeIngestBudget
+	case "healthEvents":
+		return ResourceTypeHealthEvent
+	case "archive":
+		return ResourceTypeArchiveJob
+	case "organizations":
+		return ResourceTypeOrganization
+	case "users":
+		return ResourceTypeUser
+	case "roles":
+		return ResourceTypeRole
+	case "dashboards":
+		return ResourceTypeDashboard
+	case "scheduledViews":
+		return ResourceTypeScheduledView
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package sumologic
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestRequestResourceType(t *testing.T) {
+	paths := map[string]string{
+		"/api/v1/collectors":                      ResourceTypeCollector,
+		"/api/v1/collectors/1":                    ResourceTypeCollector,
+		"/api/v1/collectors/1/sources/2":          ResourceTypeSource,
+		"/api/v1/search/jobs/ABC":                 ResourceTypeSearchJob,
+		"/api/v2/content/0000000000000001/export": ResourceTypeContent,
+		"/api/v2/ingestBudgets":                   ResourceTypeIngestBudget,
+		"/api/v1/organizations/0000000000000001":  ResourceTypeOrganization,
+		"/api/v1/healthEvents/resources":          ResourceTypeHealthEvent,
+		"/api/v2/dashboards/9jS3sGpbBYNm":         ResourceTypeDashboard,
+		"/api/v1/scheduledViews/0000000000000C01": ResourceTypeScheduledView,
+		"/api/v1/archive/2/jobs":                  ResourceTypeArchiveJob,
+		"/collectors/1/sources":                   ResourceTypeSource,
+		"/api/v1/unknown":                         "",
+		"/":                                       "",
+	}
+	for path, expected := range paths {
+		if resourceType := requestResourceType(path); resourceType != expected {
+			t.Errorf("requestResourceType(%s) expected ‘%s’, got ‘%s’", path, expected, resourceType)
+		}
+	}
+}
+
+func TestWithRequestPolicyTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/collectors/1" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithRequestPolicy(ResourceTypeCollector, RequestPolicy{Timeout: 10 * time.Millisecond}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHostedCollector(1); err == nil {
+		t.Errorf("DeleteHostedCollector() expected a timeout error")
+	}
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+
+	if _, err := NewClient("accessToken", ts.URL, WithRequestPolicy(ResourceTypeCollector, RequestPolicy{Timeout: -time.Second})); err == nil {
+		t.Errorf("WithRequestPolicy() expected an error for a negative timeout")
+	}
+}
+
+func TestWithRequestPolicyRetry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	failures := 0
+	flaky := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if failures > 0 {
+				failures--
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+
+	c, err := NewClient("accessToken", ts.URL,
+		WithMiddleware(flaky),
+		WithRequestPolicy(ResourceTypeSource, RequestPolicy{Retry: &backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}}),
+	)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	failures = 2
+	if err := c.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() expected the source policy to retry, got %v", err)
+	}
+	failures = 1
+	if err := c.DeleteHostedCollector(1); !errors.Is(err, ErrTransient) {
+		t.Errorf("DeleteHostedCollector() expected a TransientError without a retry policy, got %v", err)
+	}
+}
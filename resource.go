@@ -0,0 +1,213 @@
+package sumologic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Resource types returned by Resource.ResourceType, and used as Change.ResourceType.
+const (
+	ResourceTypeCollector     = "collector"
+	ResourceTypeSource        = "source"
+	ResourceTypeOrganization  = "organization"
+	ResourceTypeUser          = "user"
+	ResourceTypeRole          = "role"
+	ResourceTypeDashboard     = "dashboard"
+	ResourceTypeScheduledView = "scheduledView"
+	ResourceTypeIngestBudget  = "ingestBudget"
+)
+
+// Resource is implemented by the Sumo Logic resources managed by the client, so that tools
+// such as exporters, differs and garbage collectors can handle them uniformly.
+type Resource interface {
+	// GetID returns the resource's ID, or an empty string if it hasn't been created yet.
+	// int IDs are formatted in decimal.
+	GetID() string
+	GetName() string
+	ResourceType() string
+	// Endpoint returns the resource's API path relative to the client's endpoint URL, or the
+	// path of the collection it's created in if it hasn't been created yet. It's empty if the
+	// resource has no valid path, e.g. a source without a collector ID.
+	Endpoint() string
+}
+
+var (
+	_ Resource = Collector{}
+	_ Resource = InstalledCollector{}
+	_ Resource = HTTPSource{}
+	_ Resource = AWSLogSource{}
+	_ Resource = SyslogSource{}
+	_ Resource = CloudSyslogSource{}
+	_ Resource = GCPSource{}
+	_ Resource = AWSMetadataSource{}
+	_ Resource = KinesisLogSource{}
+	_ Resource = LocalFileSource{}
+	_ Resource = RemoteFileSource{}
+	_ Resource = WindowsEventLogSource{}
+	_ Resource = Organization{}
+	_ Resource = User{}
+	_ Resource = Role{}
+	_ Resource = Dashboard{}
+	_ Resource = ScheduledView{}
+	_ Resource = IngestBudget{}
+	_ Resource = IngestBudgetV2{}
+	_ Resource = CloudToCloudSource{}
+	_ Resource = ArchiveIngestionJob{}
+)
+
+// intResourceID formats an int ID for Resource.GetID.
+func intResourceID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// resourceEndpoint appends id to a collection path for Resource.Endpoint.
+func resourceEndpoint(collection, id string) string {
+	if id == "" {
+		return collection
+	}
+	path, err := formatPath(collection+"/%s", id)
+	if err != nil {
+		return collection
+	}
+	return path
+}
+
+// sourceEndpoint is the Resource.Endpoint of a source on the collector with the specified ID.
+// It's empty if collectorID isn't a valid ID.
+func sourceEndpoint(collectorID, id int) string {
+	var path string
+	if id == 0 {
+		path, _ = formatPath("collectors/%d/sources", collectorID)
+	} else {
+		path, _ = formatPath("collectors/%d/sources/%d", collectorID, id)
+	}
+	return path
+}
+
+// GetID returns the collector's ID.
+func (c Collector) GetID() string { return intResourceID(c.ID) }
+
+// GetName returns the collector's name.
+func (c Collector) GetName() string { return c.Name }
+
+// ResourceType returns ResourceTypeCollector.
+func (c Collector) ResourceType() string { return ResourceTypeCollector }
+
+// Endpoint returns the collector's API path.
+func (c Collector) Endpoint() string { return resourceEndpoint("collectors", c.GetID()) }
+
+// GetID returns the source's ID.
+func (s HTTPSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s HTTPSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s HTTPSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s HTTPSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetID returns the source's ID.
+func (s AWSLogSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s AWSLogSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s AWSLogSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s AWSLogSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetID returns the organization's ID.
+func (o Organization) GetID() string { return o.OrgID }
+
+// GetName returns the organization's name.
+func (o Organization) GetName() string { return o.OrganizationName }
+
+// ResourceType returns ResourceTypeOrganization.
+func (o Organization) ResourceType() string { return ResourceTypeOrganization }
+
+// Endpoint returns the organization's API path.
+func (o Organization) Endpoint() string { return resourceEndpoint("organizations", o.OrgID) }
+
+// GetID returns the user's ID.
+func (u User) GetID() string { return u.ID }
+
+// GetName returns the user's full name, or their email if it's empty.
+func (u User) GetName() string {
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	return u.Email
+}
+
+// ResourceType returns ResourceTypeUser.
+func (u User) ResourceType() string { return ResourceTypeUser }
+
+// Endpoint returns the user's API path.
+func (u User) Endpoint() string { return resourceEndpoint("users", u.ID) }
+
+// GetID returns the role's ID.
+func (r Role) GetID() string { return r.ID }
+
+// GetName returns the role's name.
+func (r Role) GetName() string { return r.Name }
+
+// ResourceType returns ResourceTypeRole.
+func (r Role) ResourceType() string { return ResourceTypeRole }
+
+// Endpoint returns the role's API path.
+func (r Role) Endpoint() string { return resourceEndpoint("roles", r.ID) }
+
+// GetID returns the dashboard's ID.
+func (d Dashboard) GetID() string { return d.ID }
+
+// GetName returns the dashboard's title.
+func (d Dashboard) GetName() string { return d.Title }
+
+// ResourceType returns ResourceTypeDashboard.
+func (d Dashboard) ResourceType() string { return ResourceTypeDashboard }
+
+// Endpoint returns the dashboard's API path.
+func (d Dashboard) Endpoint() string { return resourceEndpoint("../v2/dashboards", d.ID) }
+
+// GetID returns the scheduled view's ID.
+func (v ScheduledView) GetID() string { return v.ID }
+
+// GetName returns the scheduled view's index name.
+func (v ScheduledView) GetName() string { return v.IndexName }
+
+// ResourceType returns ResourceTypeScheduledView.
+func (v ScheduledView) ResourceType() string { return ResourceTypeScheduledView }
+
+// Endpoint returns the scheduled view's API path.
+func (v ScheduledView) Endpoint() string { return resourceEndpoint("scheduledViews", v.ID) }
+
+// GetID returns the budget's ID.
+func (b IngestBudget) GetID() string { return b.ID }
+
+// GetName returns the budget's name.
+func (b IngestBudget) GetName() string { return b.Name }
+
+// ResourceType returns ResourceTypeIngestBudget.
+func (b IngestBudget) ResourceType() string { return ResourceTypeIngestBudget }
+
+// Endpoint returns the budget's API path.
+func (b IngestBudget) Endpoint() string { return resourceEndpoint("ingestBudgets", b.ID) }
+
+// GetID returns the budget's ID.
+func (b IngestBudgetV2) GetID() string { return b.ID }
+
+// GetName returns the budget's name.
+func (b IngestBudgetV2) GetName() string { return b.Name }
+
+// ResourceType returns ResourceTypeIngestBudget.
+func (b IngestBudgetV2) ResourceType() string { return ResourceTypeIngestBudget }
+
+// Endpoint returns the budget's API path.
+func (b IngestBudgetV2) Endpoint() string { return resourceEndpoint("../v2/ingestBudgets", b.ID) }
@@ -0,0 +1,44 @@
+package sumologic
+
+import "testing"
+
+func TestResource(t *testing.T) {
+	cases := []struct {
+		resource     Resource
+		id           string
+		name         string
+		resourceType string
+		endpoint     string
+	}{
+		{Collector{ID: 1, Name: "collector"}, "1", "collector", ResourceTypeCollector, "collectors/1"},
+		{Collector{Name: "new"}, "", "new", ResourceTypeCollector, "collectors"},
+		{HTTPSource{ID: 2, CollectorID: 1, Name: "http"}, "2", "http", ResourceTypeSource, "collectors/1/sources/2"},
+		{AWSLogSource{ID: 3, CollectorID: 1, Name: "aws"}, "3", "aws", ResourceTypeSource, "collectors/1/sources/3"},
+		{HTTPSource{CollectorID: 1, Name: "new"}, "", "new", ResourceTypeSource, "collectors/1/sources"},
+		{HTTPSource{ID: 2, Name: "orphan"}, "2", "orphan", ResourceTypeSource, ""},
+		{Organization{OrgID: "0000000000000131", OrganizationName: "child"}, "0000000000000131", "child", ResourceTypeOrganization, "organizations/0000000000000131"},
+		{User{ID: "00000000000000A1", FirstName: "Jane", LastName: "Doe"}, "00000000000000A1", "Jane Doe", ResourceTypeUser, "users/00000000000000A1"},
+		{User{ID: "00000000000000A2", Email: "jdoe@example.com"}, "00000000000000A2", "jdoe@example.com", ResourceTypeUser, "users/00000000000000A2"},
+		{Role{ID: "00000000000000B1", Name: "Administrator"}, "00000000000000B1", "Administrator", ResourceTypeRole, "roles/00000000000000B1"},
+		{Dashboard{ID: "9jS3sGpbBYNm", Title: "Overview"}, "9jS3sGpbBYNm", "Overview", ResourceTypeDashboard, "../v2/dashboards/9jS3sGpbBYNm"},
+		{ScheduledView{ID: "0000000000000C01", IndexName: "errors_by_host"}, "0000000000000C01", "errors_by_host", ResourceTypeScheduledView, "scheduledViews/0000000000000C01"},
+		{CloudToCloudSource{ID: 4, CollectorID: 1, Config: map[string]interface{}{"name": "okta"}}, "4", "okta", ResourceTypeSource, "collectors/1/sources/4"},
+		{ArchiveIngestionJob{ID: "0000000000000F01", SourceID: 2, Name: "incident-1234"}, "0000000000000F01", "incident-1234", ResourceTypeArchiveJob, "archive/2/jobs/0000000000000F01"},
+		{ArchiveIngestionJob{Name: "new"}, "", "new", ResourceTypeArchiveJob, ""},
+	}
+
+	for _, c := range cases {
+		if id := c.resource.GetID(); id != c.id {
+			t.Errorf("GetID() of %T expected ‘%s’, got ‘%s’", c.resource, c.id, id)
+		}
+		if name := c.resource.GetName(); name != c.name {
+			t.Errorf("GetName() of %T expected ‘%s’, got ‘%s’", c.resource, c.name, name)
+		}
+		if resourceType := c.resource.ResourceType(); resourceType != c.resourceType {
+			t.Errorf("ResourceType() of %T expected ‘%s’, got ‘%s’", c.resource, c.resourceType, resourceType)
+		}
+		if endpoint := c.resource.Endpoint(); endpoint != c.endpoint {
+			t.Errorf("Endpoint() of %T expected ‘%s’, got ‘%s’", c.resource, c.endpoint, endpoint)
+		}
+	}
+}
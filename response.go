@@ -0,0 +1,65 @@
+package sumologic
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response describes a single HTTP exchange with the API, as passed to a response hook.
+type Response struct {
+	Method string
+	// Endpoint is the request path with IDs replaced by `{id}`, as used by Metrics.
+	Endpoint   string
+	StatusCode int
+	Header     http.Header
+	// Duration is the time from sending the request until the response headers arrived. It's
+	// only set for responses passed to a response hook.
+	Duration time.Duration
+	// Err is set if no response was received, in which case StatusCode is 0 and Header nil.
+	Err error
+}
+
+// ETag returns the ETag response header.
+func (r *Response) ETag() string {
+	return r.Header.Get("ETag")
+}
+
+// WithResponseHook calls hook with every response received by the client, including each
+// retried attempt, so callers can observe status codes, headers and latency for caching or
+// monitoring without a custom RoundTripper. hook is called synchronously before the method
+// returns, possibly from multiple goroutines at once, and must not retain the Response's
+// Header after it returns.
+func WithResponseHook(hook func(*Response)) ClientOption {
+	return func(s *Client) error {
+		s.responseHook = hook
+		return nil
+	}
+}
+
+// callResponseHook passes the outcome of a request to the client's response hook, if any.
+func (s *Client) callResponseHook(req *http.Request, endpoint string, resp *http.Response, err error, start time.Time) {
+	if s.responseHook == nil {
+		return
+	}
+	r := &Response{
+		Method:   req.Method,
+		Endpoint: endpoint,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		r.StatusCode = resp.StatusCode
+		r.Header = resp.Header
+	}
+	s.responseHook(r)
+}
+
+// newResponse describes resp, the successful response returned by a WithResponse method.
+func (s *Client) newResponse(resp *http.Response) *Response {
+	r := &Response{StatusCode: resp.StatusCode, Header: resp.Header}
+	if resp.Request != nil {
+		r.Method = resp.Request.Method
+		r.Endpoint = s.endpointName(resp.Request)
+	}
+	return r
+}
@@ -0,0 +1,72 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWithResponseHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", "etag")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"collector":{"id":1,"name":"collector"}}`))
+	}))
+	defer ts.Close()
+
+	var mu sync.Mutex
+	var responses []*Response
+	c, _ := NewClient("accessToken", ts.URL, WithResponseHook(func(r *Response) {
+		mu.Lock()
+		defer mu.Unlock()
+		responses = append(responses, r)
+	}))
+
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if len(responses) != 1 {
+		t.Errorf("Expected the hook to be called once, got %d", len(responses))
+		return
+	}
+	r := responses[0]
+	if r.Method != "GET" || r.Endpoint != "GET collectors/{id}" || r.StatusCode != http.StatusOK || r.ETag() != "etag" || r.Err != nil {
+		t.Errorf("Expected the response of ‘GET collectors/{id}’ with its ETag, got %+v", r)
+	}
+	if r.Duration <= 0 {
+		t.Errorf("Expected a positive duration, got %s", r.Duration)
+	}
+}
+
+func TestCreateHTTPSourceWithResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"created"`)
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"source":{"id":2,"name":"http","sourceType":"HTTP"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, r, err := c.CreateHTTPSourceWithResponse(1, HTTPSource{Name: "http", SourceType: "HTTP"})
+	if err != nil {
+		t.Errorf("CreateHTTPSourceWithResponse() returned an error: %s", err)
+		return
+	}
+	if source.ID != 2 {
+		t.Errorf("CreateHTTPSourceWithResponse() expected source 2, got %d", source.ID)
+	}
+	if r.Method != "POST" || r.Endpoint != "POST collectors/{id}/sources" || r.StatusCode != http.StatusCreated || r.ETag() != `"created"` {
+		t.Errorf("CreateHTTPSourceWithResponse() expected the response of ‘POST collectors/{id}/sources’ with its ETag, got %+v", r)
+	}
+	if r.Metadata().RateLimitRemaining != 3 {
+		t.Errorf("CreateHTTPSourceWithResponse() expected 3 requests remaining, got %+v", r.Metadata())
+	}
+}
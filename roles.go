@@ -0,0 +1,71 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Role is a set of capabilities and a search filter that can be assigned to users.
+type Role struct {
+	ID                   string   `json:"id,omitempty"`
+	Name                 string   `json:"name"`
+	Description          string   `json:"description,omitempty"`
+	FilterPredicate      string   `json:"filterPredicate,omitempty"`
+	Users                []string `json:"users,omitempty"`
+	Capabilities         []string `json:"capabilities,omitempty"`
+	AutofillDependencies bool     `json:"autofillDependencies,omitempty"`
+	CreatedAt            string   `json:"createdAt,omitempty"`
+	CreatedBy            string   `json:"createdBy,omitempty"`
+	ModifiedAt           string   `json:"modifiedAt,omitempty"`
+	ModifiedBy           string   `json:"modifiedBy,omitempty"`
+}
+
+// ListRolesOptions are server-side filters for ListRoles. Empty fields are not sent.
+type ListRolesOptions struct {
+	// Name only returns roles with this name.
+	Name string
+	// SortBy is the field to sort by, e.g. `name`.
+	SortBy string
+}
+
+// ListRoles returns all roles matching the options, following pagination transparently.
+func (s *Client) ListRoles(options ListRolesOptions) ([]Role, error) {
+	roles := []Role{}
+	err := s.listAllPages("roles", options.query(), func(data json.RawMessage) error {
+		var page []Role
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		roles = append(roles, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ListRolesPage returns the page of roles matching the options at cursor, and the cursor of
+// the next page, which is empty after the last page.
+func (s *Client) ListRolesPage(options ListRolesOptions, cursor Cursor) ([]Role, Cursor, error) {
+	data, next, err := s.listTokenPage("roles", options.query(), cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var page []Role
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, "", err
+	}
+	return page, next, nil
+}
+
+func (options ListRolesOptions) query() url.Values {
+	query := url.Values{}
+	if options.Name != "" {
+		query.Set("name", options.Name)
+	}
+	if options.SortBy != "" {
+		query.Set("sortBy", options.SortBy)
+	}
+	return query
+}
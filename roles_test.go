@@ -0,0 +1,58 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListRolesOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/roles" {
+			t.Errorf("Expected request to ‘/roles’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("name") != "Administrator" {
+			t.Errorf("Expected name filter ‘Administrator’, got ‘%s’", r.URL.Query().Get("name"))
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"data": []Role{{ID: "0000000000000001", Name: "Administrator"}},
+		})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	roles, err := c.ListRoles(ListRolesOptions{Name: "Administrator"})
+	if err != nil {
+		t.Errorf("ListRoles() returned an error: %s", err)
+		return
+	}
+	if len(roles) != 1 || roles[0].Name != "Administrator" {
+		t.Errorf("ListRoles() expected the Administrator role, got %+v", roles)
+	}
+}
+
+func TestListRolesAuthenticationFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.ListRoles(ListRolesOptions{})
+	if err != ErrClientAuthenticationError {
+		t.Errorf("ListRoles() returned the wrong error: %s", err)
+	}
+}
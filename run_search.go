@@ -0,0 +1,114 @@
+package sumologic
+
+import (
+	"context"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// RunSearchOptions configures RunSearch. The zero value polls with the client's job polling
+// policy and collects all messages and records in the SearchResults.
+type RunSearchOptions struct {
+	// Polling overrides the client's job polling policy (see WithJobPolling) for this search.
+	Polling *backoff.Policy
+	// PageSize is the number of messages or records requested per page. Zero means the API's
+	// maximum of 10000.
+	PageSize int
+	// OnMessage, if set, is called with each raw message and its offset as the pages are
+	// read, instead of collecting them in SearchResults.Messages, so that large results
+	// aren't buffered in memory. Returning an error stops the search.
+	OnMessage func(offset int, message SearchJobRow) error
+	// OnRecord is like OnMessage, for aggregate records.
+	OnRecord func(offset int, record SearchJobRow) error
+}
+
+// SearchResults are the results of a search run by RunSearch.
+type SearchResults struct {
+	// Status is the final status of the search job.
+	Status        SearchJobStatus
+	MessageFields []SearchJobField
+	// Messages are the raw messages, unless RunSearchOptions.OnMessage was set.
+	Messages     []SearchJobRow
+	RecordFields []SearchJobField
+	// Records are the aggregate results, unless RunSearchOptions.OnRecord was set.
+	Records []SearchJobRow
+}
+
+// RunSearch runs a search job to completion: it creates the job, polls its status until it
+// is done gathering results, reads all messages and records a page at a time and deletes
+// the job, even if the search fails or ctx is done. It returns ErrSearchJobCancelled if the
+// job is cancelled and ErrJobTimeout if it doesn't complete within the polling policy's
+// maximum wait.
+func (s *Client) RunSearch(ctx context.Context, request SearchJobRequest, options RunSearchOptions) (*SearchResults, error) {
+	policy := s.jobPollingPolicy()
+	if options.Polling != nil {
+		policy = *options.Polling
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = searchJobMessagesPageSize
+	}
+
+	job, err := s.createSearchJob(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer s.DeleteSearchJob(job.ID)
+
+	status, err := s.waitForSearchJobWith(ctx, policy, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	results := &SearchResults{Status: *status}
+
+	for offset := 0; offset < status.MessageCount; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := s.getSearchJobMessages(ctx, job.ID, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Messages) == 0 {
+			break
+		}
+		results.MessageFields = page.Fields
+		if options.OnMessage == nil {
+			results.Messages = append(results.Messages, page.Messages...)
+			offset += len(page.Messages)
+			continue
+		}
+		for _, message := range page.Messages {
+			if err := options.OnMessage(offset, message); err != nil {
+				return nil, err
+			}
+			offset++
+		}
+	}
+
+	for offset := 0; offset < status.RecordCount; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := s.getSearchJobRecords(ctx, job.ID, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Records) == 0 {
+			break
+		}
+		results.RecordFields = page.Fields
+		if options.OnRecord == nil {
+			results.Records = append(results.Records, page.Records...)
+			offset += len(page.Records)
+			continue
+		}
+		for _, record := range page.Records {
+			if err := options.OnRecord(offset, record); err != nil {
+				return nil, err
+			}
+			offset++
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,110 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestRunSearch(t *testing.T) {
+	fake := &searchJobServer{t: t, records: []SearchJobRow{{Map: map[string]string{"_count": "5"}}}}
+	for i := 0; i < 5; i++ {
+		fake.messages = append(fake.messages, SearchJobRow{Map: map[string]string{"_raw": strconv.Itoa(i)}})
+	}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	polling := backoff.Policy{Initial: time.Millisecond}
+	results, err := c.RunSearch(context.Background(), SearchJobRequest{Query: "error | count"}, RunSearchOptions{Polling: &polling, PageSize: 2})
+	if err != nil {
+		t.Errorf("RunSearch() returned an error: %s", err)
+		return
+	}
+	if fake.query != "error | count" || fake.polls != 2 || !fake.deleted {
+		t.Errorf("RunSearch() expected to create, poll and delete the job, got query ‘%s’, %d polls and deleted %t", fake.query, fake.polls, fake.deleted)
+	}
+	if results.Status.State != SearchJobDoneGatheringResults || len(results.Messages) != 5 || len(results.Records) != 1 {
+		t.Errorf("RunSearch() returned unexpected results: %+v", results)
+		return
+	}
+	for i, message := range results.Messages {
+		if message.Map["_raw"] != strconv.Itoa(i) {
+			t.Errorf("RunSearch() expected message %d, got %+v", i, message)
+		}
+	}
+}
+
+func TestRunSearchOnMessageError(t *testing.T) {
+	fake := &searchJobServer{t: t, messages: make([]SearchJobRow, 4)}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithJobPolling(backoff.Policy{Initial: time.Millisecond}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	stop := errors.New("stop")
+	var offsets []int
+	_, err = c.RunSearch(context.Background(), SearchJobRequest{Query: "error"}, RunSearchOptions{
+		OnMessage: func(offset int, message SearchJobRow) error {
+			offsets = append(offsets, offset)
+			if offset == 1 {
+				return stop
+			}
+			return nil
+		},
+	})
+	if err != stop {
+		t.Errorf("RunSearch() expected the OnMessage error, got %v", err)
+	}
+	if len(offsets) != 2 || !fake.deleted {
+		t.Errorf("RunSearch() expected to stop after 2 messages and delete the job, got %v and deleted %t", offsets, fake.deleted)
+	}
+}
+
+func TestRunSearchCancelsPolling(t *testing.T) {
+	fake := &searchJobServer{t: t}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			// Block the status poll until the client gives up on it.
+			<-r.Context().Done()
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = c.RunSearch(ctx, SearchJobRequest{Query: "error"}, RunSearchOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RunSearch() expected the context's error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunSearch() expected the status poll to be canceled with ctx, took %s", elapsed)
+	}
+	if !fake.deleted {
+		t.Errorf("RunSearch() expected the job to be deleted")
+	}
+}
@@ -0,0 +1,185 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Content definition types used by the Content API.
+const (
+	ContentTypeFolder      = "FolderSyncDefinition"
+	ContentTypeSavedSearch = "SavedSearchWithScheduleSyncDefinition"
+)
+
+// SavedSearch is a saved search content definition, optionally run on a schedule.
+// It is parsed from the JSON returned by ExportContent with ParseSavedSearch or ListSavedSearches.
+type SavedSearch struct {
+	Type           string           `json:"type"`
+	Name           string           `json:"name"`
+	Description    string           `json:"description"`
+	Search         SavedSearchQuery `json:"search"`
+	SearchSchedule *SearchSchedule  `json:"searchSchedule"`
+}
+
+// SavedSearchQuery is the query of a saved search.
+type SavedSearchQuery struct {
+	QueryText        string            `json:"queryText"`
+	DefaultTimeRange string            `json:"defaultTimeRange"`
+	ByReceiptTime    bool              `json:"byReceiptTime"`
+	ViewName         string            `json:"viewName,omitempty"`
+	ViewStartTime    string            `json:"viewStartTime,omitempty"`
+	QueryParameters  []json.RawMessage `json:"queryParameters,omitempty"`
+	ParsingMode      string            `json:"parsingMode,omitempty"`
+}
+
+// SearchSchedule is when a saved search runs and who is notified of its results.
+type SearchSchedule struct {
+	CronExpression       string             `json:"cronExpression,omitempty"`
+	DisplayableTimeRange string             `json:"displayableTimeRange"`
+	ParseableTimeRange   json.RawMessage    `json:"parseableTimeRange,omitempty"`
+	TimeZone             string             `json:"timeZone"`
+	Threshold            json.RawMessage    `json:"threshold,omitempty"`
+	Notification         SearchNotification `json:"notification"`
+	ScheduleType         string             `json:"scheduleType"`
+	MuteErrorEmails      bool               `json:"muteErrorEmails"`
+	Parameters           []json.RawMessage  `json:"parameters,omitempty"`
+}
+
+// SearchNotification is how the results of a scheduled search are delivered. Only the fields
+// common to email notifications are modelled; fields specific to other task types (e.g. a
+// webhook ID) are preserved by UpdateSavedSearches.
+type SearchNotification struct {
+	TaskType             string   `json:"taskType"`
+	ToList               []string `json:"toList,omitempty"`
+	SubjectTemplate      string   `json:"subjectTemplate,omitempty"`
+	IncludeQuery         bool     `json:"includeQuery,omitempty"`
+	IncludeResultSet     bool     `json:"includeResultSet,omitempty"`
+	IncludeHistogram     bool     `json:"includeHistogram,omitempty"`
+	IncludeCsvAttachment bool     `json:"includeCsvAttachment,omitempty"`
+}
+
+// IsScheduled reports whether the saved search runs on a schedule.
+func (s SavedSearch) IsScheduled() bool {
+	return s.SearchSchedule != nil
+}
+
+// contentItem is the part of a content definition needed to walk a folder tree.
+type contentItem struct {
+	Type     string            `json:"type"`
+	Children []json.RawMessage `json:"children"`
+}
+
+// ParseSavedSearch parses a single saved search content definition.
+func ParseSavedSearch(definition json.RawMessage) (*SavedSearch, error) {
+	var search = new(SavedSearch)
+	if err := json.Unmarshal(definition, search); err != nil {
+		return nil, err
+	}
+	if search.Type != ContentTypeSavedSearch {
+		return nil, fmt.Errorf("Content of type `%s` is not a saved search", search.Type)
+	}
+	return search, nil
+}
+
+// ListSavedSearches returns every saved search in a content definition, descending into folders.
+func ListSavedSearches(content json.RawMessage) ([]SavedSearch, error) {
+	searches := []SavedSearch{}
+	_, err := UpdateSavedSearches(content, func(search *SavedSearch) error {
+		searches = append(searches, *search)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// UpdateSavedSearches calls update for every saved search in a content definition, descending
+// into folders, and returns the definition with the changes applied, ready for ImportContent.
+// Fields of the definition that SavedSearch doesn't model are preserved, and modelled fields
+// cleared by update are removed.
+func UpdateSavedSearches(content json.RawMessage, update func(*SavedSearch) error) (json.RawMessage, error) {
+	var item contentItem
+	if err := json.Unmarshal(content, &item); err != nil {
+		return nil, err
+	}
+
+	switch item.Type {
+	case ContentTypeSavedSearch:
+		search, err := ParseSavedSearch(content)
+		if err != nil {
+			return nil, err
+		}
+		if err := update(search); err != nil {
+			return nil, err
+		}
+		updated, err := json.Marshal(search)
+		if err != nil {
+			return nil, err
+		}
+		return mergeModelled(content, updated, reflect.TypeOf(SavedSearch{}))
+	case ContentTypeFolder:
+		children := make([]json.RawMessage, len(item.Children))
+		for i, child := range item.Children {
+			updated, err := UpdateSavedSearches(child, update)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = updated
+		}
+		var folder map[string]json.RawMessage
+		if err := json.Unmarshal(content, &folder); err != nil {
+			return nil, err
+		}
+		updated, err := json.Marshal(children)
+		if err != nil {
+			return nil, err
+		}
+		folder["children"] = updated
+		return json.Marshal(folder)
+	default:
+		return content, nil
+	}
+}
+
+// mergeModelled returns original with the keys modelled by t, a struct type, taken from
+// updated, the JSON encoding of a t. Modelled keys missing from updated, such as fields
+// omitted for holding their zero value, are removed, so cleared fields stay cleared. Keys t
+// doesn't model are kept, and nested structs are merged the same way.
+func mergeModelled(original, updated json.RawMessage, t reflect.Type) (json.RawMessage, error) {
+	var o, u map[string]json.RawMessage
+	if json.Unmarshal(original, &o) != nil || json.Unmarshal(updated, &u) != nil || o == nil || u == nil {
+		return updated, nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "-" || field.PkgPath != "" {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		value, ok := u[key]
+		if !ok {
+			delete(o, key)
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if existing, ok := o[key]; ok && fieldType.Kind() == reflect.Struct {
+			merged, err := mergeModelled(existing, value, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			o[key] = merged
+			continue
+		}
+		o[key] = value
+	}
+	return json.Marshal(o)
+}
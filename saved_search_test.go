@@ -0,0 +1,95 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const savedSearchFolder = `{
+	"type": "FolderSyncDefinition",
+	"name": "Alerts",
+	"description": "",
+	"children": [
+		{
+			"type": "SavedSearchWithScheduleSyncDefinition",
+			"name": "Errors",
+			"description": "Error count",
+			"search": {"queryText": "error | count", "defaultTimeRange": "-15m", "byReceiptTime": false, "parsingMode": "Manual"},
+			"searchSchedule": {
+				"cronExpression": "0 0 * * * ? *",
+				"displayableTimeRange": "-1h",
+				"parseableTimeRange": {"type": "BeginBoundedTimeRange", "from": {"type": "RelativeTimeRangeBoundary", "relativeTime": "-1h"}},
+				"timeZone": "UTC",
+				"notification": {"taskType": "WebhookSearchNotificationSyncDefinition", "webhookId": "000000000000ABCD", "itemizeAlerts": false},
+				"scheduleType": "1Hour",
+				"muteErrorEmails": false
+			}
+		},
+		{"type": "DashboardSyncDefinition", "name": "Overview"}
+	]
+}`
+
+func TestListSavedSearches(t *testing.T) {
+	searches, err := ListSavedSearches(json.RawMessage(savedSearchFolder))
+	if err != nil {
+		t.Errorf("ListSavedSearches() returned an error: %s", err)
+		return
+	}
+	if len(searches) != 1 {
+		t.Errorf("ListSavedSearches() expected 1 saved search, got %d", len(searches))
+		return
+	}
+	s := searches[0]
+	if s.Name != "Errors" || s.Search.QueryText != "error | count" || !s.IsScheduled() {
+		t.Errorf("ListSavedSearches() returned an unexpected saved search: %+v", s)
+	}
+	if s.SearchSchedule.Notification.TaskType != "WebhookSearchNotificationSyncDefinition" {
+		t.Errorf("ListSavedSearches() expected a webhook notification, got `%s`", s.SearchSchedule.Notification.TaskType)
+	}
+}
+
+func TestUpdateSavedSearchesPreservesUnmodelledFields(t *testing.T) {
+	updated, err := UpdateSavedSearches(json.RawMessage(savedSearchFolder), func(s *SavedSearch) error {
+		s.SearchSchedule.CronExpression = "0 30 * * * ? *"
+		return nil
+	})
+	if err != nil {
+		t.Errorf("UpdateSavedSearches() returned an error: %s", err)
+		return
+	}
+	for _, expected := range []string{`"0 30 * * * ? *"`, `"webhookId":"000000000000ABCD"`, `"DashboardSyncDefinition"`, `"relativeTime":"-1h"`} {
+		if !strings.Contains(string(updated), expected) {
+			t.Errorf("UpdateSavedSearches() expected output to contain %s, got `%s`", expected, updated)
+		}
+	}
+}
+
+func TestUpdateSavedSearchesClearsFields(t *testing.T) {
+	search := `{"type":"SavedSearchWithScheduleSyncDefinition","name":"Errors","search":{"queryText":"error"},` +
+		`"searchSchedule":{"cronExpression":"0 0 * * * ? *","notification":{"taskType":"EmailSearchNotificationSyncDefinition","toList":["a@b.c"],"includeQuery":true,"itemizeAlerts":true}}}`
+	updated, err := UpdateSavedSearches(json.RawMessage(search), func(s *SavedSearch) error {
+		s.SearchSchedule.Notification.IncludeQuery = false
+		s.SearchSchedule.Notification.ToList = nil
+		s.SearchSchedule.CronExpression = ""
+		return nil
+	})
+	if err != nil {
+		t.Errorf("UpdateSavedSearches() returned an error: %s", err)
+		return
+	}
+	for _, cleared := range []string{`"includeQuery"`, `"toList"`, `"cronExpression"`} {
+		if strings.Contains(string(updated), cleared) {
+			t.Errorf("UpdateSavedSearches() expected %s to be cleared, got `%s`", cleared, updated)
+		}
+	}
+	if !strings.Contains(string(updated), `"itemizeAlerts":true`) {
+		t.Errorf("UpdateSavedSearches() expected unmodelled fields to be kept, got `%s`", updated)
+	}
+}
+
+func TestParseSavedSearchWrongType(t *testing.T) {
+	if _, err := ParseSavedSearch(json.RawMessage(savedSearchFolder)); err == nil {
+		t.Errorf("ParseSavedSearch() did not return an error for a folder")
+	}
+}
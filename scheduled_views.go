@@ -0,0 +1,178 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// Scheduled view parsing modes.
+const (
+	ParsingModeManual    = "Manual"
+	ParsingModeAutoParse = "AutoParse"
+)
+
+// ScheduledView is a scheduled view: a query run continuously at ingest time whose results
+// are indexed, so that searches and dashboards over them are fast.
+type ScheduledView struct {
+	ID    string `json:"id,omitempty"`
+	Query string `json:"query"`
+	// IndexName is the name the view is searched by, as `_view=<indexName>`.
+	IndexName string `json:"indexName"`
+	// StartTime is the RFC 3339 time from which the view is backfilled.
+	StartTime string `json:"startTime"`
+	// RetentionPeriod is the number of days the view's data is kept; 0 uses the default.
+	RetentionPeriod  int    `json:"retentionPeriod,omitempty"`
+	DataForwardingID string `json:"dataForwardingId,omitempty"`
+	// ParsingMode is ParsingModeManual or ParsingModeAutoParse.
+	ParsingMode string `json:"parsingMode,omitempty"`
+	// ReduceRetentionPeriodImmediately deletes data older than a reduced RetentionPeriod
+	// right away on UpdateScheduledView, instead of after the current retention period.
+	ReduceRetentionPeriodImmediately bool   `json:"reduceRetentionPeriodImmediately,omitempty"`
+	TotalBytes                       int64  `json:"totalBytes,omitempty"`
+	TotalMessageCount                int64  `json:"totalMessageCount,omitempty"`
+	CreatedAt                        string `json:"createdAt,omitempty"`
+	CreatedBy                        string `json:"createdBy,omitempty"`
+	ModifiedAt                       string `json:"modifiedAt,omitempty"`
+	ModifiedBy                       string `json:"modifiedBy,omitempty"`
+}
+
+// scheduledViewUpdate is the request body of UpdateScheduledView; the query, index name and
+// start time of a scheduled view can't be changed.
+type scheduledViewUpdate struct {
+	DataForwardingID                 string `json:"dataForwardingId,omitempty"`
+	RetentionPeriod                  int    `json:"retentionPeriod,omitempty"`
+	ReduceRetentionPeriodImmediately bool   `json:"reduceRetentionPeriodImmediately,omitempty"`
+}
+
+// ErrScheduledViewNotFound is returned when a scheduled view doesn't exist on a Get, Update
+// or Disable.
+var ErrScheduledViewNotFound = errors.New("Scheduled view not found")
+
+// scheduledViewIndexNamePattern matches valid scheduled view index names.
+var scheduledViewIndexNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the scheduled view for errors the API would reject, without sending a
+// request.
+func (v ScheduledView) Validate() error {
+	if v.Query == "" {
+		return &ValidationError{Field: "query", Message: "must not be empty"}
+	}
+	if !scheduledViewIndexNamePattern.MatchString(v.IndexName) {
+		return &ValidationError{Field: "indexName", Message: fmt.Sprintf("`%s` must start with a letter or underscore and contain only letters, digits and underscores", v.IndexName)}
+	}
+	if _, err := time.Parse(time.RFC3339, v.StartTime); err != nil {
+		return &ValidationError{Field: "startTime", Message: fmt.Sprintf("`%s` must be an RFC 3339 time", v.StartTime)}
+	}
+	if v.RetentionPeriod < 0 {
+		return &ValidationError{Field: "retentionPeriod", Message: fmt.Sprintf("must be a number of days, got %d", v.RetentionPeriod)}
+	}
+	if m := v.ParsingMode; m != "" && m != ParsingModeManual && m != ParsingModeAutoParse {
+		return &ValidationError{Field: "parsingMode", Message: fmt.Sprintf("must be `%s` or `%s`, got `%s`", ParsingModeManual, ParsingModeAutoParse, m)}
+	}
+	return nil
+}
+
+// ListScheduledViews returns all scheduled views, following pagination transparently.
+func (s *Client) ListScheduledViews() ([]ScheduledView, error) {
+	views := []ScheduledView{}
+	err := s.listAllPages("scheduledViews", nil, func(data json.RawMessage) error {
+		var page []ScheduledView
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		views = append(views, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// CreateScheduledView creates a new scheduled view, which starts backfilling from its
+// StartTime.
+func (s *Client) CreateScheduledView(view ScheduledView) (*ScheduledView, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeScheduledView, nil, nil, view); err != nil {
+		return nil, err
+	}
+	var v = new(ScheduledView)
+	if _, err := s.do("POST", "scheduledViews", view, v); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if a scheduled view named `%s` already exists", view.IndexName))
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeScheduledView, v.ID, nil, v)
+	return v, nil
+}
+
+// GetScheduledView gets the scheduled view with the specified ID.
+func (s *Client) GetScheduledView(id string) (*ScheduledView, error) {
+	path, err := formatPath("scheduledViews/%s", id)
+	if err != nil {
+		return nil, err
+	}
+	var v = new(ScheduledView)
+	if _, err := s.do("GET", path, nil, v); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrScheduledViewNotFound)
+	}
+	return v, nil
+}
+
+// UpdateScheduledView updates the retention period and data forwarding destination of the
+// scheduled view with the ID of view. Its query, index name and start time can't be changed;
+// disable it and create a new one instead.
+func (s *Client) UpdateScheduledView(view ScheduledView) (*ScheduledView, error) {
+	if view.RetentionPeriod < 0 {
+		return nil, &ValidationError{Field: "retentionPeriod", Message: fmt.Sprintf("must be a number of days, got %d", view.RetentionPeriod)}
+	}
+	path, err := formatPath("scheduledViews/%s", view.ID)
+	if err != nil {
+		return nil, err
+	}
+	update := scheduledViewUpdate{
+		DataForwardingID:                 view.DataForwardingID,
+		RetentionPeriod:                  view.RetentionPeriod,
+		ReduceRetentionPeriodImmediately: view.ReduceRetentionPeriodImmediately,
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeScheduledView, view.ID, nil, update); err != nil {
+		return nil, err
+	}
+	var v = new(ScheduledView)
+	if _, err := s.do("PUT", path, update, v); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusNotFound, ErrScheduledViewNotFound)
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeScheduledView, v.ID, nil, v)
+	return v, nil
+}
+
+// DisableScheduledView disables the scheduled view with the specified ID. Its data is kept
+// until the end of its retention period, but no new data is indexed. Scheduled views can't
+// be deleted or re-enabled.
+func (s *Client) DisableScheduledView(id string) error {
+	path, err := formatPath("scheduledViews/%s/disable", id)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeDelete, ResourceTypeScheduledView, id, nil, nil); err != nil {
+		return err
+	}
+	if _, err := s.do("DELETE", path, nil, nil); err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrScheduledViewNotFound)
+	}
+
+	s.recordChange(ChangeDelete, ResourceTypeScheduledView, id, nil, nil)
+	return nil
+}
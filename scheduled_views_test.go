@@ -0,0 +1,119 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultScheduledView = ScheduledView{
+	ID:              "0000000000000C01",
+	Query:           "_sourceCategory=prod error | count by _sourceHost",
+	IndexName:       "errors_by_host",
+	StartTime:       "2020-05-01T00:00:00Z",
+	RetentionPeriod: 30,
+	ParsingMode:     ParsingModeManual,
+}
+
+func TestScheduledViews(t *testing.T) {
+	var disabled bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		viewPath := "/scheduledViews/" + defaultScheduledView.ID
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/scheduledViews":
+			body, _ := io.ReadAll(r.Body)
+			v := new(ScheduledView)
+			if err := json.Unmarshal(body, &v); err != nil {
+				t.Errorf("Unable to unmarshal ScheduledView, got `%s`", body)
+			}
+			v.ID = defaultScheduledView.ID
+			js, _ := json.Marshal(v)
+			w.Write(js)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/scheduledViews":
+			js, _ := json.Marshal(map[string]interface{}{"data": []ScheduledView{defaultScheduledView}})
+			w.Write(js)
+		case r.Method == "GET" && r.URL.EscapedPath() == viewPath:
+			js, _ := json.Marshal(defaultScheduledView)
+			w.Write(js)
+		case r.Method == "PUT" && r.URL.EscapedPath() == viewPath:
+			var update map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &update)
+			if _, ok := update["query"]; ok || update["retentionPeriod"] != float64(7) || update["reduceRetentionPeriodImmediately"] != true {
+				t.Errorf("UpdateScheduledView() sent an unexpected body: %s", body)
+			}
+			v := defaultScheduledView
+			v.RetentionPeriod = 7
+			js, _ := json.Marshal(v)
+			w.Write(js)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == viewPath+"/disable":
+			disabled = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	view := defaultScheduledView
+	view.ID = ""
+	created, err := c.CreateScheduledView(view)
+	if err != nil {
+		t.Errorf("CreateScheduledView() returned an error: %s", err)
+		return
+	}
+	if created.ID != defaultScheduledView.ID || created.IndexName != view.IndexName {
+		t.Errorf("CreateScheduledView() returned an unexpected view: %+v", created)
+	}
+
+	views, err := c.ListScheduledViews()
+	if err != nil || len(views) != 1 || views[0].IndexName != defaultScheduledView.IndexName {
+		t.Errorf("ListScheduledViews() returned %+v and %v", views, err)
+	}
+
+	got, err := c.GetScheduledView(defaultScheduledView.ID)
+	if err != nil {
+		t.Errorf("GetScheduledView() returned an error: %s", err)
+		return
+	}
+	got.RetentionPeriod = 7
+	got.ReduceRetentionPeriodImmediately = true
+	updated, err := c.UpdateScheduledView(*got)
+	if err != nil || updated.RetentionPeriod != 7 {
+		t.Errorf("UpdateScheduledView() returned %+v and %v", updated, err)
+	}
+
+	if err := c.DisableScheduledView(defaultScheduledView.ID); err != nil || !disabled {
+		t.Errorf("DisableScheduledView() returned %v, disabled %t", err, disabled)
+	}
+	if _, err := c.GetScheduledView("missing"); err != ErrScheduledViewNotFound {
+		t.Errorf("GetScheduledView() expected ErrScheduledViewNotFound, got %v", err)
+	}
+}
+
+func TestScheduledViewValidate(t *testing.T) {
+	cases := map[string]func(v *ScheduledView){
+		"query":       func(v *ScheduledView) { v.Query = "" },
+		"indexName":   func(v *ScheduledView) { v.IndexName = "errors-by-host" },
+		"startTime":   func(v *ScheduledView) { v.StartTime = "2020-05-01" },
+		"parsingMode": func(v *ScheduledView) { v.ParsingMode = "Auto" },
+	}
+	for field, invalidate := range cases {
+		v := defaultScheduledView
+		invalidate(&v)
+		if verr, ok := v.Validate().(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ‘%s’ error, got %v", field, v.Validate())
+		}
+	}
+	if err := defaultScheduledView.Validate(); err != nil {
+		t.Errorf("Validate() returned an error for a valid view: %s", err)
+	}
+}
@@ -0,0 +1,17 @@
+#!/usr/bin/env bash
+
+# Generates time_zones_list.go from the IANA tz database, by default the system's copy.
+TZDATA=${TZDATA:-/usr/share/zoneinfo/tzdata.zi}
+VERSION=$(sed -n 's/^# version //p' "$TZDATA")
+
+{
+  echo "// Code generated by scripts/gentimezones.sh from tzdata $VERSION. DO NOT EDIT."
+  echo
+  echo "package sumologic"
+  echo
+  echo "// timeZones are the IANA time zone names, sorted, including backward-compatible links."
+  echo "var timeZones = []string{"
+  awk '$1 == "Z" { print $2 } $1 == "L" { print $3 }' "$TZDATA" | grep -v '^Factory$' | LC_ALL=C sort -u | sed 's/.*/\t"&",/'
+  echo "}"
+} > time_zones_list.go
+gofmt -w time_zones_list.go
@@ -0,0 +1,281 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// SearchJobRequest starts a search job. From and To are ISO 8601 timestamps or epoch
+// milliseconds, as accepted by the Search Job API.
+//
+// The Search Job API relies on cookies for session affinity, so the client's HTTP client
+// should have a cookie jar when running search jobs.
+type SearchJobRequest struct {
+	Query         string `json:"query"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	TimeZone      string `json:"timeZone"`
+	ByReceiptTime bool   `json:"byReceiptTime,omitempty"`
+}
+
+// SearchJob is a search job started by CreateSearchJob.
+type SearchJob struct {
+	ID string `json:"id"`
+}
+
+// Search job states reported by GetSearchJobStatus.
+const (
+	SearchJobGatheringResults     = "GATHERING RESULTS"
+	SearchJobDoneGatheringResults = "DONE GATHERING RESULTS"
+	SearchJobCancelled            = "CANCELLED"
+	SearchJobNotStarted           = "NOT STARTED"
+)
+
+// SearchJobStatus is the progress of a search job.
+type SearchJobStatus struct {
+	State           string   `json:"state"`
+	MessageCount    int      `json:"messageCount"`
+	RecordCount     int      `json:"recordCount"`
+	PendingWarnings []string `json:"pendingWarnings"`
+	PendingErrors   []string `json:"pendingErrors"`
+	// PollHint is the delay before polling again suggested by the API, if any.
+	PollHint time.Duration `json:"-"`
+}
+
+func (j *SearchJobStatus) setPollHint(d time.Duration) {
+	j.PollHint = d
+}
+
+// SearchJobField describes a field of search job messages or records.
+type SearchJobField struct {
+	Name      string `json:"name"`
+	FieldType string `json:"fieldType"`
+	KeyField  bool   `json:"keyField"`
+}
+
+// SearchJobRow is a single message or record. All values are returned as strings.
+type SearchJobRow struct {
+	Map map[string]string `json:"map"`
+}
+
+// SearchJobRecords is a page of aggregate results of a search job.
+type SearchJobRecords struct {
+	Fields  []SearchJobField `json:"fields"`
+	Records []SearchJobRow   `json:"records"`
+}
+
+// SearchJobMessages is a page of raw messages of a search job.
+type SearchJobMessages struct {
+	Fields   []SearchJobField `json:"fields"`
+	Messages []SearchJobRow   `json:"messages"`
+}
+
+// ErrSearchJobNotFound is returned when a search job doesn't exist or has expired.
+var ErrSearchJobNotFound = errors.New("Search job not found")
+
+// ErrSearchJobCancelled is returned when waiting for a search job that was cancelled.
+var ErrSearchJobCancelled = errors.New("Search job was cancelled")
+
+// CreateSearchJob starts a new search job.
+func (s *Client) CreateSearchJob(request SearchJobRequest) (*SearchJob, error) {
+	return s.createSearchJob(context.Background(), request)
+}
+
+// createSearchJob is CreateSearchJob, canceled when ctx is done.
+func (s *Client) createSearchJob(ctx context.Context, request SearchJobRequest) (*SearchJob, error) {
+	var j = new(SearchJob)
+	if _, err := s.doIfMatchContext(ctx, "POST", "search/jobs", "", request, j); err != nil {
+		if _, ok := badRequest(err); ok {
+			return nil, err
+		}
+		return nil, errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check the search query"))
+	}
+	return j, nil
+}
+
+// GetSearchJobStatus gets the status of the search job with the specified ID.
+func (s *Client) GetSearchJobStatus(id string) (*SearchJobStatus, error) {
+	return s.getSearchJobStatus(context.Background(), id)
+}
+
+// getSearchJobStatus is GetSearchJobStatus, canceled when ctx is done.
+func (s *Client) getSearchJobStatus(ctx context.Context, id string) (*SearchJobStatus, error) {
+	path, err := formatPath("search/jobs/%s", id)
+	if err != nil {
+		return nil, err
+	}
+	var status = new(SearchJobStatus)
+	if err := s.getSearchJobResource(ctx, path, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetSearchJobRecords gets a page of aggregate results of the search job with the specified ID.
+func (s *Client) GetSearchJobRecords(id string, offset, limit int) (*SearchJobRecords, error) {
+	return s.getSearchJobRecords(context.Background(), id, offset, limit)
+}
+
+// getSearchJobRecords is GetSearchJobRecords, canceled when ctx is done.
+func (s *Client) getSearchJobRecords(ctx context.Context, id string, offset, limit int) (*SearchJobRecords, error) {
+	path, err := formatPath("search/jobs/%s/records", id)
+	if err != nil {
+		return nil, err
+	}
+	var records = new(SearchJobRecords)
+	err = s.getSearchJobResource(ctx, path, searchJobPage(offset, limit), records)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetSearchJobMessages gets a page of raw messages of the search job with the specified ID.
+func (s *Client) GetSearchJobMessages(id string, offset, limit int) (*SearchJobMessages, error) {
+	return s.getSearchJobMessages(context.Background(), id, offset, limit)
+}
+
+// getSearchJobMessages is GetSearchJobMessages, canceled when ctx is done.
+func (s *Client) getSearchJobMessages(ctx context.Context, id string, offset, limit int) (*SearchJobMessages, error) {
+	path, err := formatPath("search/jobs/%s/messages", id)
+	if err != nil {
+		return nil, err
+	}
+	var messages = new(SearchJobMessages)
+	err = s.getSearchJobResource(ctx, path, searchJobPage(offset, limit), messages)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeleteSearchJob deletes the search job with the specified ID, releasing its resources.
+func (s *Client) DeleteSearchJob(id string) error {
+	path, err := formatPath("search/jobs/%s", id)
+	if err != nil {
+		return err
+	}
+	_, err = s.do("DELETE", path, nil, nil)
+	return errorForStatus(err, http.StatusNotFound, ErrSearchJobNotFound)
+}
+
+// waitForSearchJob polls the search job until it is done gathering results, the maximum
+// wait is exceeded or ctx is done.
+func (s *Client) waitForSearchJob(ctx context.Context, id string) (*SearchJobStatus, error) {
+	return s.waitForSearchJobWith(ctx, s.jobPollingPolicy(), id)
+}
+
+// waitForSearchJobWith is like waitForSearchJob, but polls with the given policy.
+func (s *Client) waitForSearchJobWith(ctx context.Context, policy backoff.Policy, id string) (*SearchJobStatus, error) {
+	var status *SearchJobStatus
+	err := pollJobWith(ctx, policy, func() (bool, time.Duration, error) {
+		var err error
+		status, err = s.getSearchJobStatus(ctx, id)
+		if err != nil {
+			return false, 0, err
+		}
+		switch status.State {
+		case SearchJobDoneGatheringResults:
+			return true, 0, nil
+		case SearchJobCancelled:
+			return false, 0, ErrSearchJobCancelled
+		}
+		return false, status.PollHint, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// searchJobMessagesPageSize is the number of messages requested per page by StreamMessages,
+// the API's maximum.
+const searchJobMessagesPageSize = 10000
+
+// SearchJobMessageEvent is a message streamed by StreamMessages, or a failure to read one.
+type SearchJobMessageEvent struct {
+	// Offset is the message's position in the search job's results.
+	Offset  int
+	Message SearchJobRow
+	// Err is set if reading messages failed; no further events are sent.
+	Err error
+}
+
+// StreamMessages sends the raw messages of the search job with the specified ID as they
+// become available, fetching a page at a time only as fast as they are received, so that
+// large results aren't buffered in memory. Messages are sent until the job is done gathering
+// results. A failure, including the job being cancelled, is sent as a final event with Err
+// set. The channel is closed once all messages are sent, after a failure or once ctx is done.
+func (s *Client) StreamMessages(ctx context.Context, jobID string) <-chan SearchJobMessageEvent {
+	events := make(chan SearchJobMessageEvent)
+
+	go func() {
+		defer close(events)
+
+		offset := 0
+		err := s.pollJob(ctx, func() (bool, time.Duration, error) {
+			status, err := s.getSearchJobStatus(ctx, jobID)
+			if err != nil {
+				return false, 0, err
+			}
+			for offset < status.MessageCount {
+				page, err := s.getSearchJobMessages(ctx, jobID, offset, searchJobMessagesPageSize)
+				if err != nil {
+					return false, 0, err
+				}
+				if len(page.Messages) == 0 {
+					break
+				}
+				for _, message := range page.Messages {
+					select {
+					case events <- SearchJobMessageEvent{Offset: offset, Message: message}:
+						offset++
+					case <-ctx.Done():
+						return false, 0, ctx.Err()
+					}
+				}
+			}
+			switch status.State {
+			case SearchJobDoneGatheringResults:
+				return true, 0, nil
+			case SearchJobCancelled:
+				return false, 0, ErrSearchJobCancelled
+			}
+			return false, status.PollHint, nil
+		})
+		if err != nil && ctx.Err() == nil {
+			select {
+			case events <- SearchJobMessageEvent{Offset: offset, Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return events
+}
+
+func searchJobPage(offset, limit int) url.Values {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return query
+}
+
+func (s *Client) getSearchJobResource(ctx context.Context, path string, query url.Values, out interface{}) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := s.doIfMatchContext(ctx, "GET", path, "", nil, out)
+	if err != nil {
+		return errorForStatus(err, http.StatusNotFound, ErrSearchJobNotFound)
+	}
+	recordPollHint(out, resp.Header)
+	return nil
+}
@@ -0,0 +1,183 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// searchJobServer is a fake Search Job API that completes every job after two status polls
+// and returns records as aggregate results and messages as raw messages. Like the API, it
+// sets a session cookie when a job is created and doesn't find the job without it.
+type searchJobServer struct {
+	t        *testing.T
+	records  []SearchJobRow
+	messages []SearchJobRow
+
+	mu      sync.Mutex
+	query   string
+	polls   int
+	deleted bool
+}
+
+func (f *searchJobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var response interface{}
+	if cookie, err := r.Cookie("JSESSIONID"); r.Method != "POST" && (err != nil || cookie.Value != "session") {
+		f.t.Errorf("Expected the session cookie for ‘%s %s’", r.Method, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case r.Method == "POST" && r.URL.EscapedPath() == "/search/jobs":
+		body, _ := io.ReadAll(r.Body)
+		request := new(SearchJobRequest)
+		json.Unmarshal(body, &request)
+		f.query = request.Query
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session", Path: "/"})
+		w.WriteHeader(http.StatusAccepted)
+		response = SearchJob{ID: "0123456789ABCDEF"}
+	case r.Method == "GET" && r.URL.EscapedPath() == "/search/jobs/0123456789ABCDEF":
+		f.polls++
+		status := SearchJobStatus{State: SearchJobGatheringResults, MessageCount: len(f.messages) / 2}
+		if f.polls >= 2 {
+			status = SearchJobStatus{State: SearchJobDoneGatheringResults, RecordCount: len(f.records), MessageCount: len(f.messages)}
+		}
+		response = status
+	case r.Method == "GET" && r.URL.EscapedPath() == "/search/jobs/0123456789ABCDEF/records":
+		response = SearchJobRecords{Records: f.records}
+	case r.Method == "GET" && r.URL.EscapedPath() == "/search/jobs/0123456789ABCDEF/messages":
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := offset + limit
+		if end > len(f.messages) {
+			end = len(f.messages)
+		}
+		response = SearchJobMessages{Messages: f.messages[offset:end]}
+	case r.Method == "DELETE" && r.URL.EscapedPath() == "/search/jobs/0123456789ABCDEF":
+		f.deleted = true
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		f.t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, _ := json.Marshal(response)
+	w.Write(body)
+}
+
+func TestGetSearchJobStatusDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.GetSearchJobStatus("0123456789ABCDEF")
+	if err != ErrSearchJobNotFound {
+		t.Errorf("GetSearchJobStatus() returned the wrong error: %s", err)
+	}
+}
+
+func TestStreamMessages(t *testing.T) {
+	defer func(p backoff.Policy) { defaultJobPolling = p }(defaultJobPolling)
+	defaultJobPolling = backoff.Policy{Initial: time.Millisecond}
+
+	fake := &searchJobServer{t: t}
+	for i := 0; i < 5; i++ {
+		fake.messages = append(fake.messages, SearchJobRow{Map: map[string]string{"_raw": strconv.Itoa(i)}})
+	}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	job, err := c.CreateSearchJob(SearchJobRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("CreateSearchJob() returned an error: %s", err)
+		return
+	}
+
+	i := 0
+	for event := range c.StreamMessages(context.Background(), job.ID) {
+		if event.Err != nil {
+			t.Errorf("StreamMessages() returned an error: %s", event.Err)
+			return
+		}
+		if event.Offset != i || event.Message.Map["_raw"] != strconv.Itoa(i) {
+			t.Errorf("StreamMessages() expected message %d, got %+v", i, event)
+		}
+		i++
+	}
+	if i != len(fake.messages) {
+		t.Errorf("StreamMessages() expected %d messages, got %d", len(fake.messages), i)
+	}
+}
+
+func TestStreamMessagesContextCancelled(t *testing.T) {
+	fake := &searchJobServer{t: t, messages: make([]SearchJobRow, 4)}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	job, err := c.CreateSearchJob(SearchJobRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("CreateSearchJob() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := c.StreamMessages(ctx, job.ID)
+	if event := <-events; event.Err != nil {
+		t.Errorf("StreamMessages() returned an error: %s", event.Err)
+	}
+	cancel()
+	for event := range events {
+		if event.Err != nil {
+			t.Errorf("StreamMessages() expected no error after cancellation, got %s", event.Err)
+		}
+	}
+}
+
+func TestStreamMessagesDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	event, ok := <-c.StreamMessages(context.Background(), "0123456789ABCDEF")
+	if !ok || event.Err != ErrSearchJobNotFound {
+		t.Errorf("StreamMessages() expected ErrSearchJobNotFound, got %+v", event)
+	}
+}
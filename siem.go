@@ -0,0 +1,91 @@
+package sumologic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields that control forwarding of a source's or collector's messages to Cloud SIEM.
+const (
+	// FieldSIEMForward forwards messages to Cloud SIEM when set to `true`.
+	FieldSIEMForward = "_siemForward"
+	// FieldSIEMParser is the path of the Cloud SIEM parser for the messages, e.g.
+	// `/Parsers/System/AWS/AWS CloudTrail`.
+	FieldSIEMParser = "_parser"
+	// FieldSIEMVendor, FieldSIEMProduct, FieldSIEMFormat and FieldSIEMEventID select a Cloud
+	// SIEM log mapping when messages are not parsed with FieldSIEMParser.
+	FieldSIEMVendor  = "_siemVendor"
+	FieldSIEMProduct = "_siemProduct"
+	FieldSIEMFormat  = "_siemFormat"
+	FieldSIEMEventID = "_siemEventID"
+)
+
+var siemFields = []string{FieldSIEMForward, FieldSIEMParser, FieldSIEMVendor, FieldSIEMProduct, FieldSIEMFormat, FieldSIEMEventID}
+
+// enableSIEMForwarding returns fields, allocated if nil, with FieldSIEMForward set and
+// FieldSIEMParser set to parser unless it is empty.
+func enableSIEMForwarding(fields map[string]string, parser string) map[string]string {
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	fields[FieldSIEMForward] = "true"
+	if parser != "" {
+		fields[FieldSIEMParser] = parser
+	}
+	return fields
+}
+
+// EnableSIEMForwarding forwards the collector's messages to Cloud SIEM, parsed with parser
+// if it is not empty.
+func (c *Collector) EnableSIEMForwarding(parser string) {
+	c.Fields = enableSIEMForwarding(c.Fields, parser)
+}
+
+// SIEMForwardingEnabled reports whether the collector's messages are forwarded to Cloud SIEM.
+func (c Collector) SIEMForwardingEnabled() bool {
+	return c.Fields[FieldSIEMForward] == "true"
+}
+
+// EnableSIEMForwarding forwards the source's messages to Cloud SIEM, parsed with parser if
+// it is not empty.
+func (s *HTTPSource) EnableSIEMForwarding(parser string) {
+	s.Fields = enableSIEMForwarding(s.Fields, parser)
+}
+
+// SIEMForwardingEnabled reports whether the source's messages are forwarded to Cloud SIEM.
+func (s HTTPSource) SIEMForwardingEnabled() bool {
+	return s.Fields[FieldSIEMForward] == "true"
+}
+
+// EnableSIEMForwarding forwards the source's messages to Cloud SIEM, parsed with parser if
+// it is not empty.
+func (s *AWSLogSource) EnableSIEMForwarding(parser string) {
+	s.Fields = enableSIEMForwarding(s.Fields, parser)
+}
+
+// SIEMForwardingEnabled reports whether the source's messages are forwarded to Cloud SIEM.
+func (s AWSLogSource) SIEMForwardingEnabled() bool {
+	return s.Fields[FieldSIEMForward] == "true"
+}
+
+// validateFields checks fields for misspellings of the Cloud SIEM field names that differ
+// only in case or underscores, e.g. `_siemforward` or `_siem_forward`, which Sumo Logic would
+// accept as custom fields that silently don't forward anything, and for FieldSIEMForward
+// values other than `true` and `false`. The names without the leading underscore, such as
+// `parser`, are left alone, as they are plausible custom fields.
+func validateFields(fields map[string]string) error {
+	for name, value := range fields {
+		for _, siemField := range siemFields {
+			if name == siemField || name == strings.TrimPrefix(siemField, "_") {
+				continue
+			}
+			if strings.EqualFold(strings.ReplaceAll(name, "_", ""), strings.ReplaceAll(siemField, "_", "")) {
+				return &ValidationError{Field: "fields", Message: fmt.Sprintf("`%s` must be spelled `%s`", name, siemField)}
+			}
+		}
+		if name == FieldSIEMForward && value != "true" && value != "false" {
+			return &ValidationError{Field: "fields", Message: fmt.Sprintf("`%s` must be `true` or `false`, got `%s`", FieldSIEMForward, value)}
+		}
+	}
+	return nil
+}
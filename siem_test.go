@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnableSIEMForwarding(t *testing.T) {
+	source := HTTPSource{Name: "http", Fields: map[string]string{"team": "security"}}
+	source.EnableSIEMForwarding("/Parsers/System/AWS/AWS CloudTrail")
+
+	if !source.SIEMForwardingEnabled() {
+		t.Errorf("SIEMForwardingEnabled() expected true after EnableSIEMForwarding()")
+	}
+	body, _ := json.Marshal(source)
+	var sent struct {
+		Fields map[string]string `json:"fields"`
+	}
+	json.Unmarshal(body, &sent)
+	if sent.Fields[FieldSIEMForward] != "true" || sent.Fields[FieldSIEMParser] != "/Parsers/System/AWS/AWS CloudTrail" || sent.Fields["team"] != "security" {
+		t.Errorf("Expected the SIEM fields to be added to the existing fields, got %v", sent.Fields)
+	}
+
+	var collector Collector
+	collector.EnableSIEMForwarding("")
+	if !collector.SIEMForwardingEnabled() || len(collector.Fields) != 1 {
+		t.Errorf("EnableSIEMForwarding() expected only ‘%s’ without a parser, got %v", FieldSIEMForward, collector.Fields)
+	}
+
+	var aws AWSLogSource
+	if aws.SIEMForwardingEnabled() {
+		t.Errorf("SIEMForwardingEnabled() expected false without fields")
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	valid := []map[string]string{
+		nil,
+		{FieldSIEMForward: "true", FieldSIEMParser: "/Parsers/System/AWS/AWS CloudTrail"},
+		{FieldSIEMForward: "false", "custom": "value"},
+		{"parser": "json", "siemForward": "yes"},
+	}
+	for _, fields := range valid {
+		if err := validateFields(fields); err != nil {
+			t.Errorf("validateFields(%v) returned an error: %s", fields, err)
+		}
+	}
+
+	invalid := []map[string]string{
+		{"_siemforward": "true"},
+		{"_siem_forward": "true"},
+		{"__parser": "/Parsers/System/AWS/AWS CloudTrail"},
+		{"_Parser": "/Parsers/System/AWS/AWS CloudTrail"},
+		{FieldSIEMForward: "yes"},
+	}
+	for _, fields := range invalid {
+		if _, ok := validateFields(fields).(*ValidationError); !ok {
+			t.Errorf("validateFields(%v) expected a ValidationError", fields)
+		}
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.CreateHTTPSource(1, HTTPSource{Name: "http", Fields: invalid[0]}); err == nil {
+		t.Errorf("CreateHTTPSource() did not return an error for misspelled SIEM fields")
+	}
+}
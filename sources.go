@@ -0,0 +1,266 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Source is a source of any type, as returned by ListSources. Fields common to all source
+// types are decoded; the complete definition is kept so it can be decoded into a
+// type-specific struct such as HTTPSource with Decode.
+type Source struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+	SourceType  string `json:"sourceType"`
+	ContentType string `json:"contentType,omitempty"`
+	Alive       bool   `json:"alive,omitempty"`
+	// Fields are attached to every message of the source, e.g. FieldSIEMForward.
+	Fields map[string]string `json:"fields,omitempty"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes the common fields and keeps the complete definition for Decode.
+func (s *Source) UnmarshalJSON(data []byte) error {
+	type source Source
+	var decoded source
+	if err := unmarshalLenient(data, &decoded); err != nil {
+		return err
+	}
+	*s = Source(decoded)
+	s.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Decode decodes the complete source definition into v, e.g. a *HTTPSource.
+func (s Source) Decode(v interface{}) error {
+	return json.Unmarshal(s.raw, v)
+}
+
+// validate checks the fields common to every source type, as the Validate method of each
+// type-specific struct does.
+func (s Source) validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	return validateFields(s.Fields)
+}
+
+// ListSources returns all sources on the collector with the specified ID.
+// The sources API has no server-side filters, so all of the collector's sources are returned.
+func (s *Client) ListSources(collectorID int) ([]Source, error) {
+	path, err := formatPath("collectors/%d/sources", collectorID)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Sources []Source `json:"sources"`
+	}
+	if _, err := s.do("GET", path, nil, &r); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+	}
+
+	return r.Sources, nil
+}
+
+// GetSourceByName returns the source with the specified name on the collector with the
+// specified ID, or ErrSourceNotFound. The sources API has no name lookup, so the collector's
+// sources are listed and filtered.
+func (s *Client) GetSourceByName(collectorID int, name string) (*Source, error) {
+	sources, err := s.ListSources(collectorID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sources {
+		if sources[i].Name == name {
+			return &sources[i], nil
+		}
+	}
+	return nil, ErrSourceNotFound
+}
+
+// DownloadSourcesJSON returns the JSON file representation of all sources on the collector
+// with the specified ID. This is the format consumed by installed collectors configured
+// with sourceSyncMode=JSON, so the result can be written directly to a sources file.
+func (s *Client) DownloadSourcesJSON(collectorID int) ([]byte, error) {
+	path, err := formatPath("collectors/%d/sources?download=true", collectorID)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if _, err := s.do("GET", path, nil, &data); err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrCollectorNotFound)
+	}
+
+	return data, nil
+}
+
+// BulkDeleteSources deletes the sources with the specified IDs from a collector concurrently,
+// bounded by the client's maximum concurrency (see WithMaxConcurrency). Results are returned
+// in the same order as ids, so one failed delete doesn't stop the rest; sources that don't
+// exist are skipped. Once ctx is done, in-flight deletes are aborted and the remaining
+// sources fail with ctx.Err().
+func (s *Client) BulkDeleteSources(ctx context.Context, collectorID int, ids []int) OperationResults {
+	results := make(OperationResults, len(ids))
+	s.forEach(len(ids), func(i int) {
+		results[i] = OperationResult{ResourceType: ResourceTypeSource, ResourceID: strconv.Itoa(ids[i]), Outcome: OperationDeleted}
+		err := ctx.Err()
+		if err == nil {
+			err = s.deleteSource(ctx, collectorID, ids[i], "")
+		}
+		switch err {
+		case nil:
+		case ErrSourceNotFound:
+			results[i].Outcome = OperationSkipped
+			results[i].Reason = "not found"
+		default:
+			results[i].Outcome = OperationFailed
+			results[i].Reason = err.Error()
+			results[i].Err = err
+		}
+	})
+	return results
+}
+
+// DeleteSourcesByCategory deletes the sources on a collector whose category matches
+// categoryGlob, where `*` matches any characters (e.g. `prod/payments/*`), concurrently like
+// BulkDeleteSources. Categories are matched case-insensitively. If dryRun is true, nothing is
+// deleted and every matching source is reported as skipped, so the report can be reviewed
+// before decommissioning an application.
+func (s *Client) DeleteSourcesByCategory(collectorID int, categoryGlob string, dryRun bool) (OperationResults, error) {
+	sources, err := s.ListSources(collectorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []Source
+	for _, source := range sources {
+		if matchGlob(categoryGlob, source.Category) {
+			matching = append(matching, source)
+		}
+	}
+
+	var results OperationResults
+	if dryRun {
+		results = make(OperationResults, len(matching))
+		for i, source := range matching {
+			results[i] = OperationResult{ResourceType: ResourceTypeSource, ResourceID: strconv.Itoa(source.ID), Outcome: OperationSkipped, Reason: "dry run"}
+		}
+	} else {
+		ids := make([]int, len(matching))
+		for i, source := range matching {
+			ids[i] = source.ID
+		}
+		results = s.BulkDeleteSources(context.Background(), collectorID, ids)
+	}
+	for i, source := range matching {
+		results[i].Name = source.Name
+	}
+	return results, nil
+}
+
+// deleteSource deletes a source of any type. If etag is not empty, it's sent as If-Match.
+func (s *Client) deleteSource(ctx context.Context, collectorID int, id int, etag string) error {
+	_, err := s.deleteSourceWithResponse(ctx, collectorID, id, etag)
+	return err
+}
+
+// deleteSourceWithResponse is deleteSource returning the response.
+func (s *Client) deleteSourceWithResponse(ctx context.Context, collectorID int, id int, etag string) (*http.Response, error) {
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.beforeMutation(ChangeDelete, ResourceTypeSource, id, collectorID, nil); err != nil {
+		return nil, err
+	}
+	resp, err := s.doIfMatchContext(ctx, "DELETE", path, etag, nil, nil)
+	if err != nil {
+		return nil, errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
+	}
+
+	s.recordChange(ChangeDelete, ResourceTypeSource, id, collectorID, nil)
+	return resp, nil
+}
+
+// getSource gets the source with the specified ID into out, a source request wrapper such
+// as *SyslogSourceRequest, and returns its ETag.
+func (s *Client) getSource(collectorID int, id int, out interface{}) (string, error) {
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.do("GET", path, nil, out)
+	if err != nil {
+		return "", errorForStatus(err, http.StatusNotFound, ErrSourceNotFound)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// createSource creates the source wrapped in request, named name, on the collector with the
+// specified ID and decodes the created source into out.
+func (s *Client) createSource(collectorID int, name string, request, out interface{}) error {
+	path, err := formatPath("collectors/%d/sources", collectorID)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeCreate, ResourceTypeSource, nil, collectorID, wrappedSource(request)); err != nil {
+		return err
+	}
+	if _, err := s.do("POST", path, request, out); err != nil {
+		e, ok := badRequest(err)
+		if !ok {
+			return sourceBadRequest(err, name)
+		}
+		if err := planLimitError(ResourceTypeSource, e); err != nil {
+			return err
+		}
+		return err
+	}
+	return nil
+}
+
+// updateSource updates the source with the specified ID, named name, with the source wrapped
+// in request if it still has etag, and decodes the updated source into out.
+func (s *Client) updateSource(collectorID int, id int, name, etag string, request, out interface{}) error {
+	etag, err := s.resolveETag(etag, "collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return err
+	}
+	path, err := formatPath("collectors/%d/sources/%d", collectorID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.beforeMutation(ChangeUpdate, ResourceTypeSource, id, collectorID, wrappedSource(request)); err != nil {
+		return err
+	}
+	if _, err := s.doIfMatch("PUT", path, etag, request, out); err != nil {
+		return sourceBadRequest(err, name)
+	}
+	return nil
+}
+
+// wrappedSource returns the JSON of the source wrapped in request, a source request wrapper
+// such as SyslogSourceRequest.
+func wrappedSource(request interface{}) json.RawMessage {
+	var r struct {
+		Source json.RawMessage `json:"source"`
+	}
+	data, _ := json.Marshal(request)
+	json.Unmarshal(data, &r)
+	return r.Source
+}
+
+// sourceBadRequest explains a 400 response to creating or updating a source named name.
+func sourceBadRequest(err error, name string) error {
+	return errorForStatus(err, http.StatusBadRequest, fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", name))
+}
@@ -0,0 +1,295 @@
+package sumologic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDownloadSourcesJSONOK(t *testing.T) {
+	sourcesJSON := `{"api.version":"v1","sources":[{"name":"test","sourceType":"LocalFile","pathExpression":"/var/log/*.log"}]}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d/sources", defaultCollector.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("download") != "true" {
+			t.Errorf("Expected query parameter ‘download=true’, got ‘%s’", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(sourcesJSON))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	body, err := c.DownloadSourcesJSON(defaultCollector.ID)
+	if err != nil {
+		t.Errorf("DownloadSourcesJSON() returned an error: %s", err)
+		return
+	}
+	if string(body) != sourcesJSON {
+		t.Errorf("DownloadSourcesJSON() expected `%s`, got `%s`", sourcesJSON, body)
+		return
+	}
+}
+
+func TestDownloadSourcesJSONDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.DownloadSourcesJSON(defaultCollector.ID)
+	if err != ErrCollectorNotFound {
+		t.Errorf("DownloadSourcesJSON() returned the wrong error: %s", err)
+		return
+	}
+}
+
+func TestListSourcesOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		expectedURL := fmt.Sprintf("/collectors/%d/sources", defaultCollector.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"sources":[
+			{"id":1,"name":"http","sourceType":"HTTP","messagePerRequest":true,"url":"https://secret"},
+			{"id":2,"name":"cloudtrail","sourceType":"Polling","contentType":"AwsCloudTrailBucket","scanInterval":300000}
+		]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	sources, err := c.ListSources(defaultCollector.ID)
+	if err != nil {
+		t.Errorf("ListSources() returned an error: %s", err)
+		return
+	}
+	if len(sources) != 2 || sources[1].ContentType != "AwsCloudTrailBucket" {
+		t.Errorf("ListSources() returned unexpected sources: %+v", sources)
+		return
+	}
+
+	var httpSource HTTPSource
+	if err := sources[0].Decode(&httpSource); err != nil {
+		t.Errorf("Decode() returned an error: %s", err)
+		return
+	}
+	if !httpSource.MessagePerRequest || httpSource.Url != "https://secret" {
+		t.Errorf("Decode() did not decode type-specific fields: %+v", httpSource)
+	}
+}
+
+// largeSourcesResponse is a list sources response with many sources, for benchmarks.
+func largeSourcesResponse() []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"sources":[`)
+	for i := 1; i <= 2000; i++ {
+		if i > 1 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":%d,"name":"source-%d","category":"prod/app/%d","sourceType":"HTTP","url":"https://endpoint.collection.sumologic.com/receiver/v1/http/%040d"}`, i, i, i, i)
+	}
+	b.WriteString(`]}`)
+	return b.Bytes()
+}
+
+func BenchmarkListSources(b *testing.B) {
+	response := largeSourcesResponse()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(response)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.ListSources(1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeSourcesBuffered and BenchmarkDecodeSourcesStreaming compare reading a whole
+// response body before unmarshaling it with decoding it straight from the body.
+func BenchmarkDecodeSourcesBuffered(b *testing.B) {
+	response := largeSourcesResponse()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		body, err := io.ReadAll(bytes.NewReader(response))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var r struct {
+			Sources []Source `json:"sources"`
+		}
+		if err := json.Unmarshal(body, &r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeSourcesStreaming(b *testing.B) {
+	response := largeSourcesResponse()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var r struct {
+			Sources []Source `json:"sources"`
+		}
+		if err := json.NewDecoder(bytes.NewReader(response)).Decode(&r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGetSourceByName(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sources":[{"id":1,"name":"http","sourceType":"HTTP"},{"id":2,"name":"cloudtrail","sourceType":"Polling"}]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, err := c.GetSourceByName(defaultCollector.ID, "cloudtrail")
+	if err != nil || source.ID != 2 {
+		t.Errorf("GetSourceByName() expected source 2, got %+v and %v", source, err)
+	}
+	if _, err := c.GetSourceByName(defaultCollector.ID, "missing"); err != ErrSourceNotFound {
+		t.Errorf("GetSourceByName() expected ErrSourceNotFound, got %v", err)
+	}
+}
+
+func TestBulkDeleteSources(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/sources/2"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/sources/3"):
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithMaxConcurrency(2))
+	results := c.BulkDeleteSources(context.Background(), 1, []int{1, 2, 3, 4})
+
+	expected := []string{OperationDeleted, OperationSkipped, OperationFailed, OperationDeleted}
+	for i, result := range results {
+		if result.Outcome != expected[i] {
+			t.Errorf("BulkDeleteSources() expected source %s to be ‘%s’, got ‘%s’", result.ResourceID, expected[i], result.Outcome)
+		}
+		if (result.Err != nil) != (result.Outcome == OperationFailed) {
+			t.Errorf("BulkDeleteSources() expected an error only for failed deletes, got %v for source %s", result.Err, result.ResourceID)
+		}
+	}
+	if summary := results.Summary(); summary != "2 deleted, 1 skipped, 1 failed" {
+		t.Errorf("Summary() expected ‘2 deleted, 1 skipped, 1 failed’, got ‘%s’", summary)
+	}
+}
+
+func TestBulkDeleteSourcesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		cancel()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithMaxConcurrency(1))
+	results := c.BulkDeleteSources(ctx, 1, []int{1, 2, 3})
+
+	if requests != 1 {
+		t.Errorf("BulkDeleteSources() expected no requests after cancellation, got %d", requests)
+	}
+	last := results[len(results)-1]
+	if last.Outcome != OperationFailed || last.Err != context.Canceled {
+		t.Errorf("BulkDeleteSources() expected remaining sources to fail with context.Canceled, got %+v", last)
+	}
+}
+
+func TestDeleteSourcesByCategory(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"sources":[
+				{"id":1,"name":"api","category":"prod/payments/api","sourceType":"HTTP"},
+				{"id":2,"name":"billing","category":"prod/billing","sourceType":"HTTP"},
+				{"id":3,"name":"worker","category":"Prod/Payments/Worker","sourceType":"HTTP"}
+			]}`))
+		case "DELETE":
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+
+	results, err := c.DeleteSourcesByCategory(1, "prod/payments/*", true)
+	if err != nil {
+		t.Errorf("DeleteSourcesByCategory() returned an error: %s", err)
+		return
+	}
+	if len(deleted) != 0 {
+		t.Errorf("DeleteSourcesByCategory() deleted sources in a dry run: %v", deleted)
+	}
+	if results.Summary() != "2 skipped" || results[0].Name != "api" || results[1].Name != "worker" {
+		t.Errorf("DeleteSourcesByCategory() expected api and worker to be skipped, got %+v", results)
+	}
+
+	results, err = c.DeleteSourcesByCategory(1, "prod/payments/*", false)
+	if err != nil {
+		t.Errorf("DeleteSourcesByCategory() returned an error: %s", err)
+		return
+	}
+	if results.Summary() != "2 deleted" || len(deleted) != 2 {
+		t.Errorf("DeleteSourcesByCategory() expected api and worker to be deleted, got %+v", results)
+	}
+}
@@ -0,0 +1,118 @@
+package sumologictest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// TestConcurrentCRUD exercises a single client from many goroutines through its shared
+// transport, retry, metrics, response hook and change recorder. Run it with -race
+// (`make testrace`) to verify the client is safe for concurrent use.
+func TestConcurrentCRUD(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	var mu sync.Mutex
+	responses := 0
+	recorder := new(countingRecorder)
+	c, err := ts.NewClient(
+		sumologic.WithChangeRecorder(recorder),
+		sumologic.WithMaxIdleConnsPerHost(8),
+		sumologic.WithTransientRetry(backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}),
+		sumologic.WithResponseHook(func(r *sumologic.Response) {
+			mu.Lock()
+			responses++
+			mu.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- crud(c, i)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	if changes := recorder.count(); changes != workers*7 {
+		t.Errorf("ChangeRecorder expected %d changes, got %d", workers*7, changes)
+	}
+	var calls int64
+	for _, m := range c.Metrics() {
+		calls += m.Calls
+	}
+	if calls != int64(responses) || calls == 0 {
+		t.Errorf("Metrics() expected %d calls, got %d", responses, calls)
+	}
+}
+
+// countingRecorder counts recorded changes.
+type countingRecorder struct {
+	mu      sync.Mutex
+	changes int
+}
+
+func (r *countingRecorder) RecordChange(change sumologic.Change) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes++
+}
+
+func (r *countingRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changes
+}
+
+// crud creates, reads, updates and deletes a collector with a source using c.
+func crud(c *sumologic.Client, i int) error {
+	collector, err := c.CreateHostedCollector(sumologic.Collector{Name: fmt.Sprintf("collector-%d", i), CollectorType: "Hosted"})
+	if err != nil {
+		return fmt.Errorf("CreateHostedCollector() returned an error: %s", err)
+	}
+	source, err := c.CreateHTTPSource(collector.ID, sumologic.HTTPSource{Name: "source", SourceType: "HTTP"})
+	if err != nil {
+		return fmt.Errorf("CreateHTTPSource() returned an error: %s", err)
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		current, etag, err := c.GetHostedCollector(collector.ID)
+		if err != nil {
+			return fmt.Errorf("GetHostedCollector() returned an error: %s", err)
+		}
+		current.Description = fmt.Sprintf("update %d", attempt)
+		if _, err := c.UpdateHostedCollector(*current, etag); err != nil {
+			return fmt.Errorf("UpdateHostedCollector() returned an error: %s", err)
+		}
+	}
+
+	if _, err := c.ListSources(collector.ID); err != nil {
+		return fmt.Errorf("ListSources() returned an error: %s", err)
+	}
+	if err := c.DeleteHTTPSource(collector.ID, source.ID); err != nil {
+		return fmt.Errorf("DeleteHTTPSource() returned an error: %s", err)
+	}
+	if err := c.DeleteHostedCollector(collector.ID); err != nil {
+		return fmt.Errorf("DeleteHostedCollector() returned an error: %s", err)
+	}
+	return nil
+}
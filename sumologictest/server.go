@@ -0,0 +1,325 @@
+// Package sumologictest provides an in-memory fake of the Sumo Logic collectors and sources
+// API for testing code built on the sumologic client.
+//
+// The fake enforces the API's optimistic concurrency control: every collector and source has
+// an ETag that changes on every write, updates must send the current ETag in If-Match, and
+// updates or deletes with a stale If-Match fail with 412 Precondition Failed.
+package sumologictest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+// apiPrefix is the path of the API on the server.
+const apiPrefix = "/api/v1/"
+
+// resource is a stored collector or source definition and its current ETag.
+type resource struct {
+	definition map[string]interface{}
+	etag       string
+}
+
+// collector is a stored collector and its sources.
+type collector struct {
+	resource
+	sources map[int]*resource
+}
+
+// Server is a fake Sumo Logic API server. Any non-empty Authorization header is accepted.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	lastID     int
+	version    int
+	collectors map[int]*collector
+}
+
+// NewServer starts a fake server. The caller must call Close when done.
+func NewServer() *Server {
+	s := &Server{collectors: map[int]*collector{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// EndpointURL is the API endpoint URL to create clients with.
+func (s *Server) EndpointURL() string {
+	return s.URL + apiPrefix
+}
+
+// NewClient creates a client for the server.
+func (s *Server) NewClient(options ...sumologic.ClientOption) (*sumologic.Client, error) {
+	return sumologic.NewClient("accessToken", s.EndpointURL(), options...)
+}
+
+// TouchCollector changes the ETag of the collector with the specified ID, as a concurrent
+// write by another client would. It reports whether the collector exists.
+func (s *Server) TouchCollector(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collectors[id]
+	if ok {
+		c.etag = s.nextETag()
+	}
+	return ok
+}
+
+// TouchSource changes the ETag of the source with the specified ID, as a concurrent write by
+// another client would. It reports whether the source exists.
+func (s *Server) TouchSource(collectorID, id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collectors[collectorID]
+	if !ok {
+		return false
+	}
+	r, ok := c.sources[id]
+	if ok {
+		r.etag = s.nextETag()
+	}
+	return ok
+}
+
+func (s *Server) nextETag() string {
+	s.version++
+	return fmt.Sprintf(`"%016x"`, s.version)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Full authentication is required")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not.found", "Unknown API")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name := strings.TrimPrefix(r.URL.EscapedPath(), apiPrefix+"collectors/name/"); name != r.URL.EscapedPath() {
+		s.serveCollectorByName(w, r, name)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+	ids := make([]int, 0, 2)
+	for i := 1; i < len(parts); i += 2 {
+		id, err := strconv.Atoi(parts[i])
+		if err != nil {
+			writeError(w, http.StatusNotFound, "not.found", "Unknown API")
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] == "collectors":
+		s.serveCollectors(w, r)
+	case len(parts) == 2 && parts[0] == "collectors":
+		s.serveCollector(w, r, ids[0])
+	case len(parts) == 3 && parts[0] == "collectors" && parts[2] == "sources":
+		s.serveSources(w, r, ids[0])
+	case len(parts) == 4 && parts[0] == "collectors" && parts[2] == "sources":
+		s.serveSource(w, r, ids[0], ids[1])
+	default:
+		writeError(w, http.StatusNotFound, "not.found", "Unknown API")
+	}
+}
+
+func (s *Server) serveCollectors(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		ids := make([]int, 0, len(s.collectors))
+		for id := range s.collectors {
+			ids = append(ids, id)
+		}
+		ids = page(sortedIDs(ids), r)
+		collectors := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			collectors = append(collectors, s.collectors[id].definition)
+		}
+		writeJSON(w, http.StatusOK, "", map[string]interface{}{"collectors": collectors})
+	case "POST":
+		definition, ok := decodeDefinition(w, r, "collector")
+		if !ok {
+			return
+		}
+		s.lastID++
+		definition["id"] = s.lastID
+		c := &collector{resource: resource{definition: definition, etag: s.nextETag()}, sources: map[int]*resource{}}
+		s.collectors[s.lastID] = c
+		writeJSON(w, http.StatusCreated, c.etag, map[string]interface{}{"collector": definition})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method.not.allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) serveCollectorByName(w http.ResponseWriter, r *http.Request, escapedName string) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "method.not.allowed", "Method not allowed")
+		return
+	}
+	name, err := url.PathUnescape(escapedName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "request.invalid", "Invalid collector name")
+		return
+	}
+	for _, c := range s.collectors {
+		if c.definition["name"] == name {
+			writeJSON(w, http.StatusOK, c.etag, map[string]interface{}{"collector": c.definition})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "collector.invalid", "Invalid collector")
+}
+
+func (s *Server) serveCollector(w http.ResponseWriter, r *http.Request, id int) {
+	c, ok := s.collectors[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "collector.invalid", "Invalid collector")
+		return
+	}
+	if !s.serveResource(w, r, &c.resource, "collector", id) {
+		return
+	}
+	if r.Method == "DELETE" {
+		delete(s.collectors, id)
+	}
+}
+
+func (s *Server) serveSources(w http.ResponseWriter, r *http.Request, collectorID int) {
+	c, ok := s.collectors[collectorID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "collector.invalid", "Invalid collector")
+		return
+	}
+	switch r.Method {
+	case "GET":
+		ids := make([]int, 0, len(c.sources))
+		for id := range c.sources {
+			ids = append(ids, id)
+		}
+		sources := make([]map[string]interface{}, 0, len(c.sources))
+		for _, id := range sortedIDs(ids) {
+			sources = append(sources, c.sources[id].definition)
+		}
+		writeJSON(w, http.StatusOK, "", map[string]interface{}{"sources": sources})
+	case "POST":
+		definition, ok := decodeDefinition(w, r, "source")
+		if !ok {
+			return
+		}
+		s.lastID++
+		definition["id"] = s.lastID
+		source := &resource{definition: definition, etag: s.nextETag()}
+		c.sources[s.lastID] = source
+		writeJSON(w, http.StatusCreated, source.etag, map[string]interface{}{"source": definition})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method.not.allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) serveSource(w http.ResponseWriter, r *http.Request, collectorID, id int) {
+	c, ok := s.collectors[collectorID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "collector.invalid", "Invalid collector")
+		return
+	}
+	source, ok := c.sources[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "source.invalid", "Invalid source")
+		return
+	}
+	if !s.serveResource(w, r, source, "source", id) {
+		return
+	}
+	if r.Method == "DELETE" {
+		delete(c.sources, id)
+	}
+}
+
+// serveResource gets, updates or deletes a collector or source wrapped in key. It reports
+// whether a DELETE request may proceed.
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, res *resource, key string, id int) bool {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, res.etag, map[string]interface{}{key: res.definition})
+	case "PUT":
+		if r.Header.Get("If-Match") != res.etag {
+			writeError(w, http.StatusPreconditionFailed, "precondition.failed", "Invalid If-Match header")
+			return false
+		}
+		definition, ok := decodeDefinition(w, r, key)
+		if !ok {
+			return false
+		}
+		definition["id"] = id
+		res.definition = definition
+		res.etag = s.nextETag()
+		writeJSON(w, http.StatusOK, res.etag, map[string]interface{}{key: definition})
+	case "DELETE":
+		if match := r.Header.Get("If-Match"); match != "" && match != res.etag {
+			writeError(w, http.StatusPreconditionFailed, "precondition.failed", "Invalid If-Match header")
+			return false
+		}
+		w.WriteHeader(http.StatusOK)
+		return true
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method.not.allowed", "Method not allowed")
+	}
+	return false
+}
+
+func sortedIDs(ids []int) []int {
+	sort.Ints(ids)
+	return ids
+}
+
+// page applies the offset and limit query parameters of r to ids.
+func page(ids []int, r *http.Request) []int {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 || offset > len(ids) {
+		offset = len(ids)
+	}
+	ids = ids[offset:]
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit >= 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// decodeDefinition decodes the request body wrapped in key, writing a 400 response if it
+// can't be decoded.
+func decodeDefinition(w http.ResponseWriter, r *http.Request, key string) (map[string]interface{}, bool) {
+	var body map[string]map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body[key] == nil {
+		writeError(w, http.StatusBadRequest, "request.invalid", fmt.Sprintf("Expected a %s definition", key))
+		return nil, false
+	}
+	return body[key], true
+}
+
+func writeJSON(w http.ResponseWriter, status int, etag string, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, "", sumologic.Error{Status: status, Code: code, Message: message})
+}
@@ -0,0 +1,304 @@
+package sumologictest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+func TestCollectorETagRoundTrip(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	created, err := c.CreateHostedCollector(sumologic.Collector{Name: "collector", CollectorType: "Hosted"})
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	collector, etag, err := c.GetHostedCollector(created.ID)
+	if err != nil || etag == "" {
+		t.Errorf("GetHostedCollector() expected an ETag, got ‘%s’ and %v", etag, err)
+		return
+	}
+
+	collector.Description = "updated"
+	if _, err := c.UpdateHostedCollector(*collector, etag); err != nil {
+		t.Errorf("UpdateHostedCollector() returned an error: %s", err)
+		return
+	}
+
+	collector.Description = "stale"
+	if _, err := c.UpdateHostedCollector(*collector, etag); err != sumologic.ErrPreconditionFailed {
+		t.Errorf("UpdateHostedCollector() expected ErrPreconditionFailed for a stale ETag, got %v", err)
+	}
+
+	collector, rotated, err := c.GetHostedCollector(created.ID)
+	if err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if rotated == etag {
+		t.Errorf("UpdateHostedCollector() expected the ETag to change, got ‘%s’", rotated)
+	}
+	if collector.Description != "updated" {
+		t.Errorf("GetHostedCollector() expected description ‘updated’, got ‘%s’", collector.Description)
+	}
+}
+
+func TestSourceConcurrentModification(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, err := c.CreateHostedCollector(sumologic.Collector{Name: "collector", CollectorType: "Hosted"})
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	created, err := c.CreateHTTPSource(collector.ID, sumologic.HTTPSource{Name: "source", SourceType: "HTTP"})
+	if err != nil {
+		t.Errorf("CreateHTTPSource() returned an error: %s", err)
+		return
+	}
+	source, etag, err := c.GetHTTPSource(collector.ID, created.ID)
+	if err != nil {
+		t.Errorf("GetHTTPSource() returned an error: %s", err)
+		return
+	}
+
+	if !ts.TouchSource(collector.ID, source.ID) {
+		t.Errorf("TouchSource() expected the source to exist")
+		return
+	}
+	if _, err := c.UpdateHTTPSource(collector.ID, *source, etag); err != sumologic.ErrPreconditionFailed {
+		t.Errorf("UpdateHTTPSource() expected ErrPreconditionFailed after a concurrent write, got %v", err)
+	}
+	if err := c.DeleteHTTPSourceWithETag(collector.ID, source.ID, etag); err != sumologic.ErrPreconditionFailed {
+		t.Errorf("DeleteHTTPSourceWithETag() expected ErrPreconditionFailed after a concurrent write, got %v", err)
+	}
+
+	_, etag, err = c.GetHTTPSource(collector.ID, source.ID)
+	if err != nil {
+		t.Errorf("GetHTTPSource() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteHTTPSourceWithETag(collector.ID, source.ID, etag); err != nil {
+		t.Errorf("DeleteHTTPSourceWithETag() returned an error: %s", err)
+	}
+	if _, _, err := c.GetHTTPSource(collector.ID, source.ID); err != sumologic.ErrSourceNotFound {
+		t.Errorf("GetHTTPSource() expected ErrSourceNotFound after delete, got %v", err)
+	}
+}
+
+func TestDeleteCollectorWithStaleETag(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, err := c.CreateHostedCollector(sumologic.Collector{Name: "collector", CollectorType: "Hosted"})
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	_, etag, err := c.GetHostedCollector(collector.ID)
+	if err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+
+	ts.TouchCollector(collector.ID)
+	if err := c.DeleteHostedCollectorWithETag(collector.ID, etag); err != sumologic.ErrPreconditionFailed {
+		t.Errorf("DeleteHostedCollectorWithETag() expected ErrPreconditionFailed, got %v", err)
+	}
+	if err := c.DeleteHostedCollector(collector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+	}
+	collectors, err := c.ListCollectors(sumologic.ListCollectorsOptions{})
+	if err != nil || len(collectors) != 0 {
+		t.Errorf("ListCollectors() expected no collectors, got %v and %v", collectors, err)
+	}
+}
+
+func TestCollectorBackupRoundTrip(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, _, err := c.CreateCollectorWithSources(sumologic.Collector{Name: "collector", CollectorType: "Hosted", TimeZone: "UTC"}, []sumologic.SourceSpec{
+		sumologic.HTTPSource{Name: "http", SourceType: "HTTP"},
+		sumologic.AWSLogSource{Name: "cloudtrail", SourceType: "Polling", ContentType: "AwsCloudTrailBucket"},
+	}, true)
+	if err != nil {
+		t.Errorf("CreateCollectorWithSources() returned an error: %s", err)
+		return
+	}
+	backup, err := c.BackupCollector(collector.ID)
+	if err != nil {
+		t.Errorf("BackupCollector() returned an error: %s", err)
+		return
+	}
+	data, err := json.Marshal(backup)
+	if err != nil {
+		t.Errorf("json.Marshal() returned an error: %s", err)
+		return
+	}
+
+	parsed, err := sumologic.ParseCollectorBackup(data)
+	if err != nil {
+		t.Errorf("ParseCollectorBackup() returned an error: %s", err)
+		return
+	}
+	restored, sourceIDs, err := c.RestoreCollector(*parsed, true)
+	if err != nil {
+		t.Errorf("RestoreCollector() returned an error: %s", err)
+		return
+	}
+	if restored.ID == collector.ID || !restored.Equivalent(*collector) || len(sourceIDs) != 2 {
+		t.Errorf("RestoreCollector() expected an equivalent new collector with 2 sources, got %+v and %v", restored, sourceIDs)
+	}
+	sources, err := c.ListSources(restored.ID)
+	if err != nil || len(sources) != 2 || sources[1].ContentType != "AwsCloudTrailBucket" {
+		t.Errorf("ListSources() expected the restored sources, got %+v and %v", sources, err)
+	}
+}
+
+func TestGetCollectorByName(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	created, err := c.CreateHostedCollector(sumologic.Collector{Name: "prod/payments", CollectorType: "Hosted"})
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	collector, etag, err := c.GetCollectorByName("prod/payments")
+	if err != nil || collector.ID != created.ID || etag == "" {
+		t.Errorf("GetCollectorByName() expected collector %d with an ETag, got %+v, ‘%s’ and %v", created.ID, collector, etag, err)
+	}
+	if _, _, err := c.GetCollectorByName("missing"); err != sumologic.ErrCollectorNotFound {
+		t.Errorf("GetCollectorByName() expected ErrCollectorNotFound, got %v", err)
+	}
+}
+
+func TestMutationsWithResponse(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, r, err := c.CreateHostedCollectorWithResponse(sumologic.Collector{Name: "collector", CollectorType: "Hosted"})
+	if err != nil {
+		t.Errorf("CreateHostedCollectorWithResponse() returned an error: %s", err)
+		return
+	}
+	collector.Description = "updated"
+	if _, r, err = c.UpdateHostedCollectorWithResponse(*collector, r.ETag()); err != nil {
+		t.Errorf("UpdateHostedCollectorWithResponse() with the ETag of the create returned an error: %s", err)
+		return
+	}
+
+	source, sr, err := c.CreateHTTPSourceWithResponse(collector.ID, sumologic.HTTPSource{Name: "http", SourceType: "HTTP"})
+	if err != nil {
+		t.Errorf("CreateHTTPSourceWithResponse() returned an error: %s", err)
+		return
+	}
+	source.Description = "updated"
+	if _, sr, err = c.UpdateHTTPSourceWithResponse(collector.ID, *source, sr.ETag()); err != nil {
+		t.Errorf("UpdateHTTPSourceWithResponse() with the ETag of the create returned an error: %s", err)
+		return
+	}
+	if _, err := c.DeleteHTTPSourceWithResponse(collector.ID, source.ID, sr.ETag()); err != nil {
+		t.Errorf("DeleteHTTPSourceWithResponse() with the ETag of the update returned an error: %s", err)
+	}
+	if _, err := c.DeleteHostedCollectorWithResponse(collector.ID, r.ETag()); err != nil {
+		t.Errorf("DeleteHostedCollectorWithResponse() with the ETag of the update returned an error: %s", err)
+	}
+}
+
+func TestFieldsRoundTrip(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := ts.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	created, err := c.CreateHostedCollector(sumologic.Collector{Name: "collector", CollectorType: "Hosted", Fields: map[string]string{"team": "payments"}})
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	source, err := c.CreateHTTPSource(created.ID, sumologic.HTTPSource{Name: "http", SourceType: "HTTP", Fields: map[string]string{sumologic.FieldSIEMForward: "true"}})
+	if err != nil {
+		t.Errorf("CreateHTTPSource() returned an error: %s", err)
+		return
+	}
+
+	collector, etag, err := c.GetHostedCollector(created.ID)
+	if err != nil || collector.Fields["team"] != "payments" {
+		t.Errorf("GetHostedCollector() expected the fields of the create, got %v and %v", collector.Fields, err)
+		return
+	}
+	sources, err := c.ListSources(created.ID)
+	if err != nil || len(sources) != 1 || sources[0].Fields[sumologic.FieldSIEMForward] != "true" {
+		t.Errorf("ListSources() expected the fields of the create, got %+v and %v", sources, err)
+	}
+
+	collector.Fields = nil
+	collector.ForceSendFields = []string{"fields"}
+	if _, err := c.UpdateHostedCollector(*collector, etag); err != nil {
+		t.Errorf("UpdateHostedCollector() returned an error: %s", err)
+		return
+	}
+	if collector, _, err = c.GetHostedCollector(created.ID); err != nil || len(collector.Fields) != 0 {
+		t.Errorf("UpdateHostedCollector() expected to remove the fields, got %v and %v", collector.Fields, err)
+	}
+
+	updated, etag, err := c.GetHTTPSource(created.ID, source.ID)
+	if err != nil {
+		t.Errorf("GetHTTPSource() returned an error: %s", err)
+		return
+	}
+	updated.Fields["team"] = "payments"
+	if _, err := c.UpdateHTTPSource(created.ID, *updated, etag); err != nil {
+		t.Errorf("UpdateHTTPSource() returned an error: %s", err)
+		return
+	}
+	if updated, _, err = c.GetHTTPSource(created.ID, source.ID); err != nil || len(updated.Fields) != 2 {
+		t.Errorf("UpdateHTTPSource() expected both fields, got %v and %v", updated.Fields, err)
+	}
+}
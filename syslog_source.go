@@ -0,0 +1,209 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Syslog protocols, for SyslogSource.Protocol.
+const (
+	SyslogProtocolUDP = "UDP"
+	SyslogProtocolTCP = "TCP"
+)
+
+// SyslogSourceRequest is a necessary wrapper for source API calls.
+type SyslogSourceRequest struct {
+	Source SyslogSource `json:"source"`
+}
+
+// SyslogSource is a source on an installed collector that listens for syslog messages, e.g.
+// from network gear, on a port.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type SyslogSource struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	CollectorID int    `json:"CollectorId,omitempty"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	HostName    string `json:"hostName,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+	SourceType  string `json:"sourceType,omitempty"`
+	// Protocol is SyslogProtocolUDP (the default) or SyslogProtocolTCP.
+	Protocol string `json:"protocol,omitempty"`
+	Port     int    `json:"port"`
+	// ForceTimeZone uses TimeZone even for messages with a time zone of their own.
+	ForceTimeZone              bool         `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing       bool         `json:"automaticDateParsing,omitempty"`
+	DefaultDateFormats         []DateFormat `json:"defaultDateFormats,omitempty"`
+	MultilineProcessingEnabled bool         `json:"multilineProcessingEnabled,omitempty"`
+	UseAutolineMatching        bool         `json:"useAutolineMatching,omitempty"`
+	ManualPrefixRegexp         string       `json:"manualPrefixRegexp,omitempty"`
+	Filters                    []Filter     `json:"filters,omitempty"`
+	Alive                      bool         `json:"alive,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// syslogSourceType is the sourceType of syslog sources.
+const syslogSourceType = "Syslog"
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s SyslogSource) MarshalJSON() ([]byte, error) {
+	type syslogSource SyslogSource
+	return marshalForceSend(syslogSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *SyslogSource) UnmarshalJSON(data []byte) error {
+	type syslogSource SyslogSource
+	return unmarshalLenient(data, (*syslogSource)(s))
+}
+
+// Validate checks the source's protocol and port and the fields validated for all sources,
+// without calling the API.
+func (s SyslogSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if err := validateDateFormats(s.DefaultDateFormats); err != nil {
+		return err
+	}
+	if err := ValidateBoundaryRegex(s.ManualPrefixRegexp); err != nil {
+		return err
+	}
+	if s.Protocol != "" && s.Protocol != SyslogProtocolUDP && s.Protocol != SyslogProtocolTCP {
+		return &ValidationError{Field: "protocol", Message: fmt.Sprintf("must be `%s` or `%s`, got `%s`", SyslogProtocolUDP, SyslogProtocolTCP, s.Protocol)}
+	}
+	if s.Port < 1 || s.Port > 65535 {
+		return &ValidationError{Field: "port", Message: fmt.Sprintf("must be between 1 and 65535, got %d", s.Port)}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and liveness) are ignored.
+func (s SyslogSource) Equivalent(other SyslogSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s SyslogSource) userManaged() SyslogSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Alive = false
+	if s.Protocol == "" {
+		s.Protocol = SyslogProtocolUDP
+	}
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.DefaultDateFormats) == 0 {
+		s.DefaultDateFormats = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s SyslogSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s SyslogSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s SyslogSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s SyslogSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+func (source SyslogSource) createOn(s *Client, collector Collector) (int, error) {
+	created, err := s.CreateSyslogSource(collector.ID, source)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// GetSyslogSource gets the source with the specified ID.
+func (s *Client) GetSyslogSource(collectorID int, id int) (*SyslogSource, string, error) {
+	var r = new(SyslogSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	return &r.Source, etag, nil
+}
+
+// CreateSyslogSource creates a new SyslogSource. An empty SourceType is set to `Syslog`.
+func (s *Client) CreateSyslogSource(collectorID int, source SyslogSource) (*SyslogSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = syslogSourceType
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(SyslogSourceRequest)
+	if err := s.createSource(collectorID, source.Name, SyslogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateSyslogSource updates an existing syslog source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateSyslogSource(collectorID int, source SyslogSource, etag string) (*SyslogSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(SyslogSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, SyslogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteSyslogSource deletes the source with the specified ID.
+func (s *Client) DeleteSyslogSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteSyslogSourceWithETag deletes the source with the specified ID only if it hasn't changed since
+// the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteSyslogSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteSyslogSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteSyslogSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteSyslogSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteSyslogSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var defaultSyslogSource = SyslogSource{
+	Name:     "firewalls",
+	Category: "network/firewall",
+	Protocol: SyslogProtocolTCP,
+	Port:     514,
+}
+
+func TestCreateSyslogSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/collectors/1/sources" {
+			t.Errorf("Expected request to ‘/collectors/1/sources’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request SyslogSourceRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Unable to unmarshal SyslogSource, got `%s`", body)
+		}
+		if request.Source.SourceType != "Syslog" || request.Source.Protocol != SyslogProtocolTCP || request.Source.Port != 514 {
+			t.Errorf("Expected a TCP Syslog source on port 514, got %+v", request.Source)
+		}
+		request.Source.ID = 2
+		body, _ = json.Marshal(request)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	source, err := c.CreateSyslogSource(1, defaultSyslogSource)
+	if err != nil {
+		t.Errorf("CreateSyslogSource() returned an error: %s", err)
+		return
+	}
+	if source.ID != 2 || !source.Equivalent(SyslogSource{Name: "firewalls", Category: "network/firewall", SourceType: "Syslog", Protocol: "TCP", Port: 514}) {
+		t.Errorf("CreateSyslogSource() returned an unexpected source: %+v", source)
+	}
+}
+
+func TestGetSyslogSourceDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, _, err := c.GetSyslogSource(1, 2); err != ErrSourceNotFound {
+		t.Errorf("GetSyslogSource() expected ErrSourceNotFound, got %v", err)
+	}
+	if err := c.DeleteSyslogSourceIfExists(1, 2); err != nil {
+		t.Errorf("DeleteSyslogSourceIfExists() returned an error: %s", err)
+	}
+}
+
+func TestSyslogSourceValidate(t *testing.T) {
+	invalid := map[string]SyslogSource{
+		"protocol": {Name: "syslog", Protocol: "SCTP", Port: 514},
+		"port":     {Name: "syslog", Port: 70000},
+		"timezone": {Name: "syslog", Port: 514, TimeZone: "Mars/Olympus"},
+	}
+	for field, source := range invalid {
+		err := source.Validate()
+		if e, ok := err.(*ValidationError); !ok || e.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+	if err := defaultSyslogSource.Validate(); err != nil {
+		t.Errorf("Validate() returned an error: %s", err)
+	}
+
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if _, err := c.UpdateSyslogSource(1, defaultSyslogSource, ""); err != ErrMissingETag {
+		t.Errorf("UpdateSyslogSource() expected ErrMissingETag, got %v", err)
+	}
+}
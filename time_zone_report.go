@@ -0,0 +1,73 @@
+package sumologic
+
+// defaultTimeZone is the time zone Sumo Logic applies when neither a source nor its collector
+// sets one.
+const defaultTimeZone = "UTC"
+
+// TimeZoneReport reports which sources of a collector set their own time zone and which
+// inherit the collector's, e.g. for audits requiring all sources to use UTC.
+type TimeZoneReport struct {
+	Collector Collector
+	Sources   []SourceTimeZone
+}
+
+// SourceTimeZone is the time zone of one source in a TimeZoneReport.
+type SourceTimeZone struct {
+	Source Source
+	// Inherited is true if the source doesn't set its own time zone.
+	Inherited bool
+	// Effective is the time zone applied to the source's messages: its own, the collector's
+	// if it inherits it, or UTC if neither is set.
+	Effective string
+}
+
+// CollectorTimeZoneReport reports the time zones of the sources of the collector with the
+// specified ID.
+func (s *Client) CollectorTimeZoneReport(collectorID int) (*TimeZoneReport, error) {
+	collector, _, err := s.GetHostedCollector(collectorID)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.ListSources(collectorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TimeZoneReport{Collector: *collector, Sources: make([]SourceTimeZone, 0, len(sources))}
+	for _, source := range sources {
+		timeZone := SourceTimeZone{Source: source, Inherited: source.TimeZone == "", Effective: source.TimeZone}
+		if timeZone.Inherited {
+			timeZone.Effective = collector.TimeZone
+		}
+		if timeZone.Effective == "" {
+			timeZone.Effective = defaultTimeZone
+		}
+		report.Sources = append(report.Sources, timeZone)
+	}
+	return report, nil
+}
+
+// Overriding returns the sources that set their own time zone.
+func (r TimeZoneReport) Overriding() []SourceTimeZone {
+	return r.filter(func(s SourceTimeZone) bool { return !s.Inherited })
+}
+
+// Inheriting returns the sources that inherit the collector's time zone.
+func (r TimeZoneReport) Inheriting() []SourceTimeZone {
+	return r.filter(func(s SourceTimeZone) bool { return s.Inherited })
+}
+
+// NotIn returns the sources whose effective time zone isn't timeZone, e.g. `UTC`.
+func (r TimeZoneReport) NotIn(timeZone string) []SourceTimeZone {
+	return r.filter(func(s SourceTimeZone) bool { return s.Effective != timeZone })
+}
+
+func (r TimeZoneReport) filter(include func(SourceTimeZone) bool) []SourceTimeZone {
+	sources := []SourceTimeZone{}
+	for _, s := range r.Sources {
+		if include(s) {
+			sources = append(sources, s)
+		}
+	}
+	return sources
+}
@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectorTimeZoneReport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/collectors/1":
+			w.Write([]byte(`{"collector":{"id":1,"name":"collector","timezone":"America/New_York"}}`))
+		case "/collectors/1/sources":
+			w.Write([]byte(`{"sources":[
+				{"id":2,"name":"inherits","sourceType":"HTTP"},
+				{"id":3,"name":"utc","sourceType":"HTTP","timezone":"UTC"},
+				{"id":4,"name":"london","sourceType":"HTTP","timezone":"Europe/London"}
+			]}`))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	report, err := c.CollectorTimeZoneReport(1)
+	if err != nil {
+		t.Errorf("CollectorTimeZoneReport() returned an error: %s", err)
+		return
+	}
+	if len(report.Sources) != 3 || report.Sources[0].Effective != "America/New_York" {
+		t.Errorf("CollectorTimeZoneReport() returned an unexpected report: %+v", report)
+		return
+	}
+	if inheriting := report.Inheriting(); len(inheriting) != 1 || inheriting[0].Source.ID != 2 {
+		t.Errorf("Inheriting() expected source 2, got %+v", inheriting)
+	}
+	if overriding := report.Overriding(); len(overriding) != 2 {
+		t.Errorf("Overriding() expected sources 3 and 4, got %+v", overriding)
+	}
+	if notUTC := report.NotIn("UTC"); len(notUTC) != 2 || notUTC[0].Source.ID != 2 || notUTC[1].Source.ID != 4 {
+		t.Errorf("NotIn() expected sources 2 and 4, got %+v", notUTC)
+	}
+}
+
+func TestCollectorTimeZoneReportDefaultsToUTC(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/collectors/1" {
+			w.Write([]byte(`{"collector":{"id":1,"name":"collector"}}`))
+			return
+		}
+		w.Write([]byte(`{"sources":[{"id":2,"name":"inherits","sourceType":"HTTP"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	report, err := c.CollectorTimeZoneReport(1)
+	if err != nil {
+		t.Errorf("CollectorTimeZoneReport() returned an error: %s", err)
+		return
+	}
+	if notUTC := report.NotIn("UTC"); len(notUTC) != 0 {
+		t.Errorf("NotIn() expected no sources without time zones, got %+v", notUTC)
+	}
+}
@@ -0,0 +1,26 @@
+package sumologic
+
+//go:generate ./scripts/gentimezones.sh
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidTimeZones returns the IANA time zone names accepted as the time zone of collectors
+// and sources, sorted.
+func ValidTimeZones() []string {
+	return append([]string(nil), timeZones...)
+}
+
+// ValidateTimeZone checks that timeZone is an IANA time zone name, e.g. `America/New_York`.
+// An empty time zone is valid and leaves the default in place.
+func ValidateTimeZone(timeZone string) error {
+	if timeZone == "" {
+		return nil
+	}
+	if i := sort.SearchStrings(timeZones, timeZone); i < len(timeZones) && timeZones[i] == timeZone {
+		return nil
+	}
+	return &ValidationError{Field: "timezone", Message: fmt.Sprintf("`%s` is not an IANA time zone name, e.g. `America/New_York` or `UTC`", timeZone)}
+}
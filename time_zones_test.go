@@ -0,0 +1,49 @@
+package sumologic
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestValidTimeZones(t *testing.T) {
+	zones := ValidTimeZones()
+	if !sort.StringsAreSorted(zones) {
+		t.Errorf("ValidTimeZones() expected sorted time zones")
+	}
+	for _, zone := range []string{"UTC", "Etc/UTC", "America/New_York", "Europe/Berlin", "US/Pacific"} {
+		if err := ValidateTimeZone(zone); err != nil {
+			t.Errorf("ValidateTimeZone(%s) returned an error: %s", zone, err)
+		}
+	}
+	if err := ValidateTimeZone(""); err != nil {
+		t.Errorf("ValidateTimeZone() returned an error for an empty time zone: %s", err)
+	}
+
+	zones[0] = "Invalid"
+	if ValidTimeZones()[0] == "Invalid" {
+		t.Errorf("ValidTimeZones() expected a copy of the time zones")
+	}
+}
+
+func TestInvalidTimeZone(t *testing.T) {
+	for _, zone := range []string{"PST", "america/new_york", "Mars/Olympus_Mons"} {
+		err := ValidateTimeZone(zone)
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != "timezone" {
+			t.Errorf("ValidateTimeZone(%s) expected a timezone ValidationError, got %v", zone, err)
+		}
+	}
+
+	c, err := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	_, err = c.CreateHostedCollector(Collector{Name: "collector", TimeZone: "PST"})
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "timezone" {
+		t.Errorf("CreateHostedCollector() expected a timezone ValidationError, got %v", err)
+	}
+	_, err = c.CreateHTTPSource(1, HTTPSource{Name: "source", TimeZone: "PST"})
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "timezone" {
+		t.Errorf("CreateHTTPSource() expected a timezone ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+// ErrTransient matches, with errors.Is, every *TransientError returned by the client.
+var ErrTransient = errors.New("Transient network error with Sumo Logic")
+
+// TransientError is returned when a request fails with a network error that is likely to
+// succeed if retried, such as a DNS timeout, a refused or reset connection or a timeout.
+// It distinguishes infrastructure flakiness from configuration errors (such as an unknown
+// host) and from errors returned by the API itself.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return ErrTransient.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying network error.
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTransient.
+func (e *TransientError) Is(target error) bool {
+	return target == ErrTransient
+}
+
+// WithTransientRetry retries requests that fail with a TransientError according to policy.
+// Retries are independent of any retries made for HTTP status codes, and requests whose
+// body can't be replayed are never retried. POST requests, such as creates, are only retried
+// if the connection couldn't be established, since otherwise the server may have processed
+// the request and a retry could create a duplicate. WithRequestPolicy can override policy for some
+// types of resources.
+func WithTransientRetry(policy backoff.Policy) ClientOption {
+	return func(s *Client) error {
+		s.transientRetry = &policy
+		return nil
+	}
+}
+
+// classifyTransportError wraps err in a *TransientError if it's a transient network error.
+func classifyTransportError(err error) error {
+	if isTransientNetworkError(err) {
+		return &TransientError{Err: err}
+	}
+	return err
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// retrySafe reports whether a request with method that failed with err can be sent again
+// without the risk of the server processing it twice: requests with idempotent methods always
+// can, others only if they failed before a connection was established.
+func retrySafe(method string, err error) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// rewindBody replaces the body of req, which has been sent, with a fresh copy so the request
+// can be sent again. It reports false if the body can't be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// retryTransient reports whether a request that failed with err on the given attempt should
+// be retried, and if so rewinds its body and waits for the retry delay.
+func (s *Client) retryTransient(req *http.Request, err error, attempt int) bool {
+	policy := s.transientRetryPolicy(req)
+	if policy == nil || !errors.Is(err, ErrTransient) || !retrySafe(req.Method, err) {
+		return false
+	}
+	if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+		return false
+	}
+	if !rewindBody(req) {
+		return false
+	}
+
+	timer := time.NewTimer(policy.Delay(attempt))
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
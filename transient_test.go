@@ -0,0 +1,117 @@
+package sumologic
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go/backoff"
+)
+
+func TestTransientErrorClassification(t *testing.T) {
+	cases := []struct {
+		err       error
+		transient bool
+	}{
+		{&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, true},
+		{&net.DNSError{Err: "i/o timeout", Name: "api.sumologic.com", IsTimeout: true}, true},
+		{&net.DNSError{Err: "no such host", Name: "api.sumologic.con", IsNotFound: true}, false},
+		{errors.New("x509: certificate signed by unknown authority"), false},
+	}
+
+	for _, c := range cases {
+		err := classifyTransportError(c.err)
+		if errors.Is(err, ErrTransient) != c.transient {
+			t.Errorf("classifyTransportError(%v) expected transient to be %t", c.err, c.transient)
+		}
+		if !errors.Is(err, c.err) {
+			t.Errorf("classifyTransportError(%v) did not wrap the original error", c.err)
+		}
+	}
+}
+
+func TestTransientErrorConnectionRefused(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	client, _ := NewClient("accessToken", ts.URL)
+	_, _, err := client.GetHostedCollector(1)
+
+	var transient *TransientError
+	if !errors.As(err, &transient) {
+		t.Errorf("GetHostedCollector() expected a TransientError, got %v", err)
+	}
+}
+
+func TestWithTransientRetry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	failures := 2
+	flaky := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if failures > 0 {
+				failures--
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+
+	policy := backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}
+	client, _ := NewClient("accessToken", ts.URL, WithMiddleware(flaky), WithTransientRetry(policy))
+
+	if err := client.DeleteHTTPSource(1, 2); err != nil {
+		t.Errorf("DeleteHTTPSource() returned an error: %s", err)
+		return
+	}
+	if m := client.Metrics()["DELETE collectors/{id}/sources/{id}"]; m.Retries != 2 {
+		t.Errorf("Expected 2 retries to be recorded, got %d", m.Retries)
+	}
+
+	failures = 3
+	if err := client.DeleteHTTPSource(1, 2); !errors.Is(err, ErrTransient) {
+		t.Errorf("DeleteHTTPSource() expected a transient error after exhausting retries, got %v", err)
+	}
+}
+
+func TestWithTransientRetryPOST(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"collector":{"id":1,"name":"collector","collectorType":"Hosted"}}`))
+	}))
+	defer ts.Close()
+
+	var failure error
+	attempts := 0
+	flaky := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, failure
+			}
+			return next.RoundTrip(req)
+		})
+	}
+
+	policy := backoff.Policy{Initial: time.Millisecond, MaxAttempts: 3}
+	client, _ := NewClient("accessToken", ts.URL, WithMiddleware(flaky), WithTransientRetry(policy))
+
+	failure = &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	if _, err := client.CreateHostedCollector(Collector{Name: "collector"}); !errors.Is(err, ErrTransient) || attempts != 1 {
+		t.Errorf("CreateHostedCollector() expected no retry after a read timeout, got %d attempts and %v", attempts, err)
+	}
+
+	attempts = 0
+	failure = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	if _, err := client.CreateHostedCollector(Collector{Name: "collector"}); err != nil || attempts != 2 {
+		t.Errorf("CreateHostedCollector() expected a retry after a refused connection, got %d attempts and %v", attempts, err)
+	}
+}
@@ -0,0 +1,136 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// UpsertHostedCollector converges the hosted collector named collector.Name to collector: it
+// creates the collector if there is none with that name, updates it if a field set on
+// collector differs and leaves it alone otherwise. Fields left at their zero value, which
+// Sumo Logic fills with defaults such as the time zone, are only compared if listed in
+// ForceSendFields. It returns the collector and an OperationResult whose Outcome is
+// OperationCreated, OperationUpdated or OperationSkipped. An error is returned if an installed
+// collector has the name.
+//
+// The update is sent with the ETag of the collector as it was read, so a concurrent change
+// fails with ErrPreconditionFailed instead of being overwritten.
+func (s *Client) UpsertHostedCollector(collector Collector) (*Collector, OperationResult, error) {
+	result := OperationResult{ResourceType: ResourceTypeCollector, Name: collector.Name}
+	current, etag, err := s.GetCollectorByName(collector.Name)
+	if err == ErrCollectorNotFound {
+		created, err := s.CreateHostedCollector(collector)
+		if err != nil {
+			return nil, result, err
+		}
+		return created, upserted(result, created.GetID(), OperationCreated), nil
+	}
+	if err != nil {
+		return nil, result, err
+	}
+	if current.CollectorType != CollectorTypeHosted {
+		return nil, result, fmt.Errorf("Collector `%s` is a `%s` collector, not a hosted collector", collector.Name, current.CollectorType)
+	}
+	collector.ID = current.ID
+	if collector.CollectorType == "" {
+		collector.CollectorType = current.CollectorType
+	}
+	if unchanged, err := setFieldsMatch(*current, collector); err != nil || unchanged {
+		return current, upserted(result, current.GetID(), OperationSkipped), err
+	}
+	updated, err := s.UpdateHostedCollector(collector, etag)
+	if err != nil {
+		return nil, result, err
+	}
+	return updated, upserted(result, updated.GetID(), OperationUpdated), nil
+}
+
+// UpsertHTTPSource converges the source named source.Name on the collector with the specified
+// ID to source, like UpsertHostedCollector. An error is returned if a source of another type
+// has the name.
+func (s *Client) UpsertHTTPSource(collectorID int, source HTTPSource) (*HTTPSource, OperationResult, error) {
+	result := OperationResult{ResourceType: ResourceTypeSource, Name: source.Name}
+	existing, err := s.GetSourceByName(collectorID, source.Name)
+	if err == ErrSourceNotFound {
+		created, err := s.CreateHTTPSource(collectorID, source)
+		if err != nil {
+			return nil, result, err
+		}
+		return created, upserted(result, created.GetID(), OperationCreated), nil
+	}
+	if err != nil {
+		return nil, result, err
+	}
+	if existing.SourceType != "HTTP" {
+		return nil, result, fmt.Errorf("Source `%s` on collector `%d` is a `%s` source, not an HTTP source", source.Name, collectorID, existing.SourceType)
+	}
+
+	current, etag, err := s.GetHTTPSource(collectorID, existing.ID)
+	if err != nil {
+		return nil, result, err
+	}
+	source.ID = current.ID
+	if source.SourceType == "" {
+		source.SourceType = current.SourceType
+	}
+	if unchanged, err := setFieldsMatch(*current, source); err != nil || unchanged {
+		return current, upserted(result, current.GetID(), OperationSkipped), err
+	}
+	updated, err := s.UpdateHTTPSource(collectorID, source, etag)
+	if err != nil {
+		return nil, result, err
+	}
+	return updated, upserted(result, updated.GetID(), OperationUpdated), nil
+}
+
+// upserted returns result for the resource with the specified ID and outcome.
+func upserted(result OperationResult, id, outcome string) OperationResult {
+	result.ResourceID = id
+	result.Outcome = outcome
+	return result
+}
+
+// setFieldsMatch reports whether every field of desired's JSON encoding, i.e. every field
+// set or listed in ForceSendFields, has the same value in current's. Fields desired leaves
+// out, such as defaults filled in by Sumo Logic, are ignored, and nested objects are compared
+// the same way.
+func setFieldsMatch(current, desired interface{}) (bool, error) {
+	from, err := jsonObject(current)
+	if err != nil {
+		return false, err
+	}
+	to, err := jsonObject(desired)
+	if err != nil {
+		return false, err
+	}
+	return objectContains(from, to), nil
+}
+
+// jsonObject returns the JSON encoding of v decoded as an object.
+func jsonObject(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	return object, json.Unmarshal(data, &object)
+}
+
+// objectContains reports whether every field of desired has the same value in current.
+func objectContains(current, desired map[string]interface{}) bool {
+	for name, value := range desired {
+		object, isObject := value.(map[string]interface{})
+		currentObject, currentIsObject := current[name].(map[string]interface{})
+		if isObject && currentIsObject {
+			if !objectContains(currentObject, object) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(current[name], value) {
+			return false
+		}
+	}
+	return true
+}
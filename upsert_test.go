@@ -0,0 +1,153 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// upsertServer is a fake collectors API holding a single collector with one HTTP source.
+type upsertServer struct {
+	t         *testing.T
+	collector *Collector
+	source    *HTTPSource
+	writes    []string
+}
+
+func (f *upsertServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response interface{}
+	switch {
+	case r.Method == "GET" && r.URL.EscapedPath() == "/collectors/name/payments":
+		if f.collector == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"c1"`)
+		response = CollectorRequest{Collector: *f.collector}
+	case r.Method == "POST" && r.URL.EscapedPath() == "/collectors":
+		body, _ := io.ReadAll(r.Body)
+		cr := new(CollectorRequest)
+		json.Unmarshal(body, &cr)
+		// Sumo Logic fills in defaults for fields the request leaves out.
+		cr.Collector.ID, cr.Collector.CollectorType, cr.Collector.TimeZone = 1, "Hosted", "Etc/UTC"
+		f.collector = &cr.Collector
+		f.writes = append(f.writes, "POST collector")
+		response = cr
+	case r.Method == "PUT" && r.URL.EscapedPath() == "/collectors/1":
+		if r.Header.Get("If-Match") != `"c1"` {
+			f.t.Errorf("Expected If-Match ‘\"c1\"’, got ‘%s’", r.Header.Get("If-Match"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		cr := new(CollectorRequest)
+		json.Unmarshal(body, &cr)
+		f.collector = &cr.Collector
+		f.writes = append(f.writes, "PUT collector")
+		response = cr
+	case r.Method == "GET" && r.URL.EscapedPath() == "/collectors/1/sources":
+		sources := []HTTPSource{}
+		if f.source != nil {
+			sources = append(sources, *f.source)
+		}
+		response = map[string]interface{}{"sources": sources}
+	case r.Method == "POST" && r.URL.EscapedPath() == "/collectors/1/sources":
+		body, _ := io.ReadAll(r.Body)
+		sr := new(HTTPSourceRequest)
+		json.Unmarshal(body, &sr)
+		sr.Source.ID, sr.Source.SourceType, sr.Source.Url = 2, "HTTP", "https://endpoint.example.com/receiver/v1/http/secret"
+		sr.Source.TimeZone, sr.Source.AutomaticDateParsing = "Etc/UTC", true
+		f.source = &sr.Source
+		f.writes = append(f.writes, "POST source")
+		response = sr
+	case r.Method == "GET" && r.URL.EscapedPath() == "/collectors/1/sources/2":
+		w.Header().Set("ETag", `"s2"`)
+		response = HTTPSourceRequest{Source: *f.source}
+	case r.Method == "PUT" && r.URL.EscapedPath() == "/collectors/1/sources/2":
+		body, _ := io.ReadAll(r.Body)
+		sr := new(HTTPSourceRequest)
+		json.Unmarshal(body, &sr)
+		f.source = &sr.Source
+		f.writes = append(f.writes, "PUT source")
+		response = sr
+	default:
+		f.t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, _ := json.Marshal(response)
+	w.Write(body)
+}
+
+func TestUpsertHostedCollector(t *testing.T) {
+	fake := &upsertServer{t: t}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	desired := Collector{Name: "payments", Category: "prod/payments"}
+	for i, expected := range []string{OperationCreated, OperationSkipped} {
+		collector, outcome, err := c.UpsertHostedCollector(desired)
+		if err != nil {
+			t.Errorf("UpsertHostedCollector() #%d returned an error: %s", i, err)
+			return
+		}
+		if outcome.Outcome != expected || outcome.ResourceID != "1" || collector.ID != 1 {
+			t.Errorf("UpsertHostedCollector() #%d expected %s collector 1, got %+v collector %d", i, expected, outcome, collector.ID)
+		}
+	}
+
+	desired.Category = "prod/billing"
+	collector, outcome, err := c.UpsertHostedCollector(desired)
+	if err != nil || outcome.Outcome != OperationUpdated || collector.Category != "prod/billing" {
+		t.Errorf("UpsertHostedCollector() expected an update, got %+v %+v and %v", outcome, collector, err)
+	}
+	if len(fake.writes) != 2 {
+		t.Errorf("UpsertHostedCollector() expected a create and an update, got %v", fake.writes)
+	}
+
+	fake.collector.CollectorType = "Installable"
+	if _, _, err := c.UpsertHostedCollector(desired); err == nil {
+		t.Errorf("UpsertHostedCollector() expected an error for an installed collector")
+	}
+	if len(fake.writes) != 2 {
+		t.Errorf("UpsertHostedCollector() expected an installed collector not to be changed, got %v", fake.writes)
+	}
+}
+
+func TestUpsertHTTPSource(t *testing.T) {
+	fake := &upsertServer{t: t, collector: &Collector{ID: 1, Name: "payments"}}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	desired := HTTPSource{Name: "api", Category: "prod/payments/api"}
+	for i, expected := range []string{OperationCreated, OperationSkipped} {
+		source, outcome, err := c.UpsertHTTPSource(1, desired)
+		if err != nil {
+			t.Errorf("UpsertHTTPSource() #%d returned an error: %s", i, err)
+			return
+		}
+		if outcome.Outcome != expected || outcome.ResourceType != ResourceTypeSource || source.ID != 2 {
+			t.Errorf("UpsertHTTPSource() #%d expected %s source 2, got %+v source %d", i, expected, outcome, source.ID)
+		}
+	}
+
+	desired.MessagePerRequest = true
+	if _, outcome, err := c.UpsertHTTPSource(1, desired); err != nil || outcome.Outcome != OperationUpdated {
+		t.Errorf("UpsertHTTPSource() expected an update, got %+v and %v", outcome, err)
+	}
+	if len(fake.writes) != 2 || fake.writes[1] != "PUT source" {
+		t.Errorf("UpsertHTTPSource() expected a create and an update, got %v", fake.writes)
+	}
+}
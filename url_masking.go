@@ -0,0 +1,82 @@
+package sumologic
+
+import (
+	"net/url"
+	"strings"
+)
+
+// maskedSecret replaces the secret part of masked ingestion URLs and tokens.
+const maskedSecret = "********"
+
+// WithMaskedSourceURLs masks the ingestion URL and token of HTTP sources, the URL of GCP and
+// Kinesis log sources and the token of cloud syslog sources returned by the client, as with
+// MaskIngestionURL, so that these secrets don't end up in logs or state files by accident.
+// GetHTTPSourceURL, GetGCPSourceURL, GetKinesisLogSourceURL and GetCloudSyslogSourceToken
+// return the unmasked values.
+func WithMaskedSourceURLs() ClientOption {
+	return func(s *Client) error {
+		s.maskSourceURLs = true
+		return nil
+	}
+}
+
+// MaskIngestionURL replaces the secret token at the end of an ingestion URL, keeping the
+// host and path prefix so the URL can still be told apart in logs, e.g.
+// `https://endpoint1.collection.sumologic.com/receiver/v1/http/********`.
+func MaskIngestionURL(ingestionURL string) string {
+	if ingestionURL == "" {
+		return ""
+	}
+	u, err := url.Parse(ingestionURL)
+	if err != nil || u.Host == "" {
+		return maskedSecret
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	if i := strings.LastIndex(u.Path, "/"); i >= 0 {
+		u.Path = u.Path[:i+1]
+	}
+	return u.String() + maskedSecret
+}
+
+// maskHTTPSource masks the source's URL and token if the client masks source URLs.
+func (s *Client) maskHTTPSource(source *HTTPSource) {
+	if !s.maskSourceURLs {
+		return
+	}
+	source.Url = MaskIngestionURL(source.Url)
+	if source.Token != "" {
+		source.Token = maskedSecret
+	}
+}
+
+// maskCloudSyslogSource masks the source's token if the client masks source URLs.
+func (s *Client) maskCloudSyslogSource(source *CloudSyslogSource) {
+	if s.maskSourceURLs && source.Token != "" {
+		source.Token = maskedSecret
+	}
+}
+
+// maskGCPSource masks the source's URL if the client masks source URLs.
+func (s *Client) maskGCPSource(source *GCPSource) {
+	if s.maskSourceURLs {
+		source.Url = MaskIngestionURL(source.Url)
+	}
+}
+
+// maskKinesisLogSource masks the source's URL if the client masks source URLs.
+func (s *Client) maskKinesisLogSource(source *KinesisLogSource) {
+	if s.maskSourceURLs {
+		source.Url = MaskIngestionURL(source.Url)
+	}
+}
+
+// unmaskHTTPSource clears a masked URL and token, so they aren't sent back to Sumo Logic.
+func unmaskHTTPSource(source *HTTPSource) {
+	if strings.HasSuffix(source.Url, maskedSecret) {
+		source.Url = ""
+	}
+	if source.Token == maskedSecret {
+		source.Token = ""
+	}
+}
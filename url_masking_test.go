@@ -0,0 +1,59 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testIngestionURL = "https://endpoint1.collection.sumologic.com/receiver/v1/http/ZaVnC4dhaV3uTKlva6zCbyM3p5FFtJ4rQ=="
+
+func TestMaskIngestionURL(t *testing.T) {
+	cases := map[string]string{
+		testIngestionURL: "https://endpoint1.collection.sumologic.com/receiver/v1/http/********",
+		"":               "",
+		"not a url":      "********",
+	}
+	for u, expected := range cases {
+		if masked := MaskIngestionURL(u); masked != expected {
+			t.Errorf("MaskIngestionURL(%q) expected ‘%s’, got ‘%s’", u, expected, masked)
+		}
+	}
+}
+
+func TestWithMaskedSourceURLs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sr HTTPSourceRequest
+		if r.Method == "PUT" {
+			json.NewDecoder(r.Body).Decode(&sr)
+			if sr.Source.Url != "" || sr.Source.Token != "" {
+				t.Errorf("Expected the masked URL and token not to be sent, got ‘%s’ and ‘%s’", sr.Source.Url, sr.Source.Token)
+			}
+		}
+		w.Header().Set("ETag", "etag")
+		body, _ := json.Marshal(HTTPSourceRequest{Source: HTTPSource{ID: 2, Name: "http", Url: testIngestionURL, Token: "secret"}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithMaskedSourceURLs())
+
+	source, etag, err := c.GetHTTPSource(1, 2)
+	if err != nil {
+		t.Errorf("GetHTTPSource() returned an error: %s", err)
+		return
+	}
+	if source.Url != MaskIngestionURL(testIngestionURL) || source.Token != maskedSecret {
+		t.Errorf("GetHTTPSource() expected the URL and token to be masked, got ‘%s’ and ‘%s’", source.Url, source.Token)
+	}
+
+	if _, err := c.UpdateHTTPSource(1, *source, etag); err != nil {
+		t.Errorf("UpdateHTTPSource() returned an error: %s", err)
+	}
+
+	u, err := c.GetHTTPSourceURL(1, 2)
+	if err != nil || u != testIngestionURL {
+		t.Errorf("GetHTTPSourceURL() expected the unmasked URL, got ‘%s’ (%v)", u, err)
+	}
+}
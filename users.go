@@ -0,0 +1,92 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// User is a user of the Sumo Logic organization.
+type User struct {
+	ID                 string   `json:"id,omitempty"`
+	FirstName          string   `json:"firstName"`
+	LastName           string   `json:"lastName"`
+	Email              string   `json:"email"`
+	RoleIDs            []string `json:"roleIds"`
+	IsActive           bool     `json:"isActive,omitempty"`
+	IsLocked           bool     `json:"isLocked,omitempty"`
+	IsMfaEnabled       bool     `json:"isMfaEnabled,omitempty"`
+	LastLoginTimestamp string   `json:"lastLoginTimestamp,omitempty"`
+	CreatedAt          string   `json:"createdAt,omitempty"`
+	CreatedBy          string   `json:"createdBy,omitempty"`
+	ModifiedAt         string   `json:"modifiedAt,omitempty"`
+	ModifiedBy         string   `json:"modifiedBy,omitempty"`
+}
+
+// ListUsersOptions are server-side filters for ListUsers. Empty fields are not sent.
+type ListUsersOptions struct {
+	// Email only returns the user with this email address.
+	Email string
+	// SortBy is one of `firstName`, `lastName` or `email`.
+	SortBy string
+}
+
+// ErrUserNotFound is returned when a user doesn't exist.
+var ErrUserNotFound = errors.New("User not found")
+
+// ListUsers returns all users matching the options, following pagination transparently.
+func (s *Client) ListUsers(options ListUsersOptions) ([]User, error) {
+	users := []User{}
+	err := s.listAllPages("users", options.query(), func(data json.RawMessage) error {
+		var page []User
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		users = append(users, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListUsersPage returns the page of users matching the options at cursor, and the cursor of
+// the next page, which is empty after the last page.
+func (s *Client) ListUsersPage(options ListUsersOptions, cursor Cursor) ([]User, Cursor, error) {
+	data, next, err := s.listTokenPage("users", options.query(), cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var page []User
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, "", err
+	}
+	return page, next, nil
+}
+
+func (options ListUsersOptions) query() url.Values {
+	query := url.Values{}
+	if options.Email != "" {
+		query.Set("email", options.Email)
+	}
+	if options.SortBy != "" {
+		query.Set("sortBy", options.SortBy)
+	}
+	return query
+}
+
+// FindUserByEmail returns the user with the specified email address.
+// ErrUserNotFound is returned if there is no such user.
+func (s *Client) FindUserByEmail(email string) (*User, error) {
+	users, err := s.ListUsers(ListUsersOptions{Email: email})
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Email == email {
+			return &users[i], nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
@@ -0,0 +1,204 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListUsersPaginates(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/users" {
+			t.Errorf("Expected request to ‘/users’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("sortBy") != "email" {
+			t.Errorf("Expected sortBy ‘email’, got ‘%s’", r.URL.Query().Get("sortBy"))
+		}
+		var body []byte
+		switch r.URL.Query().Get("token") {
+		case "":
+			body, _ = json.Marshal(map[string]interface{}{
+				"data": []User{{ID: "0000000000000001", Email: "a@example.com"}},
+				"next": "page2",
+			})
+		case "page2":
+			body, _ = json.Marshal(map[string]interface{}{
+				"data": []User{{ID: "0000000000000002", Email: "b@example.com"}},
+			})
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	users, err := c.ListUsers(ListUsersOptions{SortBy: "email"})
+	if err != nil {
+		t.Errorf("ListUsers() returned an error: %s", err)
+		return
+	}
+	if len(users) != 2 || users[1].Email != "b@example.com" {
+		t.Errorf("ListUsers() expected users from both pages, got %+v", users)
+		return
+	}
+}
+
+func TestListUsersWithoutData(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	users, err := c.ListUsers(ListUsersOptions{})
+	if err != nil {
+		t.Errorf("ListUsers() returned an error: %s", err)
+		return
+	}
+	if len(users) != 0 {
+		t.Errorf("ListUsers() expected no users, got %+v", users)
+	}
+}
+
+func TestListUsersRepeatedToken(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		next := "page2"
+		if r.URL.Query().Get("token") == "page2" {
+			next = "page3"
+		} else if r.URL.Query().Get("token") == "page3" {
+			next = "page2"
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"data": []User{{ID: "0000000000000001", Email: "a@example.com"}},
+			"next": next,
+		})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.ListUsers(ListUsersOptions{})
+	if err != ErrRepeatedPageToken {
+		t.Errorf("ListUsers() returned ‘%v’, expected ErrRepeatedPageToken", err)
+	}
+	if requests != 3 {
+		t.Errorf("ListUsers() expected to stop after 3 requests, got %d", requests)
+	}
+}
+
+func TestFindUserByEmail(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var users []User
+		if r.URL.Query().Get("email") == "a@example.com" {
+			users = []User{{ID: "0000000000000001", Email: "a@example.com"}}
+		}
+		body, _ := json.Marshal(map[string]interface{}{"data": users})
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	user, err := c.FindUserByEmail("a@example.com")
+	if err != nil {
+		t.Errorf("FindUserByEmail() returned an error: %s", err)
+		return
+	}
+	if user.ID != "0000000000000001" {
+		t.Errorf("FindUserByEmail() expected ID `0000000000000001`, got `%s`", user.ID)
+	}
+
+	_, err = c.FindUserByEmail("missing@example.com")
+	if err != ErrUserNotFound {
+		t.Errorf("FindUserByEmail() returned the wrong error: %s", err)
+	}
+}
+
+func TestListUsersPageResumes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		switch r.URL.Query().Get("token") {
+		case "":
+			body, _ = json.Marshal(map[string]interface{}{
+				"data": []User{{ID: "0000000000000001", Email: "a@example.com"}},
+				"next": "page2",
+			})
+		case "page2":
+			body, _ = json.Marshal(map[string]interface{}{
+				"data": []User{{ID: "0000000000000002", Email: "b@example.com"}},
+			})
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	options := ListUsersOptions{SortBy: "email"}
+	users, cursor, err := c.ListUsersPage(options, "")
+	if err != nil {
+		t.Errorf("ListUsersPage() returned an error: %s", err)
+		return
+	}
+	if len(users) != 1 || cursor == "" {
+		t.Errorf("ListUsersPage() expected the first page and a cursor, got %+v, ‘%s’", users, cursor)
+		return
+	}
+
+	// A fresh client resumes from the persisted cursor.
+	c, _ = NewClient("accessToken", ts.URL)
+	users, cursor, err = c.ListUsersPage(options, cursor)
+	if err != nil {
+		t.Errorf("ListUsersPage() returned an error: %s", err)
+		return
+	}
+	if len(users) != 1 || users[0].Email != "b@example.com" || cursor != "" {
+		t.Errorf("ListUsersPage() expected the last page and no cursor, got %+v, ‘%s’", users, cursor)
+		return
+	}
+
+	_, first, _ := c.ListUsersPage(options, "")
+	if _, _, err := c.ListUsersPage(ListUsersOptions{SortBy: "lastName"}, first); err != ErrInvalidCursor {
+		t.Errorf("ListUsersPage() with another list's cursor expected ErrInvalidCursor, got %v", err)
+	}
+	if _, _, err := c.ListUsersPage(options, "not a cursor"); err != ErrInvalidCursor {
+		t.Errorf("ListUsersPage() with a malformed cursor expected ErrInvalidCursor, got %v", err)
+	}
+}
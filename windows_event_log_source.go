@@ -0,0 +1,194 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WindowsEventLogSourceRequest is a necessary wrapper for source API calls.
+type WindowsEventLogSourceRequest struct {
+	Source WindowsEventLogSource `json:"source"`
+}
+
+// WindowsEventLogSource is a source on an installed collector on Windows that reads the
+// event logs of the collector's host.
+//
+// Optional fields are omitted when they hold their zero value. An update replaces the whole
+// source, so an omitted field takes its server default; start from the source returned by a
+// Get to keep its current settings. To explicitly send a zero value, list the field's JSON
+// name in ForceSendFields.
+type WindowsEventLogSource struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	CollectorID int    `json:"CollectorId,omitempty"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	HostName    string `json:"hostName,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+	SourceType  string `json:"sourceType,omitempty"`
+	// LogNames are the event logs to read, e.g. `Security`, `Application` or `System`.
+	LogNames []string `json:"logNames"`
+	// RenderMessages includes the rendered event messages, not only the event data.
+	RenderMessages bool `json:"renderMessages,omitempty"`
+	// EventFormat is WindowsEventFormatLegacy (the default) or WindowsEventFormatXML.
+	EventFormat          int      `json:"eventFormat,omitempty"`
+	ForceTimeZone        bool     `json:"forceTimeZone,omitempty"`
+	AutomaticDateParsing bool     `json:"automaticDateParsing,omitempty"`
+	CutoffRelativeTime   string   `json:"cutoffRelativeTime,omitempty"`
+	Filters              []Filter `json:"filters,omitempty"`
+	Alive                bool     `json:"alive,omitempty"`
+	// Fields are attached to every message, e.g. FieldSIEMForward.
+	Fields          map[string]string `json:"fields,omitempty"`
+	ForceSendFields []string          `json:"-"`
+}
+
+// Event formats of WindowsEventLogSource, for WindowsEventLogSource.EventFormat.
+const (
+	WindowsEventFormatLegacy = 0
+	WindowsEventFormatXML    = 1
+)
+
+// windowsEventLogSourceType is the sourceType of Windows event log sources.
+const windowsEventLogSourceType = "LocalWindowsEventLog"
+
+// MarshalJSON omits zero-valued optional fields unless they are listed in ForceSendFields.
+func (s WindowsEventLogSource) MarshalJSON() ([]byte, error) {
+	type windowsEventLogSource WindowsEventLogSource
+	return marshalForceSend(windowsEventLogSource(s), s.ForceSendFields)
+}
+
+// UnmarshalJSON also accepts numeric fields encoded as strings, as returned by some API
+// versions.
+func (s *WindowsEventLogSource) UnmarshalJSON(data []byte) error {
+	type windowsEventLogSource WindowsEventLogSource
+	return unmarshalLenient(data, (*windowsEventLogSource)(s))
+}
+
+// Validate checks the source's event logs and format and the fields validated for all
+// sources, without calling the API.
+func (s WindowsEventLogSource) Validate() error {
+	if err := validateNameAndCategory(s.Name, s.Category); err != nil {
+		return err
+	}
+	if err := ValidateTimeZone(s.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFields(s.Fields); err != nil {
+		return err
+	}
+	if len(s.LogNames) == 0 {
+		return &ValidationError{Field: "logNames", Message: "must list at least one event log"}
+	}
+	for _, name := range s.LogNames {
+		if name == "" {
+			return &ValidationError{Field: "logNames", Message: "must not contain empty log names"}
+		}
+	}
+	if s.EventFormat != WindowsEventFormatLegacy && s.EventFormat != WindowsEventFormatXML {
+		return &ValidationError{Field: "eventFormat", Message: fmt.Sprintf("must be %d (legacy) or %d (XML), got %d", WindowsEventFormatLegacy, WindowsEventFormatXML, s.EventFormat)}
+	}
+	return nil
+}
+
+// Equivalent reports whether s and other have the same user-manageable configuration.
+// Fields managed by Sumo Logic (ID, collector ID and liveness) are ignored.
+func (s WindowsEventLogSource) Equivalent(other WindowsEventLogSource) bool {
+	return reflect.DeepEqual(s.userManaged(), other.userManaged())
+}
+
+func (s WindowsEventLogSource) userManaged() WindowsEventLogSource {
+	s.ForceSendFields = nil
+	s.ID = 0
+	s.CollectorID = 0
+	s.Alive = false
+	if len(s.Filters) == 0 {
+		s.Filters = nil
+	}
+	if len(s.Fields) == 0 {
+		s.Fields = nil
+	}
+	return s
+}
+
+// GetID returns the source's ID.
+func (s WindowsEventLogSource) GetID() string { return intResourceID(s.ID) }
+
+// GetName returns the source's name.
+func (s WindowsEventLogSource) GetName() string { return s.Name }
+
+// ResourceType returns ResourceTypeSource.
+func (s WindowsEventLogSource) ResourceType() string { return ResourceTypeSource }
+
+// Endpoint returns the source's API path. It requires CollectorID to be set.
+func (s WindowsEventLogSource) Endpoint() string { return sourceEndpoint(s.CollectorID, s.ID) }
+
+// GetWindowsEventLogSource gets the source with the specified ID.
+func (s *Client) GetWindowsEventLogSource(collectorID int, id int) (*WindowsEventLogSource, string, error) {
+	var r = new(WindowsEventLogSourceRequest)
+	etag, err := s.getSource(collectorID, id, r)
+	if err != nil {
+		return nil, "", err
+	}
+	return &r.Source, etag, nil
+}
+
+// CreateWindowsEventLogSource creates a new WindowsEventLogSource on an installed collector. An empty
+// SourceType is set to `LocalWindowsEventLog`.
+func (s *Client) CreateWindowsEventLogSource(collectorID int, source WindowsEventLogSource) (*WindowsEventLogSource, error) {
+	if source.SourceType == "" {
+		source.SourceType = windowsEventLogSourceType
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(WindowsEventLogSourceRequest)
+	if err := s.createSource(collectorID, source.Name, WindowsEventLogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeCreate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// UpdateWindowsEventLogSource updates an existing Windows event log source.
+// etag must be the ETag returned by the corresponding Get; ErrMissingETag is returned if it is empty
+// and ErrPreconditionFailed if the resource has changed since.
+func (s *Client) UpdateWindowsEventLogSource(collectorID int, source WindowsEventLogSource, etag string) (*WindowsEventLogSource, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	var r = new(WindowsEventLogSourceRequest)
+	if err := s.updateSource(collectorID, source.ID, source.Name, etag, WindowsEventLogSourceRequest{Source: source}, r); err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ChangeUpdate, ResourceTypeSource, r.Source.ID, collectorID, r.Source)
+	return &r.Source, nil
+}
+
+// DeleteWindowsEventLogSource deletes the source with the specified ID.
+func (s *Client) DeleteWindowsEventLogSource(collectorID int, id int) error {
+	return s.deleteSource(context.Background(), collectorID, id, "")
+}
+
+// DeleteWindowsEventLogSourceWithETag deletes the source with the specified ID only if it hasn't changed
+// since the Get that returned etag. ErrPreconditionFailed is returned if it has.
+func (s *Client) DeleteWindowsEventLogSourceWithETag(collectorID int, id int, etag string) error {
+	if etag == "" {
+		return ErrMissingETag
+	}
+	return s.deleteSource(context.Background(), collectorID, id, etag)
+}
+
+// DeleteWindowsEventLogSourceIfExists deletes the source with the specified ID.
+// Unlike DeleteWindowsEventLogSource, a source that doesn't exist is not an error.
+func (s *Client) DeleteWindowsEventLogSourceIfExists(collectorID int, id int) error {
+	err := s.DeleteWindowsEventLogSource(collectorID, id)
+	if err == ErrSourceNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetWindowsEventLogSourceOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", "etag")
+		w.Write([]byte(`{"source":{"id":2,"name":"security","sourceType":"LocalWindowsEventLog","logNames":["Security","System"],"renderMessages":true,"eventFormat":1}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source, etag, err := c.GetWindowsEventLogSource(1, 2)
+	if err != nil {
+		t.Errorf("GetWindowsEventLogSource() returned an error: %s", err)
+		return
+	}
+	if etag != "etag" || len(source.LogNames) != 2 || !source.RenderMessages || source.EventFormat != WindowsEventFormatXML {
+		t.Errorf("GetWindowsEventLogSource() returned an unexpected source: %+v", source)
+	}
+}
+
+func TestWindowsEventLogSourceValidate(t *testing.T) {
+	cases := map[string]WindowsEventLogSource{
+		"logNames":    {Name: "security"},
+		"eventFormat": {Name: "security", LogNames: []string{"Security"}, EventFormat: 2},
+	}
+	for field, source := range cases {
+		err := source.Validate()
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != field {
+			t.Errorf("Validate() expected a ValidationError for `%s`, got %v", field, err)
+		}
+	}
+
+	// renderMessages is omitted unless set or forced, so updates don't reset it.
+	body, _ := json.Marshal(WindowsEventLogSource{Name: "security", LogNames: []string{"Security"}, ForceSendFields: []string{"renderMessages"}})
+	var fields map[string]interface{}
+	json.Unmarshal(body, &fields)
+	if fields["renderMessages"] != false {
+		t.Errorf("Expected renderMessages to be sent as false, got `%s`", body)
+	}
+}
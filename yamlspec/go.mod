@@ -0,0 +1,10 @@
+module github.com/nextgenhealthcare/sumologic-sdk-go/yamlspec
+
+go 1.16
+
+require (
+	github.com/nextgenhealthcare/sumologic-sdk-go v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/nextgenhealthcare/sumologic-sdk-go => ../
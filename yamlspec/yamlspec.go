@@ -0,0 +1,67 @@
+// Package yamlspec reads and writes SDK resources, such as collectors, sources and
+// collector backups, as YAML, for declarative specs that are easier to review and edit than
+// JSON.
+//
+// Values are converted through their JSON encoding, so the JSON field names, omitted empty
+// fields, ForceSendFields and custom JSON decoding of the SDK's types apply to YAML as well
+// and the types don't need YAML tags. Fields are written in the order of their JSON
+// encoding.
+//
+// It is a separate module, with its own go.mod, so that the core SDK doesn't depend on a
+// YAML library.
+package yamlspec
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Marshal returns the YAML encoding of v, e.g. a sumologic.Collector or
+// sumologic.CollectorBackup.
+func Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetStyle(&node)
+
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := e.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Unmarshal decodes the YAML document data into v, as json.Unmarshal would decode the
+// equivalent JSON document.
+func Unmarshal(data []byte, v interface{}) error {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// resetStyle drops the JSON flow style and quoting of a node parsed from JSON, so it's
+// written as block YAML. Strings that would read back as another type stay quoted.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
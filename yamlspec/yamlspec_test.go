@@ -0,0 +1,74 @@
+package yamlspec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nextgenhealthcare/sumologic-sdk-go"
+)
+
+func TestMarshal(t *testing.T) {
+	source := sumologic.HTTPSource{
+		Name:            "http",
+		SourceType:      "HTTP",
+		Category:        "true",
+		Fields:          map[string]string{"port": "8080"},
+		ForceSendFields: []string{"multilineProcessingEnabled"},
+	}
+	data, err := Marshal(source)
+	if err != nil {
+		t.Errorf("Marshal() returned an error: %s", err)
+		return
+	}
+	expected := `category: "true"
+fields:
+  port: "8080"
+multilineProcessingEnabled: false
+name: http
+sourceType: HTTP
+`
+	if string(data) != expected {
+		t.Errorf("Marshal() expected:\n%s\ngot:\n%s", expected, data)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	source := json.RawMessage(`{"id":2,"name":"http","sourceType":"HTTP","filters":[{"name":"drop","filterType":"Exclude","regexp":".*debug.*"}]}`)
+	backup := sumologic.CollectorBackup{
+		SchemaVersion: sumologic.CollectorBackupSchemaVersion,
+		Collector:     sumologic.Collector{ID: 1, Name: "collector", CollectorType: sumologic.CollectorTypeHosted, TimeZone: "UTC"},
+		Sources:       []json.RawMessage{source},
+	}
+	data, err := Marshal(backup)
+	if err != nil {
+		t.Errorf("Marshal() returned an error: %s", err)
+		return
+	}
+
+	var decoded sumologic.CollectorBackup
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Unmarshal() returned an error: %s", err)
+		return
+	}
+	if decoded.SchemaVersion != backup.SchemaVersion || !decoded.Collector.Equivalent(backup.Collector) || decoded.Collector.ID != 1 {
+		t.Errorf("Unmarshal() returned an unexpected backup: %+v", decoded)
+	}
+	var s sumologic.Source
+	if len(decoded.Sources) != 1 || json.Unmarshal(decoded.Sources[0], &s) != nil || s.Name != "http" {
+		t.Errorf("Unmarshal() expected the source to round-trip, got %s", decoded.Sources)
+	}
+	var httpSource sumologic.HTTPSource
+	if err := s.Decode(&httpSource); err != nil || len(httpSource.Filters) != 1 || httpSource.Filters[0].Regexp != ".*debug.*" {
+		t.Errorf("Unmarshal() expected the source filters to round-trip, got %+v and %v", httpSource.Filters, err)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	var c sumologic.Collector
+	if err := Unmarshal([]byte("name: [unterminated"), &c); err == nil {
+		t.Errorf("Unmarshal() expected an error for invalid YAML")
+	}
+	if err := Unmarshal([]byte("name:\n  nested: true\n"), &c); err == nil {
+		t.Errorf("Unmarshal() expected an error for a mapping where a string is expected")
+	}
+}